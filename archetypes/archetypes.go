@@ -96,6 +96,12 @@ var (
 		components.FinishLine,
 		components.Object,
 	)
+	Reward = newArchetype(
+		tags.Reward,
+		components.Reward,
+		components.Object,
+		components.Sprite,
+	)
 )
 
 type archetype struct {
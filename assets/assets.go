@@ -5,7 +5,11 @@ import (
 	"embed"
 	"fmt"
 	"image"
+	"io/fs"
+	"os"
 	"path/filepath"
+	"strings"
+	"sync"
 
 	"github.com/automoto/doomerang/config"
 	"github.com/hajimehoshi/ebiten/v2"
@@ -16,18 +20,59 @@ import (
 )
 
 var (
-	//go:embed all:levels all:chunks
+	//go:embed all:levels all:chunks all:enemies
 	assetFS embed.FS
 
+	// devFS replaces assetFS when assets are read from disk, see UseAssetDir
+	devFS fs.FS
+
 	//go:embed all:images
 	animationFS embed.FS
 )
 
-// GetAssetFS returns the embedded filesystem containing levels and chunks
-func GetAssetFS() embed.FS {
+// GetAssetFS returns the filesystem containing levels, chunks and enemy
+// definitions: the embedded one, or the asset directory set with UseAssetDir,
+// with the active level pack layered on top
+func GetAssetFS() fs.FS {
+	if activePack != nil {
+		return activePack.FS(gameAssetFS())
+	}
+	return gameAssetFS()
+}
+
+// gameAssetFS returns the game's own assets, without any level pack
+func gameAssetFS() fs.FS {
+	if devFS != nil {
+		return devFS
+	}
 	return assetFS
 }
 
+// UseAssetDir reads levels, chunks and enemy definitions from dir on disk
+// instead of the copies embedded at build time, so edits are picked up the
+// next time a file is loaded.
+func UseAssetDir(dir string) error {
+	if _, err := os.Stat(filepath.Join(dir, "chunks")); err != nil {
+		return fmt.Errorf("%s is not an asset directory: %w", dir, err)
+	}
+	devFS = os.DirFS(dir)
+	return nil
+}
+
+// loadEnemyTypes applies the asset tree's enemy definitions once per process
+var loadEnemyTypes = sync.OnceValue(func() error {
+	return config.LoadEnemyTypes(GetAssetFS(), "enemies")
+})
+
+// LoadEnemyTypes adds the enemy definitions in the asset tree to
+// config.Enemy.Types. Everything that generates runs calls it first, since
+// the types decide which enemies a seed places. Only the first call reads
+// the definitions; later ones return its result, so types loaded on top
+// afterwards are kept.
+func LoadEnemyTypes() error {
+	return loadEnemyTypes()
+}
+
 type PlayerSpawn struct {
 	X          float64
 	Y          float64
@@ -45,11 +90,24 @@ type Level struct {
 	Fires        []FireSpawn
 	Messages     []MessageSpawn
 	FinishLines  []FinishLineSpawn
+	Rewards      []RewardSpawn
+	Rooms        []Room // Generated levels only: bounds of each placed chunk
 	Name         string
 	Width        int
 	Height       int
 }
 
+// Room is the world-space area covered by one chunk of a generated level
+type Room struct {
+	X, Y, Width, Height float64
+	ChunkID             string
+}
+
+// Contains reports whether world point (x, y) is inside the room
+func (r Room) Contains(x, y float64) bool {
+	return x >= r.X && x < r.X+r.Width && y >= r.Y && y < r.Y+r.Height
+}
+
 // SolidTile represents a solid collision tile
 type SolidTile struct {
 	X, Y, Width, Height float64
@@ -87,10 +145,24 @@ type FinishLineSpawn struct {
 	X, Y, Width, Height float64
 }
 
-type LevelLoader struct{}
+type RewardSpawn struct {
+	X, Y, Width, Height float64
+	RewardType          string // "health", or a boomerang modifier
+}
 
+// LevelLoader loads campaign levels from a filesystem
+type LevelLoader struct {
+	fs fs.FS
+}
+
+// NewLevelLoader creates a LevelLoader using the asset filesystem
 func NewLevelLoader() *LevelLoader {
-	return &LevelLoader{}
+	return &LevelLoader{fs: GetAssetFS()}
+}
+
+// NewLevelLoaderWithFS creates a LevelLoader reading levels from fsys
+func NewLevelLoaderWithFS(fsys fs.FS) *LevelLoader {
+	return &LevelLoader{fs: fsys}
 }
 
 type Path struct {
@@ -168,20 +240,34 @@ func GetFrame(dir string, state config.StateID, frameIndex int, srcRect image.Re
 	return animationLoader.GetFrame(dir, state, frameIndex, srcRect)
 }
 
-func (l *LevelLoader) MustLoadLevels() []Level {
-	entries, err := assetFS.ReadDir("levels")
+// LevelPaths returns the TMX paths of all campaign levels in load order.
+// Cheaper than MustLoadLevels when only the level list is needed (e.g. level select).
+func (l *LevelLoader) LevelPaths() ([]string, error) {
+	entries, err := fs.ReadDir(l.fs, "levels")
 	if err != nil {
-		panic(fmt.Sprintf("Failed to read levels directory: %v", err))
+		return nil, err
 	}
 
-	var levels []Level
+	var paths []string
 	for _, entry := range entries {
 		if !entry.IsDir() && filepath.Ext(entry.Name()) == ".tmx" {
-			levelPath := filepath.Join("levels", entry.Name())
-			level := l.MustLoadLevel(levelPath)
-			levels = append(levels, level)
+			paths = append(paths, filepath.Join("levels", entry.Name()))
 		}
 	}
+	return paths, nil
+}
+
+func (l *LevelLoader) MustLoadLevels() []Level {
+	paths, err := l.LevelPaths()
+	if err != nil {
+		panic(fmt.Sprintf("Failed to read levels directory: %v", err))
+	}
+
+	var levels []Level
+	for _, levelPath := range paths {
+		level := l.MustLoadLevel(levelPath)
+		levels = append(levels, level)
+	}
 
 	if len(levels) == 0 {
 		panic("No level files found in assets/levels directory")
@@ -190,8 +276,14 @@ func (l *LevelLoader) MustLoadLevels() []Level {
 	return levels
 }
 
+// LevelDisplayName converts a level path like "levels/level1.tmx" into "level1".
+func LevelDisplayName(levelPath string) string {
+	base := filepath.Base(levelPath)
+	return strings.TrimSuffix(base, filepath.Ext(base))
+}
+
 func (l *LevelLoader) MustLoadLevel(levelPath string) Level {
-	levelMap, err := tiled.LoadFile(levelPath, tiled.WithFileSystem(assetFS))
+	levelMap, err := tiled.LoadFile(levelPath, tiled.WithFileSystem(l.fs))
 	if err != nil {
 		panic(err)
 	}
@@ -316,6 +408,16 @@ func (l *LevelLoader) MustLoadLevel(levelPath string) Level {
 					Height: o.Height,
 				})
 			}
+		case "Rewards":
+			for _, o := range og.Objects {
+				level.Rewards = append(level.Rewards, RewardSpawn{
+					X:          o.X,
+					Y:          o.Y,
+					Width:      o.Width,
+					Height:     o.Height,
+					RewardType: o.Properties.GetString("rewardType"),
+				})
+			}
 		}
 	}
 
@@ -364,7 +466,7 @@ func (l *LevelLoader) MustLoadLevel(levelPath string) Level {
 
 		// Load image from embedded filesystem
 		imgPath := filepath.Join("levels", imgLayer.Image.Source)
-		imgBytes, err := assetFS.ReadFile(imgPath)
+		imgBytes, err := fs.ReadFile(l.fs, imgPath)
 		if err != nil {
 			fmt.Printf("Warning: Failed to load image layer %s: %v\n", imgLayer.Name, err)
 			continue
@@ -393,7 +495,7 @@ func (l *LevelLoader) MustLoadLevel(levelPath string) Level {
 	}
 
 	// Create a renderer that uses the embedded filesystem
-	renderer, err := render.NewRendererWithFileSystem(levelMap, assetFS)
+	renderer, err := render.NewRendererWithFileSystem(levelMap, l.fs)
 	if err != nil {
 		panic(fmt.Sprintf("Failed to create renderer: %v", err))
 	}
@@ -5,6 +5,7 @@ import (
 	"embed"
 	"fmt"
 	"io"
+	"io/fs"
 	"path/filepath"
 	"strings"
 
@@ -155,7 +156,7 @@ func (l *AudioLoader) LoadLevelMusic(levelName string) (*audio.Player, string, e
 	// We need to look in assets/levels/{levelName}/music/
 	musicDir := fmt.Sprintf("levels/%s/music", levelName)
 
-	entries, err := assetFS.ReadDir(musicDir)
+	entries, err := fs.ReadDir(GetAssetFS(), musicDir)
 	if err != nil {
 		return nil, "", fmt.Errorf("no music directory for level %s: %w", levelName, err)
 	}
@@ -169,8 +170,8 @@ func (l *AudioLoader) LoadLevelMusic(levelName string) (*audio.Player, string, e
 		if ext == ".ogg" {
 			musicPath := filepath.Join(musicDir, entry.Name())
 
-			// Load from assetFS (levels embed)
-			data, err := assetFS.ReadFile(musicPath)
+			// Load from the level assets, not the audio embed
+			data, err := fs.ReadFile(GetAssetFS(), musicPath)
 			if err != nil {
 				return nil, "", fmt.Errorf("failed to read level music %s: %w", musicPath, err)
 			}
@@ -0,0 +1,199 @@
+package assets
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/lafriks/go-tiled"
+)
+
+// A level pack is a directory of community content laid out like the asset
+// tree: campaign levels in levels/, procgen chunks in chunks/ and anything
+// they reference, such as tilesets, next to them. Files the pack doesn't
+// have are read from the game's own assets, so a pack can reuse the built-in
+// tilesets. A pack's levels replace the campaign; its chunks join the
+// built-in chunk pool unless the manifest asks to replace it.
+const PackManifestFile = "pack.json"
+
+// PackManifest describes a level pack
+type PackManifest struct {
+	Name          string `json:"name"`
+	Author        string `json:"author"`
+	Description   string `json:"description"`
+	Version       string `json:"version"`
+	ReplaceChunks bool   `json:"replaceChunks"` // generate runs from the pack's chunks only
+}
+
+// LevelPack is a level pack found on disk
+type LevelPack struct {
+	ID       string // directory name, stable across sessions
+	Manifest PackManifest
+	Levels   int // campaign levels in the pack
+	Chunks   int // procgen chunks in the pack
+	files    fs.FS
+}
+
+// activePack is the pack GetAssetFS layers over the game's assets, if any
+var activePack *LevelPack
+
+// ActiveLevelPack returns the level pack in use, or nil for the built-in content
+func ActiveLevelPack() *LevelPack {
+	return activePack
+}
+
+// UseLevelPack layers pack over the game's assets for every loader created
+// afterwards. nil goes back to the built-in content.
+func UseLevelPack(pack *LevelPack) {
+	activePack = pack
+}
+
+// FS returns the pack's files layered over the game's assets, as GetAssetFS
+// does while the pack is active
+func (p *LevelPack) FS(base fs.FS) fs.FS {
+	hidden := map[string]bool{}
+	if p.Levels > 0 {
+		hidden["levels"] = true
+	}
+	if p.Manifest.ReplaceChunks {
+		hidden["chunks"] = true
+	}
+	return packFS{pack: p.files, base: base, hidden: hidden}
+}
+
+// LoadLevelPacks finds every pack in dir, sorted by name. Packs that fail to
+// load are logged and skipped so one broken download doesn't hide the rest.
+// A missing dir has no packs.
+func LoadLevelPacks(dir string) []*LevelPack {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			log.Printf("Warning: Could not read level packs: %v", err)
+		}
+		return nil
+	}
+
+	var packs []*LevelPack
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		pack, err := LoadLevelPack(os.DirFS(dir+"/"+entry.Name()), entry.Name())
+		if err != nil {
+			log.Printf("Warning: Skipping level pack %s: %v", entry.Name(), err)
+			continue
+		}
+		packs = append(packs, pack)
+	}
+	sort.Slice(packs, func(i, j int) bool {
+		return strings.ToLower(packs[i].Manifest.Name) < strings.ToLower(packs[j].Manifest.Name)
+	})
+	return packs
+}
+
+// LoadLevelPack reads the pack in fsys and checks its manifest and levels
+func LoadLevelPack(fsys fs.FS, id string) (*LevelPack, error) {
+	data, err := fs.ReadFile(fsys, PackManifestFile)
+	if err != nil {
+		return nil, fmt.Errorf("missing %s: %w", PackManifestFile, err)
+	}
+	pack := &LevelPack{ID: id, files: fsys}
+	if err := json.Unmarshal(data, &pack.Manifest); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", PackManifestFile, err)
+	}
+	if pack.Manifest.Name == "" {
+		pack.Manifest.Name = id
+	}
+
+	levels := tmxFiles(fsys, "levels")
+	pack.Levels = len(levels)
+	pack.Chunks = len(tmxFiles(fsys, "chunks"))
+	if pack.Levels == 0 && pack.Chunks == 0 {
+		return nil, errors.New("no levels/*.tmx or chunks/*.tmx files")
+	}
+	if pack.Manifest.ReplaceChunks && pack.Chunks == 0 {
+		return nil, errors.New("replaceChunks is set but the pack has no chunks")
+	}
+
+	// Parse the levels now so a broken one is reported here instead of
+	// crashing the campaign when it is reached
+	files := pack.FS(gameAssetFS())
+	for _, level := range levels {
+		if _, err := tiled.LoadFile(level, tiled.WithFileSystem(files)); err != nil {
+			return nil, fmt.Errorf("invalid level %s: %w", level, err)
+		}
+	}
+	return pack, nil
+}
+
+// tmxFiles returns the paths of the TMX files directly in dir
+func tmxFiles(fsys fs.FS, dir string) []string {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil
+	}
+	var paths []string
+	for _, entry := range entries {
+		if !entry.IsDir() && path.Ext(entry.Name()) == ".tmx" {
+			paths = append(paths, path.Join(dir, entry.Name()))
+		}
+	}
+	return paths
+}
+
+// packFS reads files from a pack first and the game's assets second. Listing
+// a hidden directory shows only the pack's files in it.
+type packFS struct {
+	pack, base fs.FS
+	hidden     map[string]bool
+}
+
+func (p packFS) Open(name string) (fs.File, error) {
+	f, err := p.pack.Open(name)
+	if err == nil {
+		return f, nil
+	}
+	return p.base.Open(name)
+}
+
+func (p packFS) ReadFile(name string) ([]byte, error) {
+	data, err := fs.ReadFile(p.pack, name)
+	if err == nil {
+		return data, nil
+	}
+	return fs.ReadFile(p.base, name)
+}
+
+func (p packFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	packEntries, packErr := fs.ReadDir(p.pack, name)
+	if packErr == nil && p.hidden[name] {
+		return packEntries, nil
+	}
+	baseEntries, baseErr := fs.ReadDir(p.base, name)
+	if packErr != nil {
+		return baseEntries, baseErr
+	}
+	if baseErr != nil {
+		return packEntries, nil
+	}
+
+	merged := map[string]fs.DirEntry{}
+	for _, entry := range baseEntries {
+		merged[entry.Name()] = entry
+	}
+	for _, entry := range packEntries {
+		merged[entry.Name()] = entry
+	}
+	entries := make([]fs.DirEntry, 0, len(merged))
+	for _, entry := range merged {
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+	return entries, nil
+}
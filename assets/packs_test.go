@@ -0,0 +1,152 @@
+package assets_test
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/automoto/doomerang/assets"
+)
+
+// builtinFile reads a file from the game's own assets
+func builtinFile(t *testing.T, name string) *fstest.MapFile {
+	t.Helper()
+	data, err := fs.ReadFile(assets.GetAssetFS(), name)
+	if err != nil {
+		t.Fatalf("failed to read %s: %v", name, err)
+	}
+	return &fstest.MapFile{Data: data}
+}
+
+func dirNames(t *testing.T, fsys fs.FS, dir string) []string {
+	t.Helper()
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		t.Fatalf("failed to list %s: %v", dir, err)
+	}
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	return names
+}
+
+func contains(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+func TestLevelPackReplacesCampaign(t *testing.T) {
+	// The level references tilesets/... which only the built-in assets have
+	files := fstest.MapFS{
+		"pack.json":        {Data: []byte(`{"name": "Rooftops", "author": "someone"}`)},
+		"levels/roof.tmx":  builtinFile(t, "levels/level1.tmx"),
+		"chunks/notes.txt": {Data: []byte("not a chunk")},
+	}
+	pack, err := assets.LoadLevelPack(files, "rooftops")
+	if err != nil {
+		t.Fatalf("LoadLevelPack failed: %v", err)
+	}
+	if pack.Levels != 1 || pack.Chunks != 0 || pack.Manifest.Name != "Rooftops" {
+		t.Errorf("unexpected pack %+v", pack)
+	}
+
+	assets.UseLevelPack(pack)
+	defer assets.UseLevelPack(nil)
+
+	paths, err := assets.NewLevelLoader().LevelPaths()
+	if err != nil {
+		t.Fatalf("LevelPaths failed: %v", err)
+	}
+	if len(paths) != 1 || !strings.HasSuffix(paths[0], "roof.tmx") {
+		t.Errorf("expected only the pack's level, got %v", paths)
+	}
+
+	// Pack chunks without replaceChunks join the built-in pool
+	chunks := dirNames(t, assets.GetAssetFS(), "chunks")
+	if !contains(chunks, "notes.txt") || !contains(chunks, "combat_01.tmx") {
+		t.Errorf("expected pack and built-in chunks to be merged, got %v", chunks)
+	}
+}
+
+func TestLevelPackReplacesChunks(t *testing.T) {
+	files := fstest.MapFS{
+		"pack.json":            {Data: []byte(`{"name": "Only One", "replaceChunks": true}`)},
+		"chunks/combat_01.tmx": builtinFile(t, "chunks/combat_01.tmx"),
+	}
+	pack, err := assets.LoadLevelPack(files, "only-one")
+	if err != nil {
+		t.Fatalf("LoadLevelPack failed: %v", err)
+	}
+
+	merged := pack.FS(assets.GetAssetFS())
+	if chunks := dirNames(t, merged, "chunks"); len(chunks) != 1 {
+		t.Errorf("expected only the pack's chunk, got %v", chunks)
+	}
+	// The campaign is untouched by a chunk-only pack
+	if levels := dirNames(t, merged, "levels"); !contains(levels, "level1.tmx") {
+		t.Errorf("expected the built-in campaign, got %v", levels)
+	}
+}
+
+func TestLoadLevelPackErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		files fstest.MapFS
+		want  string
+	}{
+		{"no manifest", fstest.MapFS{"levels/a.tmx": {Data: []byte("<map/>")}}, "missing pack.json"},
+		{"bad manifest", fstest.MapFS{"pack.json": {Data: []byte(`{"name": 3}`)}}, "invalid pack.json"},
+		{"empty", fstest.MapFS{"pack.json": {Data: []byte(`{}`)}}, "no levels/*.tmx or chunks/*.tmx"},
+		{"replace without chunks", fstest.MapFS{
+			"pack.json":    {Data: []byte(`{"replaceChunks": true}`)},
+			"levels/a.tmx": {Data: []byte("<map/>")},
+		}, "replaceChunks is set"},
+		{"broken level", fstest.MapFS{
+			"pack.json":    {Data: []byte(`{}`)},
+			"levels/a.tmx": {Data: []byte("not xml")},
+		}, "invalid level levels/a.tmx"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := assets.LoadLevelPack(tt.files, "pack")
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("expected an error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestLoadLevelPacksSkipsBrokenPacks(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, data string) {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	level := string(builtinFile(t, "levels/level1.tmx").Data)
+	write("zeta/pack.json", `{"name": "Alpha"}`)
+	write("zeta/levels/a.tmx", level)
+	write("beta/pack.json", `{"name": "Beta"}`)
+	write("beta/levels/a.tmx", level)
+	write("broken/pack.json", `{`)
+
+	packs := assets.LoadLevelPacks(dir)
+	if len(packs) != 2 || packs[0].ID != "zeta" || packs[1].ID != "beta" {
+		t.Errorf("expected the two valid packs sorted by name, got %d packs", len(packs))
+	}
+	if packs := assets.LoadLevelPacks(filepath.Join(dir, "missing")); packs != nil {
+		t.Errorf("expected no packs from a missing directory, got %d", len(packs))
+	}
+}
@@ -0,0 +1,407 @@
+// Command procgen generates roguelite runs without launching the game and
+// writes them as JSON, so level designers can inspect seeds in bulk and diff
+// the output between chunk changes.
+//
+// Usage:
+//
+//	go run ./cmd/procgen -seed 42
+//	go run ./cmd/procgen -code 42H0-BC34-GM
+//	go run ./cmd/procgen -seed 1 -count 1000 -length 12 -biome neon -out runs.json
+//	go run ./cmd/procgen -seed 42 -tmx assets/levels/level2.tmx
+//	go run ./cmd/procgen -count 5000 -report
+//	go run ./cmd/procgen -count 5000 -thresholds cmd/procgen/thresholds.json
+//	go run ./cmd/procgen -lint
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"slices"
+	"sort"
+
+	"github.com/automoto/doomerang/assets"
+	"github.com/automoto/doomerang/config"
+	"github.com/automoto/doomerang/procgen"
+)
+
+// runReport is the JSON form of one generated run
+type runReport struct {
+	Seed        int64              `json:"seed"`
+	Error       string             `json:"error,omitempty"` // why the run couldn't be generated
+	SeedCode    string             `json:"seedCode"`
+	Biome       string             `json:"biome"`
+	Length      int                `json:"length"`
+	Width       int                `json:"width"`
+	Height      int                `json:"height"`
+	Chunks      []chunkReport      `json:"chunks"`
+	PlayerSpawn pointReport        `json:"playerSpawn"`
+	Enemies     []enemyReport      `json:"enemies"`
+	PatrolPaths []patrolReport     `json:"patrolPaths"`
+	DeadZones   []rectReport       `json:"deadZones"`
+	Fires       []fireReport       `json:"fires"`
+	Checkpoints []checkpointReport `json:"checkpoints"`
+	Rewards     []rewardReport     `json:"rewards"`
+	FinishLines []rectReport       `json:"finishLines"`
+	Validation  validationReport   `json:"validation"`
+}
+
+// chunkReport is a placed chunk and the concept graph node it was chosen for
+type chunkReport struct {
+	Index      int     `json:"index"`
+	ID         string  `json:"id"`
+	Source     string  `json:"source"`
+	Biome      string  `json:"biome"`
+	Node       string  `json:"node,omitempty"`
+	Difficulty int     `json:"difficulty,omitempty"`
+	Optional   bool    `json:"optional,omitempty"`
+	OffsetX    float64 `json:"offsetX"`
+	OffsetY    float64 `json:"offsetY"`
+	Width      int     `json:"width"`
+	Height     int     `json:"height"`
+}
+
+type pointReport struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+}
+
+type rectReport struct {
+	X      float64 `json:"x"`
+	Y      float64 `json:"y"`
+	Width  float64 `json:"width"`
+	Height float64 `json:"height"`
+}
+
+type enemyReport struct {
+	X          float64 `json:"x"`
+	Y          float64 `json:"y"`
+	Type       string  `json:"type"`
+	PatrolPath string  `json:"patrolPath,omitempty"`
+}
+
+type patrolReport struct {
+	Name   string        `json:"name"`
+	Points []pointReport `json:"points"`
+}
+
+type fireReport struct {
+	X         float64 `json:"x"`
+	Y         float64 `json:"y"`
+	Type      string  `json:"type"`
+	Direction string  `json:"direction"`
+}
+
+type checkpointReport struct {
+	ID float64 `json:"id"`
+	rectReport
+}
+
+type rewardReport struct {
+	Type string `json:"type"`
+	rectReport
+}
+
+// validationReport is the validator's reachability analysis of the chunk layout
+type validationReport struct {
+	Solvable      bool             `json:"solvable"`
+	PlatformCount int              `json:"platformCount"`
+	Start         platformReport   `json:"start"`
+	Exit          platformReport   `json:"exit"`
+	Unreachable   []platformReport `json:"unreachable"`
+}
+
+type platformReport struct {
+	Index int     `json:"index"`
+	Chunk int     `json:"chunk"`
+	X     float64 `json:"x"`
+	Y     float64 `json:"y"`
+	Width float64 `json:"width"`
+}
+
+func main() {
+	seed := flag.Int64("seed", 1, "Seed of the first run to generate")
+	code := flag.String("code", "", "Seed code shared from the game; overrides -seed, -length and -biome")
+	count := flag.Int("count", 1, "Number of consecutive seeds to generate")
+	length := flag.Int("length", config.Procgen.DefaultRunLength, "Run length (middle rooms in the concept graph)")
+	biome := flag.String("biome", "", "Biome for the whole run (default: picked from the seed)")
+	out := flag.String("out", "", "Write JSON to this file instead of stdout")
+	tmx := flag.String("tmx", "", "Also export the run as a Tiled map to this file (single seed only)")
+	tmxDir := flag.String("tmx-dir", "levels", "Asset directory the Tiled map will live in, for tileset paths")
+	quality := flag.Bool("report", false, "Write aggregate quality statistics over all seeds instead of each run")
+	thresholds := flag.String("thresholds", "", "JSON file of quality limits; exit with status 1 if the report violates any (implies -report)")
+	lint := flag.Bool("lint", false, "Check every chunk for authoring mistakes instead of generating runs; exit with status 1 if any are found")
+	flag.Parse()
+
+	// The game's enemy types decide which enemies each seed places
+	if err := assets.LoadEnemyTypes(); err != nil {
+		log.Fatalf("Could not load enemy definitions: %v", err)
+	}
+
+	if *lint {
+		os.Exit(lintChunks())
+	}
+
+	if *code != "" {
+		codeOpts, err := procgen.DecodeSeedCode(*code)
+		if err != nil {
+			log.Fatalf("-code: %v", err)
+		}
+		*seed = codeOpts.Seed
+		*biome = codeOpts.Biome
+		if codeOpts.Length > 0 {
+			*length = codeOpts.Length
+		}
+	}
+	if *count < 1 {
+		log.Fatalf("-count must be at least 1")
+	}
+	if *length < config.Procgen.MinRunLength || *length > config.Procgen.MaxRunLength {
+		log.Fatalf("-length must be between %d and %d", config.Procgen.MinRunLength, config.Procgen.MaxRunLength)
+	}
+	if *biome != "" && !slices.Contains(config.Procgen.Biomes, *biome) {
+		log.Fatalf("unknown biome %q (available: %v)", *biome, config.Procgen.Biomes)
+	}
+	if *tmx != "" && *count != 1 {
+		log.Fatalf("-tmx exports a single seed; use -count 1")
+	}
+
+	opts := procgen.RunOptions{
+		Seed:     *seed,
+		Length:   *length,
+		Biome:    *biome,
+		Headless: true,
+	}
+
+	var v interface{}
+	var violations []string
+	if *quality || *thresholds != "" {
+		report := procgen.AnalyzeRuns(opts, *count)
+		v = report
+
+		if *thresholds != "" {
+			limits, err := loadThresholds(*thresholds)
+			if err != nil {
+				log.Fatal(err)
+			}
+			violations = report.Check(limits)
+		}
+	} else {
+		reports := make([]runReport, 0, *count)
+		for i := 0; i < *count; i++ {
+			opts.Seed = *seed + int64(i)
+			run, err := procgen.GenerateRun(opts)
+			if err != nil {
+				// Report the seed and keep going, so one bad seed doesn't
+				// lose the rest of a batch
+				violations = append(violations, fmt.Sprintf("seed %d: %v", opts.Seed, err))
+				reports = append(reports, runReport{Seed: opts.Seed, Error: err.Error()})
+				continue
+			}
+			reports = append(reports, buildReport(opts, run))
+
+			if *tmx != "" {
+				if err := exportTMX(*tmx, *tmxDir, run); err != nil {
+					log.Fatal(err)
+				}
+			}
+		}
+		v = reports
+	}
+
+	if err := writeOutput(*out, v); err != nil {
+		log.Fatal(err)
+	}
+
+	if len(violations) > 0 {
+		for _, violation := range violations {
+			log.Printf("FAIL: %s", violation)
+		}
+		os.Exit(1)
+	}
+}
+
+// lintChunks prints every authoring mistake in the chunks and returns the
+// exit status
+func lintChunks() int {
+	issues, err := procgen.NewChunkLoader().LintChunks("chunks")
+	if err != nil {
+		log.Print(err)
+		return 1
+	}
+	for _, issue := range issues {
+		fmt.Println(issue)
+	}
+	if len(issues) > 0 {
+		log.Printf("FAIL: %d issues", len(issues))
+		return 1
+	}
+	return 0
+}
+
+// loadThresholds reads quality limits from a JSON file
+func loadThresholds(path string) (procgen.QualityThresholds, error) {
+	var limits procgen.QualityThresholds
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return limits, err
+	}
+	if err := json.Unmarshal(data, &limits); err != nil {
+		return limits, fmt.Errorf("failed to parse thresholds %s: %w", path, err)
+	}
+	return limits, nil
+}
+
+// writeOutput writes v as JSON to path, or stdout if path is empty
+func writeOutput(path string, v interface{}) error {
+	if path == "" {
+		return writeJSON(os.Stdout, v)
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := writeJSON(f, v); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// exportTMX writes run as a Tiled map at path
+func exportTMX(path, dir string, run *procgen.Run) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := procgen.ExportTMX(f, run.Result, run.Level, dir); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func writeJSON(w io.Writer, v interface{}) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		return fmt.Errorf("failed to write JSON: %w", err)
+	}
+	return nil
+}
+
+// buildReport converts a generated run into its JSON form
+func buildReport(opts procgen.RunOptions, run *procgen.Run) runReport {
+	level := run.Level
+	// The same code the game shows for the run
+	seedCode, _ := procgen.EncodeSeedCode(run.Options)
+
+	report := runReport{
+		Seed:        opts.Seed,
+		SeedCode:    seedCode,
+		Biome:       run.Biome,
+		Length:      opts.Length,
+		Width:       level.Width,
+		Height:      level.Height,
+		Chunks:      []chunkReport{},
+		Enemies:     []enemyReport{},
+		PatrolPaths: []patrolReport{},
+		DeadZones:   []rectReport{},
+		Fires:       []fireReport{},
+		Checkpoints: []checkpointReport{},
+		Rewards:     []rewardReport{},
+		FinishLines: []rectReport{},
+	}
+
+	for i, pc := range run.Result.PlacedChunks {
+		chunk := chunkReport{
+			Index:   i,
+			ID:      pc.Chunk.ID,
+			Source:  pc.Chunk.SourcePath,
+			Biome:   pc.Chunk.Biome,
+			OffsetX: pc.OffsetX,
+			OffsetY: pc.OffsetY,
+			Width:   pc.Chunk.Width,
+			Height:  pc.Chunk.Height,
+		}
+		if node := run.RoomNode(i); node != nil {
+			chunk.Node = string(node.Type)
+			chunk.Difficulty = node.Difficulty
+			chunk.Optional = node.Optional
+		}
+		report.Chunks = append(report.Chunks, chunk)
+	}
+
+	if len(level.PlayerSpawns) > 0 {
+		report.PlayerSpawn = pointReport{X: level.PlayerSpawns[0].X, Y: level.PlayerSpawns[0].Y}
+	}
+
+	for _, e := range level.EnemySpawns {
+		report.Enemies = append(report.Enemies, enemyReport{X: e.X, Y: e.Y, Type: e.EnemyType, PatrolPath: e.PatrolPath})
+	}
+
+	// Patrol paths are stored in a map; sort by name so output diffs cleanly
+	names := make([]string, 0, len(level.PatrolPaths))
+	for name := range level.PatrolPaths {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		path := patrolReport{Name: name, Points: []pointReport{}}
+		for _, p := range level.PatrolPaths[name].Points {
+			path.Points = append(path.Points, pointReport{X: p.X, Y: p.Y})
+		}
+		report.PatrolPaths = append(report.PatrolPaths, path)
+	}
+
+	for _, dz := range level.DeadZones {
+		report.DeadZones = append(report.DeadZones, rectReport{X: dz.X, Y: dz.Y, Width: dz.Width, Height: dz.Height})
+	}
+	for _, f := range level.Fires {
+		report.Fires = append(report.Fires, fireReport{X: f.X, Y: f.Y, Type: f.FireType, Direction: f.Direction})
+	}
+	for _, cp := range level.Checkpoints {
+		report.Checkpoints = append(report.Checkpoints, checkpointReport{
+			ID:         cp.CheckpointID,
+			rectReport: rectReport{X: cp.X, Y: cp.Y, Width: cp.Width, Height: cp.Height},
+		})
+	}
+	for _, r := range level.Rewards {
+		report.Rewards = append(report.Rewards, rewardReport{
+			Type:       r.RewardType,
+			rectReport: rectReport{X: r.X, Y: r.Y, Width: r.Width, Height: r.Height},
+		})
+	}
+	for _, fl := range level.FinishLines {
+		report.FinishLines = append(report.FinishLines, rectReport{X: fl.X, Y: fl.Y, Width: fl.Width, Height: fl.Height})
+	}
+
+	report.Validation = buildValidationReport(run.Validate())
+	return report
+}
+
+func buildValidationReport(vr procgen.ValidationResult) validationReport {
+	report := validationReport{
+		Solvable:      vr.Solvable,
+		PlatformCount: vr.PlatformCount,
+		Unreachable:   []platformReport{},
+	}
+
+	platform := func(idx int) platformReport {
+		if idx < 0 || idx >= len(vr.Platforms) {
+			return platformReport{Index: idx}
+		}
+		p := vr.Platforms[idx]
+		return platformReport{Index: idx, Chunk: p.ChunkIndex, X: p.X, Y: p.Y, Width: p.Width}
+	}
+
+	report.Start = platform(vr.StartIdx)
+	report.Exit = platform(vr.ExitIdx)
+	for _, idx := range vr.Unreachable {
+		report.Unreachable = append(report.Unreachable, platform(idx))
+	}
+	return report
+}
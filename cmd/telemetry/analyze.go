@@ -0,0 +1,168 @@
+package main
+
+import (
+	"sort"
+
+	"github.com/automoto/doomerang/components"
+)
+
+const ticksPerSecond = 60
+
+// levelSummary aggregates the events logged while one level or run was played
+type levelSummary struct {
+	Level           string                               `json:"level"`
+	Plays           int                                  `json:"plays"`
+	Seconds         float64                              `json:"seconds"` // gameplay time up to the last event of each play
+	Events          map[components.GameplayEventKind]int `json:"events"`
+	Deaths          int                                  `json:"deaths"`
+	DeathsByCause   map[string]int                       `json:"deathsByCause"`
+	DamageTakenBy   map[string]int                       `json:"damageTakenBy"`
+	DamageDealtWith map[string]int                       `json:"damageDealtWith"`
+	EnemiesKilled   map[string]int                       `json:"enemiesKilled"`
+	Boomerang       boomerangSummary                     `json:"boomerang"`
+	Rooms           []roomSummary                        `json:"rooms,omitempty"`
+	Finishes        int                                  `json:"finishes"`
+
+	lastTick int64
+}
+
+// boomerangSummary reports how well throws land and come back
+type boomerangSummary struct {
+	Throws         int     `json:"throws"`
+	Catches        int     `json:"catches"`
+	PerfectCatches int     `json:"perfectCatches"`
+	Hits           int     `json:"hits"`
+	CatchRate      float64 `json:"catchRate"`
+	HitsPer        float64 `json:"hitsPerThrow"`
+}
+
+// roomSummary is the average time spent in one main path room, from entering it
+// to entering the next. The first room has no entry event so isn't listed.
+type roomSummary struct {
+	Index   int     `json:"index"`
+	Chunk   string  `json:"chunk"`
+	Entries int     `json:"entries"`
+	Seconds float64 `json:"avgSeconds"`
+}
+
+// summarize groups events by the level_start event before them and totals each
+// level. Levels are listed in the order they were first played.
+func summarize(events []components.GameplayEventData) []*levelSummary {
+	var levels []*levelSummary
+	byName := map[string]*levelSummary{}
+	var current *levelSummary
+	inRoom := -1 // index into current.Rooms of the room the player is in
+	var roomTick int64
+
+	// endPlay closes out the running play: its time and the room the player was in
+	endPlay := func() {
+		if current == nil {
+			return
+		}
+		current.Seconds += float64(current.lastTick) / ticksPerSecond
+		if inRoom >= 0 {
+			current.Rooms[inRoom].Seconds += float64(current.lastTick-roomTick) / ticksPerSecond
+		}
+		inRoom = -1
+	}
+
+	for _, event := range events {
+		if event.Kind == components.EventLevelStart {
+			endPlay()
+			current = byName[event.Detail]
+			if current == nil {
+				current = newLevelSummary(event.Detail)
+				byName[event.Detail] = current
+				levels = append(levels, current)
+			}
+			current.Plays++
+			current.lastTick = 0
+			continue
+		}
+		if current == nil {
+			// Events logged before any level_start, e.g. from a truncated log
+			current = newLevelSummary("")
+			byName[""] = current
+			levels = append(levels, current)
+			current.Plays++
+		}
+
+		current.lastTick = event.Tick
+		current.Events[event.Kind]++
+
+		switch event.Kind {
+		case components.EventDeath:
+			if event.Entity == "Player" {
+				current.Deaths++
+				current.DeathsByCause[causeLabel(event.Detail)]++
+			} else {
+				current.EnemiesKilled[event.Entity]++
+			}
+		case components.EventDamageTaken:
+			current.DamageTakenBy[causeLabel(event.Detail)] += event.Amount
+		case components.EventDamageDealt:
+			current.DamageDealtWith[causeLabel(event.Detail)] += event.Amount
+		case components.EventBoomerangThrow:
+			current.Boomerang.Throws++
+		case components.EventBoomerangCatch:
+			current.Boomerang.Catches++
+		case components.EventPerfectCatch:
+			current.Boomerang.PerfectCatches++
+		case components.EventBoomerangHit:
+			current.Boomerang.Hits++
+		case components.EventFinish:
+			current.Finishes++
+		case components.EventRoomEnter:
+			if inRoom >= 0 {
+				current.Rooms[inRoom].Seconds += float64(event.Tick-roomTick) / ticksPerSecond
+			}
+			inRoom = current.room(event.Amount, event.Detail)
+			current.Rooms[inRoom].Entries++
+			roomTick = event.Tick
+		}
+	}
+	endPlay()
+
+	for _, level := range levels {
+		if b := &level.Boomerang; b.Throws > 0 {
+			b.CatchRate = float64(b.Catches) / float64(b.Throws)
+			b.HitsPer = float64(b.Hits) / float64(b.Throws)
+		}
+		for i := range level.Rooms {
+			if entries := level.Rooms[i].Entries; entries > 0 {
+				level.Rooms[i].Seconds /= float64(entries)
+			}
+		}
+		sort.Slice(level.Rooms, func(i, j int) bool { return level.Rooms[i].Index < level.Rooms[j].Index })
+	}
+	return levels
+}
+
+func newLevelSummary(name string) *levelSummary {
+	return &levelSummary{
+		Level:           name,
+		Events:          map[components.GameplayEventKind]int{},
+		DeathsByCause:   map[string]int{},
+		DamageTakenBy:   map[string]int{},
+		DamageDealtWith: map[string]int{},
+		EnemiesKilled:   map[string]int{},
+	}
+}
+
+// room returns the position in l.Rooms of main path room index, adding it if needed
+func (l *levelSummary) room(index int, chunk string) int {
+	for i := range l.Rooms {
+		if l.Rooms[i].Index == index && l.Rooms[i].Chunk == chunk {
+			return i
+		}
+	}
+	l.Rooms = append(l.Rooms, roomSummary{Index: index, Chunk: chunk})
+	return len(l.Rooms) - 1
+}
+
+func causeLabel(detail string) string {
+	if detail == "" {
+		return "unknown"
+	}
+	return detail
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/automoto/doomerang/telemetry"
+)
+
+const testLog = `{"kind":"level_start","tick":0,"x":0,"y":0,"entity":"","detail":"levels/level1.tmx"}
+{"kind":"boomerang_throw","tick":30,"x":100,"y":100,"entity":"Player"}
+{"kind":"boomerang_hit","tick":40,"x":200,"y":100,"entity":"Guard","amount":10}
+{"kind":"damage_dealt","tick":40,"x":200,"y":100,"entity":"Guard","amount":10,"detail":"boomerang"}
+{"kind":"boomerang_catch","tick":60,"x":110,"y":100,"entity":"Player"}
+{"kind":"damage_taken","tick":120,"x":300,"y":100,"entity":"Player","amount":20,"detail":"Guard"}
+{"kind":"death","tick":180,"x":300,"y":100,"entity":"Player","detail":"Guard"}
+{"kind":"level_start","tick":0,"x":0,"y":0,"entity":"","detail":"roguelite 22H0-BC34-0C"}
+{"kind":"room_enter","tick":600,"x":330,"y":100,"entity":"Player","amount":1,"detail":"combat_01"}
+{"kind":"room_enter","tick":1200,"x":650,"y":100,"entity":"Player","amount":2,"detail":"exit_01"}
+{"kind":"finish","tick":1500,"x":900,"y":100,"entity":"Player"}
+{"kind":"level_start","tick":0,"x":0,"y":0,"entity":"","detail":"levels/level1.tmx"}
+{"kind":"death","tick":60,"x":310,"y":110,"entity":"Player","detail":"Fire"}
+`
+
+func TestSummarize(t *testing.T) {
+	events, err := telemetry.ReadEvents(strings.NewReader(testLog))
+	if err != nil {
+		t.Fatalf("ReadEvents failed: %v", err)
+	}
+	levels := summarize(events)
+	if len(levels) != 2 {
+		t.Fatalf("expected 2 levels, got %d", len(levels))
+	}
+
+	campaign := levels[0]
+	if campaign.Plays != 2 || campaign.Deaths != 2 {
+		t.Errorf("expected 2 plays and 2 deaths, got %d and %d", campaign.Plays, campaign.Deaths)
+	}
+	if campaign.DeathsByCause["Guard"] != 1 || campaign.DeathsByCause["Fire"] != 1 {
+		t.Errorf("expected one death each to Guard and Fire, got %v", campaign.DeathsByCause)
+	}
+	if campaign.DamageTakenBy["Guard"] != 20 || campaign.DamageDealtWith["boomerang"] != 10 {
+		t.Errorf("unexpected damage totals: taken %v, dealt %v", campaign.DamageTakenBy, campaign.DamageDealtWith)
+	}
+	if b := campaign.Boomerang; b.Throws != 1 || b.Catches != 1 || b.Hits != 1 || b.CatchRate != 1 {
+		t.Errorf("unexpected boomerang summary %+v", b)
+	}
+	if campaign.Seconds != 4 {
+		t.Errorf("expected 4 seconds across both plays, got %v", campaign.Seconds)
+	}
+
+	run := levels[1]
+	if run.Finishes != 1 || len(run.Rooms) != 2 {
+		t.Fatalf("expected 1 finish and 2 rooms, got %d and %d", run.Finishes, len(run.Rooms))
+	}
+	if run.Rooms[0].Chunk != "combat_01" || run.Rooms[0].Seconds != 10 {
+		t.Errorf("expected 10s in combat_01, got %+v", run.Rooms[0])
+	}
+	if run.Rooms[1].Seconds != 5 {
+		t.Errorf("expected the exit room to run until the last event, got %+v", run.Rooms[1])
+	}
+}
@@ -0,0 +1,154 @@
+package main
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/automoto/doomerang/assets"
+	"github.com/automoto/doomerang/components"
+	"github.com/automoto/doomerang/procgen"
+	"github.com/automoto/doomerang/telemetry"
+	"github.com/lafriks/go-tiled"
+	"github.com/lafriks/go-tiled/render"
+)
+
+// heatAlpha is how opaque heat cells are drawn over a map
+const heatAlpha = 170
+
+// writeHeatmaps saves a heatmap per campaign level or run under dir, and one
+// per procgen chunk under dir/chunks aggregated across every run that used it.
+// Campaign levels and chunks are drawn over their map.
+func writeHeatmaps(dir string, events []components.GameplayEventData, levels []*levelSummary, kinds telemetry.Kinds, cellSize float64) error {
+	for _, level := range levels {
+		grid := telemetry.LevelHeat(events, level.Level, kinds, cellSize)
+		if grid.Empty() {
+			continue
+		}
+		var backdrop *image.NRGBA
+		if strings.HasSuffix(level.Level, ".tmx") {
+			backdrop = loadLevelBackdrop(level.Level)
+		}
+		if err := savePNG(filepath.Join(dir, fileName(level.Level)+".png"), renderHeatmap(grid, backdrop)); err != nil {
+			return err
+		}
+	}
+
+	chunkIDs := telemetry.Chunks(events)
+	if len(chunkIDs) == 0 {
+		return nil
+	}
+	chunks, err := procgen.NewChunkLoader().LoadAllChunks("chunks")
+	if err != nil {
+		log.Printf("Warning: Could not load chunks, drawing chunk heatmaps without maps: %v", err)
+	}
+	chunkDir := filepath.Join(dir, "chunks")
+	if err := os.MkdirAll(chunkDir, 0o755); err != nil {
+		return err
+	}
+	for _, id := range chunkIDs {
+		grid := telemetry.ChunkHeat(events, id, kinds, cellSize)
+		if grid.Empty() {
+			continue
+		}
+		var backdrop *image.NRGBA
+		for _, chunk := range chunks {
+			if chunk.ID == id && chunk.TiledMap != nil {
+				backdrop = renderMap(chunk.TiledMap, id)
+				break
+			}
+		}
+		if err := savePNG(filepath.Join(chunkDir, fileName(id)+".png"), renderHeatmap(grid, backdrop)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// renderHeatmap draws grid's cells over backdrop, whose pixels are world
+// pixels. Without a backdrop the image just covers the cells with events.
+func renderHeatmap(grid *telemetry.Grid, backdrop *image.NRGBA) *image.RGBA {
+	cellSize := int(grid.CellSize)
+	var img *image.RGBA
+	var origin image.Point // world pixel at the image's top-left corner
+	if backdrop != nil {
+		img = image.NewRGBA(backdrop.Bounds())
+		draw.Draw(img, img.Bounds(), &image.Uniform{C: color.RGBA{R: 20, G: 20, B: 30, A: 255}}, image.Point{}, draw.Src)
+		draw.Draw(img, img.Bounds(), backdrop, image.Point{}, draw.Over)
+	} else {
+		bounds := grid.Bounds()
+		origin = bounds.Min.Mul(cellSize)
+		img = image.NewRGBA(image.Rect(0, 0, bounds.Dx()*cellSize, bounds.Dy()*cellSize))
+		draw.Draw(img, img.Bounds(), &image.Uniform{C: color.RGBA{R: 20, G: 20, B: 30, A: 255}}, image.Point{}, draw.Src)
+	}
+
+	for cell, n := range grid.Counts {
+		c := grid.Color(n)
+		min := cell.Mul(cellSize).Sub(origin)
+		r := image.Rectangle{Min: min, Max: min.Add(image.Pt(cellSize, cellSize))}
+		draw.Draw(img, r, &image.Uniform{C: color.NRGBA{R: c.R, G: c.G, B: c.B, A: heatAlpha}}, image.Point{}, draw.Over)
+	}
+	return img
+}
+
+// loadLevelBackdrop renders a campaign level's map, or returns nil if it can't be loaded
+func loadLevelBackdrop(path string) *image.NRGBA {
+	levelMap, err := tiled.LoadFile(path, tiled.WithFileSystem(assets.GetAssetFS()))
+	if err != nil {
+		log.Printf("Warning: Could not load %s, drawing its heatmap without the map: %v", path, err)
+		return nil
+	}
+	return renderMap(levelMap, path)
+}
+
+// renderMap draws the tile layers the game renders, or returns nil on failure
+func renderMap(m *tiled.Map, name string) *image.NRGBA {
+	renderer, err := render.NewRendererWithFileSystem(m, assets.GetAssetFS())
+	if err != nil {
+		log.Printf("Warning: Could not render %s: %v", name, err)
+		return nil
+	}
+	for i, layer := range m.Layers {
+		if !layer.Properties.GetBool("render") {
+			continue
+		}
+		if err := renderer.RenderLayer(i); err != nil {
+			log.Printf("Warning: Could not render layer %d of %s: %v", i, name, err)
+		}
+	}
+	return renderer.Result
+}
+
+func savePNG(path string, img image.Image) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := png.Encode(f, img); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	log.Printf("Wrote %s", path)
+	return f.Close()
+}
+
+// fileName turns a level label like "levels/level1.tmx" into a safe file name
+func fileName(label string) string {
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-':
+			return r
+		}
+		return '_'
+	}, strings.TrimSuffix(label, ".tmx"))
+	if name == "" {
+		return "unknown"
+	}
+	return name
+}
@@ -0,0 +1,90 @@
+// Command telemetry turns a gameplay telemetry log, recorded with
+// `go run . -telemetry session.jsonl`, into a JSON summary per level and
+// optional heatmap images of where events happened. Campaign levels and
+// procgen chunks are drawn under their heatmaps, and chunk heatmaps aggregate
+// every run that used the chunk.
+//
+// Usage:
+//
+//	go run ./cmd/telemetry -in session.jsonl
+//	go run ./cmd/telemetry -in session.jsonl -out summary.json -heatmaps heatmaps
+//	go run ./cmd/telemetry -in session.jsonl -heatmaps heatmaps -kinds damage_taken -cell 16
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/automoto/doomerang/components"
+	"github.com/automoto/doomerang/telemetry"
+)
+
+func main() {
+	in := flag.String("in", "", "Telemetry log to analyze (required)")
+	out := flag.String("out", "", "Write the summary to this file instead of stdout")
+	heatmapDir := flag.String("heatmaps", "", "Write a heatmap PNG per level to this directory")
+	kinds := flag.String("kinds", "death,damage_taken", "Comma-separated event kinds to plot on heatmaps")
+	cell := flag.Float64("cell", 32, "Heatmap cell size in world pixels")
+	flag.Parse()
+
+	if *in == "" {
+		log.Fatal("-in is required")
+	}
+	if *cell <= 0 {
+		log.Fatal("-cell must be positive")
+	}
+
+	events, err := telemetry.LoadEvents(*in)
+	if err != nil {
+		log.Fatalf("failed to read %s: %v", *in, err)
+	}
+
+	levels := summarize(events)
+
+	if *heatmapDir != "" {
+		plot := telemetry.Kinds{}
+		for _, kind := range strings.Split(*kinds, ",") {
+			plot[components.GameplayEventKind(strings.TrimSpace(kind))] = true
+		}
+		if err := os.MkdirAll(*heatmapDir, 0o755); err != nil {
+			log.Fatal(err)
+		}
+		if err := writeHeatmaps(*heatmapDir, events, levels, plot, *cell); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	if err := writeOutput(*out, levels); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// writeOutput writes v as JSON to path, or stdout if path is empty
+func writeOutput(path string, v interface{}) error {
+	if path == "" {
+		return writeJSON(os.Stdout, v)
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := writeJSON(f, v); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func writeJSON(w io.Writer, v interface{}) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		return fmt.Errorf("failed to write JSON: %w", err)
+	}
+	return nil
+}
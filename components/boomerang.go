@@ -9,6 +9,8 @@ type BoomerangState int
 const (
 	BoomerangOutbound BoomerangState = iota
 	BoomerangInbound
+	BoomerangEmbedded // Stuck in a wall as a platform
+	BoomerangDropped  // Knocked out of flight, waiting on the ground to be picked up
 )
 
 type BoomerangData struct {
@@ -20,6 +22,62 @@ type BoomerangData struct {
 	HitEnemies       map[*donburi.Entry]struct{}
 	Damage           int
 	ChargeRatio      float64 // 0.0 = quick throw, 1.0 = fully charged
+	Bounces          int     // Ricochets left before a wall sends it back
+	ReturnFrames     int     // Frames spent flying back, tightening the curve
+	CatchPressed     bool    // Owner pressed throw while it was out; only the first press can time a perfect catch
+	StateFrames      int     // Frames left embedded or dropped before it flies back on its own
+	ReturnSpeed      float64 // Speed it flies back at
+	Pierces          int     // Enemies it passes through before the short return rule turns it back
+	BurnDamage       int     // Damage per burn tick to enemies it sets on fire; 0 = no fire
+	Lifesteal        int     // Health it restores to the owner for each enemy hit
+	Twin             bool    // An extra boomerang thrown alongside; it is never the owner's active one
+	Heavy            bool    // Sticks in walls when fully charged, and drops when the owner is hit
+}
+
+// BoomerangModifier is a stackable run modifier picked up in a roguelite run.
+// The values double as the reward types of the pickups.
+type BoomerangModifier string
+
+const (
+	ModifierRange     BoomerangModifier = "range"     // Flies further before turning back
+	ModifierPierce    BoomerangModifier = "pierce"    // Passes through more enemies before turning back
+	ModifierFire      BoomerangModifier = "fire"      // Sets enemies it hits on fire
+	ModifierTwin      BoomerangModifier = "twin"      // Throws another boomerang alongside
+	ModifierReturn    BoomerangModifier = "return"    // Flies back faster
+	ModifierLifesteal BoomerangModifier = "lifesteal" // Heals the owner for each enemy hit
+	ModifierRicochet  BoomerangModifier = "ricochet"  // Bounces off more walls before turning back
+	ModifierHeavy     BoomerangModifier = "heavy"     // Sticks in walls, but a hit knocks it out of the air
+)
+
+// BoomerangModifiers lists every modifier in the order the HUD and run summary show them
+var BoomerangModifiers = []BoomerangModifier{
+	ModifierRange, ModifierPierce, ModifierFire, ModifierTwin, ModifierReturn, ModifierLifesteal,
+	ModifierRicochet, ModifierHeavy,
+}
+
+var boomerangModifierLabels = map[BoomerangModifier]string{
+	ModifierRange:     "Range",
+	ModifierPierce:    "Pierce",
+	ModifierFire:      "Fire",
+	ModifierTwin:      "Twin",
+	ModifierReturn:    "Return",
+	ModifierLifesteal: "Lifesteal",
+	ModifierRicochet:  "Ricochet",
+	ModifierHeavy:     "Heavy",
+}
+
+// Label returns the modifier's display name, or "" if m isn't a modifier
+func (m BoomerangModifier) Label() string {
+	return boomerangModifierLabels[m]
+}
+
+// ModifierStacks counts how many times each modifier appears in mods
+func ModifierStacks(mods []BoomerangModifier) map[BoomerangModifier]int {
+	stacks := make(map[BoomerangModifier]int, len(mods))
+	for _, m := range mods {
+		stacks[m]++
+	}
+	return stacks
 }
 
 var Boomerang = donburi.NewComponentType[BoomerangData]()
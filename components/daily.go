@@ -0,0 +1,11 @@
+package components
+
+import "github.com/yohamta/donburi"
+
+// DailyData stores the current state of the daily run screen
+type DailyData struct {
+	ScrollOffset int // first history row shown
+}
+
+// Daily is the component type for daily run screen state
+var Daily = donburi.NewComponentType[DailyData]()
@@ -6,6 +6,7 @@ type DamageEventData struct {
 	Amount     int
 	KnockbackX float64
 	KnockbackY float64
+	Source     string // what dealt the damage, e.g. an enemy type or "Fire"; recorded as cause of death
 }
 
 var DamageEvent = donburi.NewComponentType[DamageEventData]()
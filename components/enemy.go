@@ -28,6 +28,8 @@ type EnemyData struct {
 	ActiveHitbox       *donburi.Entry // Direct reference to the active hitbox
 	SeparationCooldown int            // Frames until separation flip is allowed again
 	LedgeCooldown      int            // Frames until ledge flip is allowed again
+	BurnFrames         int            // Frames left burning after a fire boomerang hit
+	BurnDamage         int            // Damage per burn tick
 }
 
 var Enemy = donburi.NewComponentType[EnemyData]()
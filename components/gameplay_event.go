@@ -0,0 +1,53 @@
+package components
+
+import (
+	"github.com/yohamta/donburi"
+	"github.com/yohamta/donburi/features/events"
+)
+
+// GameplayEventKind names something that happened during gameplay
+type GameplayEventKind string
+
+const (
+	EventLevelStart     GameplayEventKind = "level_start" // Detail = level or run being played
+	EventDamageDealt    GameplayEventKind = "damage_dealt"
+	EventDamageTaken    GameplayEventKind = "damage_taken"
+	EventBoomerangThrow GameplayEventKind = "boomerang_throw"
+	EventBoomerangCatch GameplayEventKind = "boomerang_catch"
+	EventBoomerangHit   GameplayEventKind = "boomerang_hit"
+	EventPerfectCatch   GameplayEventKind = "perfect_catch"   // follows the boomerang_catch it rewards
+	EventModifierPickup GameplayEventKind = "modifier_pickup" // Detail = boomerang modifier picked up
+	EventDeath          GameplayEventKind = "death"
+	EventRespawn        GameplayEventKind = "respawn"
+	EventCheckpoint     GameplayEventKind = "checkpoint"
+	EventRoomEnter      GameplayEventKind = "room_enter" // Amount = main path room index
+	EventFinish         GameplayEventKind = "finish"
+)
+
+// GameplayEventData is one gameplay event. The JSON form is one line of a
+// telemetry log.
+type GameplayEventData struct {
+	Kind   GameplayEventKind `json:"kind"`
+	Tick   int64             `json:"tick"`
+	X      float64           `json:"x"`
+	Y      float64           `json:"y"`
+	Entity string            `json:"entity"`           // "Player", an enemy type, or "" for the level itself
+	Amount int               `json:"amount,omitempty"` // damage dealt, room index, ...
+	Detail string            `json:"detail,omitempty"` // damage source, checkpoint ID, ...
+
+	// Generated levels also record the chunk the event happened in and the
+	// position within it, so events can be aggregated per chunk across runs
+	Chunk  string  `json:"chunk,omitempty"`
+	ChunkX float64 `json:"chunkX,omitempty"`
+	ChunkY float64 `json:"chunkY,omitempty"`
+}
+
+// GameplayEvent is the event bus gameplay systems publish to
+var GameplayEvent = events.NewEventType[GameplayEventData]()
+
+// TelemetryData counts gameplay ticks so events can be placed in time
+type TelemetryData struct {
+	Tick int64
+}
+
+var Telemetry = donburi.NewComponentType[TelemetryData]()
@@ -0,0 +1,22 @@
+package components
+
+import (
+	"image/color"
+
+	"github.com/automoto/doomerang/assets"
+	"github.com/yohamta/donburi"
+)
+
+// HeatmapCell is one square of the debug heatmap in world space
+type HeatmapCell struct {
+	X, Y, Size float64
+	Color      color.NRGBA
+}
+
+// HeatmapData caches the heatmap cells loaded for Level
+type HeatmapData struct {
+	Level *assets.Level
+	Cells []HeatmapCell
+}
+
+var Heatmap = donburi.NewComponentType[HeatmapData]()
@@ -27,6 +27,10 @@ type InputData struct {
 	Current         [cfg.ActionCount]bool // Current frame's Pressed state
 	Previous        [cfg.ActionCount]bool // Previous frame's Pressed state
 	LastInputMethod InputMethod           // Most recently used input method
+
+	// Free aim from an analog stick or the mouse, if either is aiming this frame
+	Aiming  bool
+	AimStep int // Direction in cfg.AimSteps steps clockwise from right
 }
 
 var Input = donburi.NewComponentType[InputData]()
@@ -10,6 +10,7 @@ type LevelData struct {
 	LevelIndex       int
 	Levels           []assets.Level
 	ActiveCheckpoint *ActiveCheckpointData // Last activated checkpoint for respawn
+	Campaign         bool                  // Playing through the campaign, so progress is saved; false for a level picked from level select
 }
 
 var Level = donburi.NewComponentType[LevelData]()
@@ -0,0 +1,12 @@
+package components
+
+import "github.com/yohamta/donburi"
+
+// LevelPacksData stores the current state of the level packs screen
+type LevelPacksData struct {
+	SelectedIndex int    // 0 is the built-in content, then one entry per pack
+	Message       string // Why the last pack could not be activated, if it failed
+}
+
+// LevelPacks is the component type for level packs screen state
+var LevelPacks = donburi.NewComponentType[LevelPacksData]()
@@ -0,0 +1,11 @@
+package components
+
+import "github.com/yohamta/donburi"
+
+// LevelSelectData stores the current state of the level select screen
+type LevelSelectData struct {
+	SelectedIndex int // Index into the level list
+}
+
+// LevelSelect is the component type for level select state
+var LevelSelect = donburi.NewComponentType[LevelSelectData]()
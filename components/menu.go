@@ -9,6 +9,11 @@ const (
 	MainMenuStart MainMenuOption = iota
 	MainMenuContinue
 	MainMenuRoguelite
+	MainMenuDaily
+	MainMenuSeedEntry
+	MainMenuRunHistory
+	MainMenuLevelSelect
+	MainMenuLevelPacks
 	MainMenuSettings
 	MainMenuExit
 )
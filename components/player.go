@@ -10,8 +10,11 @@ type PlayerData struct {
 	InvulnFrames        int // Invulnerability frames timer
 	BoomerangChargeTime int
 	ActiveBoomerang     *donburi.Entry
-	ChargeVFX           *donburi.Entry // VFX shown while charging boomerang
-	LastSafeX           float64        // Last position where player was safely grounded
+	ChargeVFX           *donburi.Entry      // VFX shown while charging boomerang
+	PerfectCatchWindow  int                 // Frames left to make a perfect catch: opened by a press while the boomerang is out, or by a catch without one
+	ChargeBonusFrames   int                 // Frames left to start a pre-charged throw after a perfect catch
+	Modifiers           []BoomerangModifier // Run modifiers picked up, in pickup order; repeats stack
+	LastSafeX           float64             // Last position where player was safely grounded
 	LastSafeY           float64
 }
 
@@ -0,0 +1,38 @@
+package components
+
+import "github.com/yohamta/donburi"
+
+// ReplayMode identifies which kind of scene a replay was recorded in
+type ReplayMode uint8
+
+const (
+	ReplayCampaign ReplayMode = iota
+	ReplayRoguelite
+)
+
+// ReplayData is a recorded play session: the start state needed to rebuild the
+// scene exactly, plus the action bitmask produced by UpdateInput on every tick.
+type ReplayData struct {
+	Mode         ReplayMode
+	Seed         int64   // roguelite generation seed (unused for campaign)
+	RunLength    int     // roguelite run length; 0 = default (unused for campaign)
+	Biome        string  // roguelite biome; "" = picked from the seed (unused for campaign)
+	LevelIndex   int     // campaign level index (unused for roguelite)
+	CheckpointID float64 // -1 = started at the level's default spawn
+	SpawnX       float64
+	SpawnY       float64
+	Lives        int
+	Health       int
+	LevelPack    string   // ID of the level pack active when recorded; "" = built-in content
+	Frames       []uint32 // bit i set = cfg.ActionID(i) pressed on that tick; bit 23 and the top byte are free aim
+}
+
+// ReplayStateData tracks an in-progress recording or playback in a scene's world
+type ReplayStateData struct {
+	Replay    *ReplayData
+	Recording bool // false = playing back
+	Tick      int  // next frame to feed during playback
+	Finished  bool // playback ran out of frames; live input resumes
+}
+
+var ReplayState = donburi.NewComponentType[ReplayStateData]()
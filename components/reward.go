@@ -0,0 +1,9 @@
+package components
+
+import "github.com/yohamta/donburi"
+
+type RewardData struct {
+	RewardType string // "health", or a BoomerangModifier
+}
+
+var Reward = donburi.NewComponentType[RewardData]()
@@ -0,0 +1,12 @@
+package components
+
+import "github.com/yohamta/donburi"
+
+// RoomBreakdownData stores the state of the per-room table on the run summary screen
+type RoomBreakdownData struct {
+	Showing      bool // the table is open in place of the summary
+	ScrollOffset int  // index of the first room row shown
+}
+
+// RoomBreakdown is the component type for run summary room table state
+var RoomBreakdown = donburi.NewComponentType[RoomBreakdownData]()
@@ -0,0 +1,24 @@
+package components
+
+import "github.com/yohamta/donburi"
+
+// RunHistorySort is the order runs are listed in on the run history screen
+type RunHistorySort int
+
+const (
+	RunHistoryNewest RunHistorySort = iota
+	RunHistoryMostRooms
+	RunHistoryMostKills
+	RunHistoryFastest
+	RunHistorySortCount // Must be last
+)
+
+// RunHistoryData stores the current state of the run history screen
+type RunHistoryData struct {
+	SelectedIndex int // Index into the sorted run list
+	Sort          RunHistorySort
+	ShowingDetail bool // The selected run's detail view is open
+}
+
+// RunHistory is the component type for run history screen state
+var RunHistory = donburi.NewComponentType[RunHistoryData]()
@@ -1,17 +1,112 @@
 package components
 
-import "github.com/yohamta/donburi"
+import (
+	"math"
+
+	"github.com/yohamta/donburi"
+)
 
 // RunStatsData tracks per-run statistics during gameplay
 type RunStatsData struct {
 	Seed           int64
+	SeedCode       string // shareable code that reproduces the run
+	DailyDate      string // day of the daily run being played; "" for regular runs
+	DailyScored    bool   // this is the day's scored daily attempt
 	TotalRooms     int
 	RoomsCleared   int
 	KillCount      int
+	Deaths         int
+	PerfectCatches int
+	Modifiers      []BoomerangModifier // boomerang modifiers picked up, in pickup order
 	ElapsedTicks   int64
-	PrevEnemyCount int       // internal: for delta-based kill detection
-	RoomBoundaries []float64 // right-edge X of each placed chunk, in order
-	LastRoomIndex  int       // highest room boundary crossed so far
+	PrevEnemyCount int            // internal: for delta-based kill detection
+	RoomBoundaries []RoomBoundary // exit line of each main path chunk, in order
+	LastRoomIndex  int            // highest room boundary crossed so far
+	Rooms          []RoomStats    // per-room breakdown of each main path room, in order
+	RoomAreas      []RoomArea     // area of each main path room, in order
+
+	LastDamageSource string // source of the most recent damage to the player
+	CauseOfDeath     string // damage source behind the latest death; "" = none yet
+	DeathRoom        int    // main path room of the latest death
+
+	OptionalRooms      []RoomArea // optional branch rooms off the main path
+	OptionalRoomsFound int
+	OptionalVisited    []bool // internal: which optional rooms have been entered
+}
+
+// RoomStats records what happened in one main path room
+type RoomStats struct {
+	ChunkID     string
+	NodeType    string // concept graph node type, e.g. "combat"; "" if unknown
+	Ticks       int64  // time spent in the room
+	Kills       int
+	DamageTaken int
+	Deaths      int
+}
+
+// RoomAt returns the index of the main path room containing (x, y), or of the
+// nearest one if the point is outside them all, e.g. in a side room or past
+// the exit. It returns -1 if the run has no room areas.
+func (s *RunStatsData) RoomAt(x, y float64) int {
+	best, bestDist := -1, math.Inf(1)
+	for i, room := range s.RoomAreas {
+		if dist := room.distance(x, y); dist < bestDist {
+			best, bestDist = i, dist
+		}
+	}
+	return best
+}
+
+// CurrentRoom returns the stats of the main path room at (x, y), or nil if the
+// run has no per-room stats.
+func (s *RunStatsData) CurrentRoom(x, y float64) *RoomStats {
+	if i := s.RoomAt(x, y); i >= 0 && i < len(s.Rooms) {
+		return &s.Rooms[i]
+	}
+	return nil
+}
+
+// RoomArea is the world-space area of a generated room
+type RoomArea struct {
+	X, Y, Width, Height float64
+}
+
+// Contains reports whether (x, y) is inside the room
+func (r RoomArea) Contains(x, y float64) bool {
+	return x >= r.X && x < r.X+r.Width && y >= r.Y && y < r.Y+r.Height
+}
+
+// distance returns the squared distance from (x, y) to the room, 0 inside it
+func (r RoomArea) distance(x, y float64) float64 {
+	dx := math.Max(0, math.Max(r.X-x, x-(r.X+r.Width)))
+	dy := math.Max(0, math.Max(r.Y-y, y-(r.Y+r.Height)))
+	return dx*dx + dy*dy
+}
+
+// RoomExitEdge is the side of a generated room the player leaves through
+type RoomExitEdge int
+
+const (
+	RoomExitRight RoomExitEdge = iota
+	RoomExitBottom
+	RoomExitTop
+)
+
+// RoomBoundary is the line the player crosses to leave a room
+type RoomBoundary struct {
+	Edge RoomExitEdge
+	Pos  float64 // world X for right exits, world Y for top/bottom exits
+}
+
+// Crossed reports whether a player at (x, y) is past the boundary
+func (b RoomBoundary) Crossed(x, y float64) bool {
+	switch b.Edge {
+	case RoomExitBottom:
+		return y >= b.Pos
+	case RoomExitTop:
+		return y < b.Pos
+	}
+	return x >= b.Pos
 }
 
 var RunStats = donburi.NewComponentType[RunStatsData]()
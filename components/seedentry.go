@@ -0,0 +1,13 @@
+package components
+
+import "github.com/yohamta/donburi"
+
+// SeedEntryData stores the current state of the seed code entry screen
+type SeedEntryData struct {
+	Code      string // characters typed so far
+	Invalid   bool   // the last submitted code could not be decoded
+	WrongPack bool   // the last submitted code is for another level pack's chunks
+}
+
+// SeedEntry is the component type for seed code entry state
+var SeedEntry = donburi.NewComponentType[SeedEntryData]()
@@ -20,6 +20,9 @@ const (
 	SoundBoomerangCatch
 	SoundBoomerangImpact
 	SoundBoomerangCharge
+	SoundPerfectCatch
+	// Pickup sounds
+	SoundRewardPickup
 	// UI sounds
 	SoundMenuNavigate
 	SoundMenuSelect
@@ -82,6 +85,8 @@ func init() {
 			SoundBoomerangCatch:  "audio/sfx/boomerang_catch.wav",
 			SoundBoomerangImpact: "audio/sfx/boomerang_impact.wav",
 			SoundBoomerangCharge: "audio/sfx/boomerang_charge.wav",
+			SoundPerfectCatch:    "audio/sfx/perfect_catch.wav",
+			SoundRewardPickup:    "audio/sfx/reward_pickup.wav",
 			SoundMenuNavigate:    "audio/sfx/menu_navigate.wav",
 			SoundMenuSelect:      "audio/sfx/menu_select.wav",
 		},
@@ -84,6 +84,11 @@ type EnemyTypeConfig struct {
 	MinVerticalToThrow float64 // Min vertical distance below which to walk to edge instead of direct throw
 	EdgeApproachSpeed  float64 // Speed when walking to platform edge
 	EdgeThrowDistance  float64 // Max horizontal distance to throw from edge
+
+	// Roguelite spawning. Ranged types only spawn on raised platforms.
+	SpawnWeight              int // Weight in a generated room's enemy pool; 0 with no per-difficulty weight never spawns
+	SpawnWeightPerDifficulty int // Added to the weight per point of room difficulty; may be negative
+	MinDifficulty            int // Lowest room difficulty it spawns in
 }
 
 // EnemyConfig contains enemy system configuration
@@ -142,6 +147,7 @@ type CombatConfig struct {
 	// Flash effects (frames)
 	HitFlashFrames    int // white flash when dealing damage
 	DamageFlashFrames int // red flash when taking damage
+	CatchFlashFrames  int // gold flash on a perfect boomerang catch
 }
 
 // PhysicsConfig contains physics-related configuration values
@@ -211,6 +217,46 @@ type BoomerangConfig struct {
 	HitKnockback         float64 // horizontal knockback applied to enemies on hit
 	BaseDamage           int     // minimum damage at no charge
 	MaxChargeDamageBonus int     // additional damage at full charge
+
+	RicochetBounces       int     // times it bounces off walls and slopes before returning; 0 returns on the first wall
+	RicochetDamageFalloff float64 // damage multiplier applied at each bounce
+
+	ReturnTurnRate        float64 // share of the way it turns toward the owner each frame on a quick throw
+	ReturnTurnRateCharged float64 // the same at full charge; lower swings a wider curve
+	ReturnTurnRamp        float64 // added to the turn rate each frame of the return so it always comes home
+
+	PerfectCatchWindow int // frames either side of a catch in which pressing throw makes it perfect
+	PerfectCatchCharge int // charge frames the next throw starts with after a perfect catch
+	PerfectCatchBonus  int // frames after a perfect catch to start that pre-charged throw
+
+	EmbedFrames  int     // frames a fully charged throw stays stuck in a wall as a platform; 0 never embeds
+	DropFrames   int     // frames a boomerang knocked loose by a hit waits to be picked up; 0 never drops
+	DropGravity  float64 // gravity on a dropped boomerang
+	DropFriction float64 // friction on a dropped boomerang
+}
+
+// AimPreviewConfig contains the boomerang trajectory preview configuration
+type AimPreviewConfig struct {
+	DotSpacing    float64    // Pixels of flight between trajectory dots
+	DotSize       float64    // Width and height of a trajectory dot
+	ReticleRadius float64    // Radius of the ring where the throw turns back
+	Color         color.RGBA // Dots and reticle at full charge
+	QuickColor    color.RGBA // Dots and reticle on a quick throw
+}
+
+// BoomerangModifierConfig contains the strength of each stack of the run
+// modifiers roguelite rewards give the boomerang
+type BoomerangModifierConfig struct {
+	MaxStacks        int     // pickups of one modifier that count; more restore health instead
+	RangeBonus       float64 // range added per stack of extra range
+	ExtraPierces     int     // enemies passed through per stack of multi-pierce before the short return
+	BurnFrames       int     // frames an enemy burns after a fire hit
+	BurnInterval     int     // frames between burn damage ticks
+	BurnDamage       int     // damage per burn tick, per stack of fire
+	TwinSpread       float64 // radians between the aim and each twin throw
+	ReturnSpeedBonus float64 // share of the return speed added per stack of faster return
+	LifestealHeal    int     // health restored per enemy hit, per stack of lifesteal
+	ExtraBounces     int     // wall ricochets added per stack of ricochet
 }
 
 // KnifeConfig contains knife projectile configuration
@@ -294,12 +340,138 @@ type RunSummaryConfig struct {
 	ValueColor        color.RGBA
 	TextColorNormal   color.RGBA
 	TextColorSelected color.RGBA
+	HighlightColor    color.RGBA // slowest room in the room table
+	DeathColor        color.RGBA // rooms the player died in
 	TitleY            float64
 	StatsStartY       float64
 	StatsRowHeight    float64
 	MenuStartY        float64
 	MenuItemHeight    float64
 	Title             string
+
+	// Room breakdown table
+	RoomsTitle       string
+	RoomsHeaderY     float64
+	RoomsStartY      float64
+	RoomsRowHeight   float64
+	RoomsVisibleRows int
+	RoomsIndexX      float64
+	RoomsChunkX      float64
+	RoomsTypeX       float64
+	RoomsTimeX       float64
+	RoomsKillsX      float64
+	RoomsDamageX     float64
+	RoomsDeathsX     float64
+}
+
+// LevelSelectConfig contains level select screen configuration values
+type LevelSelectConfig struct {
+	BackgroundColor   color.RGBA
+	TitleColor        color.RGBA
+	TextColorNormal   color.RGBA
+	TextColorSelected color.RGBA
+	TextColorLocked   color.RGBA
+	CompletedColor    color.RGBA
+	TitleY            float64
+	ListStartY        float64
+	RowHeight         float64
+	VisibleRows       int // rows shown at once; the list scrolls to keep the selection visible
+	NameX             float64
+	StatusX           float64
+	BestTimeX         float64
+	DeathsX           float64
+	Title             string
+}
+
+// DailyConfig contains daily run screen configuration values
+type DailyConfig struct {
+	BackgroundColor color.RGBA
+	TitleColor      color.RGBA
+	TextColor       color.RGBA
+	HighlightColor  color.RGBA
+	ClearedColor    color.RGBA
+	FailedColor     color.RGBA
+	TitleY          float64
+	TodayY          float64
+	StatusY         float64
+	HistoryTitleY   float64
+	HistoryStartY   float64
+	RowHeight       float64
+	VisibleRows     int // history rows shown at once; the list scrolls
+	DateX           float64
+	OutcomeX        float64
+	RoomsX          float64
+	KillsX          float64
+	TimeX           float64
+	Title           string
+}
+
+// RunHistoryConfig contains run history screen configuration values
+type RunHistoryConfig struct {
+	MaxEntries        int // runs kept on disk; the oldest are dropped first
+	BackgroundColor   color.RGBA
+	TitleColor        color.RGBA
+	TextColorNormal   color.RGBA
+	TextColorSelected color.RGBA
+	LabelColor        color.RGBA
+	ClearedColor      color.RGBA
+	FailedColor       color.RGBA
+	TitleY            float64
+	SortY             float64
+	ListStartY        float64
+	RowHeight         float64
+	VisibleRows       int // rows shown at once; the list scrolls to keep the selection visible
+	DateX             float64
+	OutcomeX          float64
+	RoomsX            float64
+	KillsX            float64
+	TimeX             float64
+	DetailStartY      float64
+	DetailRowHeight   float64
+	DetailLabelX      float64
+	DetailValueX      float64
+	DetailChunksWidth float64 // chunk list wraps at this width
+	ChunkLineHeight   float64
+	Title             string
+}
+
+// LevelPacksConfig contains level pack screen configuration values
+type LevelPacksConfig struct {
+	BackgroundColor   color.RGBA
+	TitleColor        color.RGBA
+	TextColorNormal   color.RGBA
+	TextColorSelected color.RGBA
+	ActiveColor       color.RGBA
+	ErrorColor        color.RGBA
+	TitleY            float64
+	ListStartY        float64
+	RowHeight         float64
+	VisibleRows       int // rows shown at once; the list scrolls to keep the selection visible
+	NameX             float64
+	ContentsX         float64
+	ActiveX           float64
+	DetailY           float64 // author and description of the selected pack
+	DetailLineHeight  float64
+	DetailWidth       float64 // description wraps at this width
+	Title             string
+}
+
+// SeedEntryConfig contains seed code entry screen configuration values
+type SeedEntryConfig struct {
+	BackgroundColor  color.RGBA
+	TitleColor       color.RGBA
+	TextColor        color.RGBA
+	CodeColor        color.RGBA
+	ErrorColor       color.RGBA
+	TitleY           float64
+	PromptY          float64
+	CodeY            float64
+	ErrorY           float64
+	MaxLength        int // characters accepted, including dashes
+	Title            string
+	Prompt           string
+	InvalidMessage   string
+	WrongPackMessage string
 }
 
 // ScreenShakeConfig contains screen shake effect configuration
@@ -340,16 +512,30 @@ type SquashStretchConfig struct {
 
 // LevelCompleteConfig contains level complete overlay configuration
 type LevelCompleteConfig struct {
-	OverlayColor color.RGBA
-	TitleColor   color.RGBA
-	TextColor    color.RGBA
-	HintColor    color.RGBA
-	TitleY       float64
-	MessageY     float64
-	HintY        float64
-	Title        string
-	Message      string
-	ContinueHint string
+	OverlayColor  color.RGBA
+	TitleColor    color.RGBA
+	TextColor     color.RGBA
+	HintColor     color.RGBA
+	TitleY        float64
+	MessageY      float64
+	HintY         float64
+	Title         string
+	MessageFormat string // formatted with the cleared level number and total level count
+	ContinueHint  string
+}
+
+// CampaignCompleteConfig contains campaign complete screen configuration
+type CampaignCompleteConfig struct {
+	BackgroundColor color.RGBA
+	TitleColor      color.RGBA
+	TextColor       color.RGBA
+	HintColor       color.RGBA
+	TitleY          float64
+	MessageY        float64
+	HintY           float64
+	Title           string
+	MessageFormat   string // formatted with the number of levels cleared
+	ContinueHint    string
 }
 
 // Config holds general game configuration
@@ -367,24 +553,45 @@ var Physics PhysicsConfig
 var Animation AnimationConfig
 var UI UIConfig
 var Boomerang BoomerangConfig
+var AimPreview AimPreviewConfig
+var BoomerangModifier BoomerangModifierConfig
 var Knife KnifeConfig
 var Fire FireConfig
 var Pause PauseConfig
 var Menu MenuConfig
 var GameOver GameOverConfig
 var RunSummary RunSummaryConfig
+var LevelSelect LevelSelectConfig
+var SeedEntry SeedEntryConfig
+var Daily DailyConfig
+var RunHistory RunHistoryConfig
+var LevelPacks LevelPacksConfig
 var ScreenShake ScreenShakeConfig
 var SquashStretch SquashStretchConfig
 var DeathZone DeathZoneConfig
 var Debug DebugConfig
+var Heatmap HeatmapConfig
 var Message MessageConfig
 var LevelComplete LevelCompleteConfig
+var CampaignComplete CampaignCompleteConfig
 var Camera CameraConfig
 
 // DebugConfig contains debug/testing command-line options
 type DebugConfig struct {
-	SkipMenu        bool    // Skip menu and go directly to game
-	StartCheckpoint float64 // Checkpoint ID to spawn at (-1 = use default)
+	SkipMenu         bool    // Skip menu and go directly to game
+	StartCheckpoint  float64 // Checkpoint ID to spawn at (-1 = use default)
+	RecordReplayPath string  // Record gameplay input to this file ("" = off)
+	PlayReplayPath   string  // Play back a recorded replay file ("" = off)
+	SeedCode         string  // Start the roguelite run for this seed code ("" = off)
+	TelemetryPath    string  // Append gameplay events to this JSONL file ("" = off)
+	HeatmapPath      string  // Telemetry log the debug heatmap reads ("" = TelemetryPath)
+	AssetDir         string  // Read assets from this directory and hot-reload them ("" = embedded)
+}
+
+// HeatmapConfig contains the debug heatmap overlay configuration
+type HeatmapConfig struct {
+	CellSize float64 // World pixels per heat cell
+	Alpha    uint8   // Opacity of the hottest cells
 }
 
 // MessageConfig contains message popup configuration
@@ -505,6 +712,46 @@ func init() {
 		HitKnockback:         2.0,
 		BaseDamage:           15,
 		MaxChargeDamageBonus: 15,
+
+		RicochetBounces:       0,
+		RicochetDamageFalloff: 0.75,
+
+		ReturnTurnRate:        0.3,
+		ReturnTurnRateCharged: 0.08,
+		ReturnTurnRamp:        0.01,
+
+		PerfectCatchWindow: 6,
+		PerfectCatchCharge: 45,
+		PerfectCatchBonus:  30,
+
+		// Only heavy boomerangs embed and drop, see ModifierHeavy
+		EmbedFrames:  240,
+		DropFrames:   600,
+		DropGravity:  0.5,
+		DropFriction: 0.2,
+	}
+
+	// Aim Preview Config
+	AimPreview = AimPreviewConfig{
+		DotSpacing:    14,
+		DotSize:       2,
+		ReticleRadius: 6,
+		Color:         color.RGBA{R: 255, G: 180, B: 50, A: 220},
+		QuickColor:    color.RGBA{R: 255, G: 255, B: 255, A: 140},
+	}
+
+	// Boomerang Modifier Config
+	BoomerangModifier = BoomerangModifierConfig{
+		MaxStacks:        3,
+		RangeBonus:       60,
+		ExtraPierces:     1,
+		BurnFrames:       120,
+		BurnInterval:     30,
+		BurnDamage:       4,
+		TwinSpread:       0.35,
+		ReturnSpeedBonus: 0.25,
+		LifestealHeal:    2,
+		ExtraBounces:     1,
 	}
 
 	// Knife Config
@@ -571,6 +818,7 @@ func init() {
 		SpriteScale:      1.0,
 		TintColor:        White,
 		SpriteSheetKey:   "player",
+		SpawnWeight:      30,
 	}
 
 	lightGuardType := EnemyTypeConfig{
@@ -598,6 +846,9 @@ func init() {
 		SpriteScale:      0.75,
 		TintColor:        Yellow,
 		SpriteSheetKey:   "player",
+		// Common early on, rarer as rooms get harder
+		SpawnWeight:              30,
+		SpawnWeightPerDifficulty: -3,
 	}
 
 	heavyGuardType := EnemyTypeConfig{
@@ -625,6 +876,9 @@ func init() {
 		SpriteScale:      1.4,
 		TintColor:        Orange,
 		SpriteSheetKey:   "player",
+		// Only in harder rooms, more common the harder they get
+		SpawnWeightPerDifficulty: 5,
+		MinDifficulty:            2,
 	}
 
 	knifeThrowerType := EnemyTypeConfig{
@@ -659,6 +913,9 @@ func init() {
 		MinVerticalToThrow: 32.0,  // If player is more than 32px below, walk to edge
 		EdgeApproachSpeed:  1.5,   // Speed when approaching platform edge
 		EdgeThrowDistance:  200.0, // Max horizontal distance to throw from edge
+		// Only in harder rooms, more common the harder they get
+		SpawnWeightPerDifficulty: 4,
+		MinDifficulty:            2,
 	}
 
 	Enemy = EnemyConfig{
@@ -710,6 +967,7 @@ func init() {
 		// Flash effects
 		HitFlashFrames:    3,
 		DamageFlashFrames: 5,
+		CatchFlashFrames:  8,
 	}
 
 	// Pause Config
@@ -729,10 +987,10 @@ func init() {
 		TextColorNormal:      White,
 		TextColorSelected:    BrightOrange,
 		TitleY:               50,
-		MenuStartY:           100,
-		MenuItemHeight:       30,
-		MenuItemGap:          12,
-		MenuOptions:          []string{"Start", "Continue", "Settings", "Exit"},
+		MenuStartY:           70,
+		MenuItemHeight:       22,
+		MenuItemGap:          4,
+		MenuOptions:          []string{"Start", "Continue", "Roguelite", "Daily Run", "Enter Seed", "Run History", "Level Select", "Level Packs", "Settings", "Exit"},
 		ConfirmDialogMessage: "Overwrite existing save?",
 		ConfirmDialogYes:     "Yes",
 		ConfirmDialogNo:      "No",
@@ -759,12 +1017,137 @@ func init() {
 		ValueColor:        LightBlue,
 		TextColorNormal:   White,
 		TextColorSelected: BrightOrange,
-		TitleY:            80,
-		StatsStartY:       140,
-		StatsRowHeight:    36,
-		MenuStartY:        290,
-		MenuItemHeight:    30,
+		HighlightColor:    BrightOrange,
+		DeathColor:        LightRed,
+		TitleY:            70,
+		StatsStartY:       120,
+		StatsRowHeight:    20,
+		MenuStartY:        260,
+		MenuItemHeight:    28,
 		Title:             "RUN COMPLETE",
+
+		RoomsTitle:       "ROOM BREAKDOWN",
+		RoomsHeaderY:     104,
+		RoomsStartY:      130,
+		RoomsRowHeight:   22,
+		RoomsVisibleRows: 9,
+		RoomsIndexX:      24,
+		RoomsChunkX:      56,
+		RoomsTypeX:       256,
+		RoomsTimeX:       356,
+		RoomsKillsX:      446,
+		RoomsDamageX:     506,
+		RoomsDeathsX:     576,
+	}
+
+	// Level Select Config
+	LevelSelect = LevelSelectConfig{
+		BackgroundColor:   color.RGBA{R: 15, G: 25, B: 50, A: 255},
+		TitleColor:        Orange,
+		TextColorNormal:   White,
+		TextColorSelected: BrightOrange,
+		TextColorLocked:   color.RGBA{R: 110, G: 110, B: 120, A: 255},
+		CompletedColor:    BrightGreen,
+		TitleY:            50,
+		ListStartY:        100,
+		RowHeight:         28,
+		VisibleRows:       8,
+		NameX:             60,
+		StatusX:           260,
+		BestTimeX:         400,
+		DeathsX:           520,
+		Title:             "LEVEL SELECT",
+	}
+
+	// Daily Run Config
+	Daily = DailyConfig{
+		BackgroundColor: color.RGBA{R: 15, G: 25, B: 50, A: 255},
+		TitleColor:      Orange,
+		TextColor:       White,
+		HighlightColor:  BrightOrange,
+		ClearedColor:    BrightGreen,
+		FailedColor:     LightRed,
+		TitleY:          50,
+		TodayY:          95,
+		StatusY:         125,
+		HistoryTitleY:   170,
+		HistoryStartY:   198,
+		RowHeight:       24,
+		VisibleRows:     6,
+		DateX:           60,
+		OutcomeX:        200,
+		RoomsX:          330,
+		KillsX:          430,
+		TimeX:           530,
+		Title:           "DAILY RUN",
+	}
+
+	// Level Packs Config
+	LevelPacks = LevelPacksConfig{
+		BackgroundColor:   color.RGBA{R: 15, G: 25, B: 50, A: 255},
+		TitleColor:        Orange,
+		TextColorNormal:   White,
+		TextColorSelected: BrightOrange,
+		ActiveColor:       BrightGreen,
+		ErrorColor:        LightRed,
+		TitleY:            50,
+		ListStartY:        100,
+		RowHeight:         28,
+		VisibleRows:       5,
+		NameX:             40,
+		ContentsX:         330,
+		ActiveX:           540,
+		DetailY:           256,
+		DetailLineHeight:  16,
+		DetailWidth:       560,
+		Title:             "LEVEL PACKS",
+	}
+
+	// Run History Config
+	RunHistory = RunHistoryConfig{
+		MaxEntries:        200,
+		BackgroundColor:   color.RGBA{R: 15, G: 25, B: 50, A: 255},
+		TitleColor:        Orange,
+		TextColorNormal:   White,
+		TextColorSelected: BrightOrange,
+		LabelColor:        color.RGBA{R: 180, G: 180, B: 190, A: 255},
+		ClearedColor:      BrightGreen,
+		FailedColor:       LightRed,
+		TitleY:            50,
+		SortY:             80,
+		ListStartY:        112,
+		RowHeight:         24,
+		VisibleRows:       9,
+		DateX:             40,
+		OutcomeX:          210,
+		RoomsX:            320,
+		KillsX:            420,
+		TimeX:             520,
+		DetailStartY:      90,
+		DetailRowHeight:   22,
+		DetailLabelX:      60,
+		DetailValueX:      220,
+		DetailChunksWidth: 380,
+		ChunkLineHeight:   16,
+		Title:             "RUN HISTORY",
+	}
+
+	// Seed Entry Config
+	SeedEntry = SeedEntryConfig{
+		BackgroundColor:  color.RGBA{R: 15, G: 25, B: 50, A: 255},
+		TitleColor:       Orange,
+		TextColor:        White,
+		CodeColor:        BrightOrange,
+		ErrorColor:       LightRed,
+		TitleY:           50,
+		PromptY:          120,
+		CodeY:            170,
+		ErrorY:           210,
+		MaxLength:        24,
+		Title:            "ENTER SEED",
+		Prompt:           "Type a seed code to play that exact run",
+		InvalidMessage:   "Invalid seed code",
+		WrongPackMessage: "Seed code is for a different level pack",
 	}
 
 	// Screen Shake Config
@@ -800,6 +1183,12 @@ func init() {
 		StartCheckpoint: -1,
 	}
 
+	// Debug heatmap overlay
+	Heatmap = HeatmapConfig{
+		CellSize: 32,
+		Alpha:    140,
+	}
+
 	// Message Config
 	Message = MessageConfig{
 		ActivationRadius: 100.0, // Large radius to catch jumping players
@@ -838,16 +1227,30 @@ func init() {
 
 	// Level Complete Config
 	LevelComplete = LevelCompleteConfig{
-		OverlayColor: BlackOverlay,
-		TitleColor:   BrightGreen,
-		TextColor:    White,
-		HintColor:    White,
-		TitleY:       80,
-		MessageY:     140,
-		HintY:        280,
-		Title:        "Level Complete!",
-		Message:      "Thanks for playing Level 1! More levels coming, stay tuned...",
-		ContinueHint: "Press ENTER to exit",
+		OverlayColor:  BlackOverlay,
+		TitleColor:    BrightGreen,
+		TextColor:     White,
+		HintColor:     White,
+		TitleY:        80,
+		MessageY:      140,
+		HintY:         280,
+		Title:         "Level Complete!",
+		MessageFormat: "Level %d of %d cleared",
+		ContinueHint:  "Press ENTER to continue",
+	}
+
+	// Campaign Complete Config
+	CampaignComplete = CampaignCompleteConfig{
+		BackgroundColor: color.RGBA{R: 10, G: 30, B: 10, A: 255},
+		TitleColor:      BrightGreen,
+		TextColor:       White,
+		HintColor:       White,
+		TitleY:          100,
+		MessageY:        160,
+		HintY:           280,
+		Title:           "CAMPAIGN COMPLETE",
+		MessageFormat:   "You cleared all %d levels. Thanks for playing!",
+		ContinueHint:    "Press ENTER to return to menu",
 	}
 
 	// Camera Config
@@ -0,0 +1,290 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"image/color"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Enemy definition files let designers tune or add enemy types without
+// touching Go code. Each .json file in the enemies directory defines one type:
+//
+//	{
+//	  "name": "HeavyKnifeThrower",
+//	  "extends": "KnifeThrower",
+//	  "health": 60,
+//	  "tint": "#7030A0"
+//	}
+//
+// Fields left out keep the values of the type named by "extends", or of the
+// built-in type with the same name when overriding one. A new type that
+// extends nothing must set every field it needs. Field names match
+// EnemyTypeConfig in lower camel case, and tints are "#RRGGBB" or "#RRGGBBAA".
+
+// enemyTypeFields mirrors EnemyTypeConfig with the names used in definition
+// files. The two must keep the same fields so they convert into each other.
+type enemyTypeFields struct {
+	Name               string     `json:"name"`
+	Health             int        `json:"health"`
+	PatrolSpeed        float64    `json:"patrolSpeed"`
+	ChaseSpeed         float64    `json:"chaseSpeed"`
+	AttackRange        float64    `json:"attackRange"`
+	ChaseRange         float64    `json:"chaseRange"`
+	StoppingDistance   float64    `json:"stoppingDistance"`
+	MaxVerticalChase   float64    `json:"maxVerticalChase"`
+	AttackCooldown     int        `json:"attackCooldown"`
+	InvulnFrames       int        `json:"invulnFrames"`
+	AttackDuration     int        `json:"attackDuration"`
+	HitstunDuration    int        `json:"hitstunDuration"`
+	Damage             int        `json:"damage"`
+	KnockbackForce     float64    `json:"knockbackForce"`
+	Gravity            float64    `json:"gravity"`
+	Friction           float64    `json:"friction"`
+	MaxSpeed           float64    `json:"maxSpeed"`
+	FrameWidth         int        `json:"frameWidth"`
+	FrameHeight        int        `json:"frameHeight"`
+	CollisionWidth     int        `json:"collisionWidth"`
+	CollisionHeight    int        `json:"collisionHeight"`
+	SpriteScale        float64    `json:"spriteScale"`
+	TintColor          color.RGBA `json:"-"` // read from enemyTypeFile.Tint
+	SpriteSheetKey     string     `json:"spriteSheet"`
+	IsRanged           bool       `json:"isRanged"`
+	ThrowRange         float64    `json:"throwRange"`
+	ThrowCooldown      int        `json:"throwCooldown"`
+	ThrowWindupTime    int        `json:"throwWindupTime"`
+	MinVerticalToThrow float64    `json:"minVerticalToThrow"`
+	EdgeApproachSpeed  float64    `json:"edgeApproachSpeed"`
+	EdgeThrowDistance  float64    `json:"edgeThrowDistance"`
+
+	SpawnWeight              int `json:"spawnWeight"`
+	SpawnWeightPerDifficulty int `json:"spawnWeightPerDifficulty"`
+	MinDifficulty            int `json:"minDifficulty"`
+}
+
+// enemyTypeFile is the layout of one enemy definition file
+type enemyTypeFile struct {
+	Extends string  `json:"extends"`
+	Tint    *string `json:"tint"`
+	enemyTypeFields
+}
+
+// LoadEnemyTypes reads every enemy definition in dir and adds the types to
+// Enemy.Types, replacing built-in types of the same name. Nothing is changed
+// if any definition is invalid. A missing dir is not an error.
+func LoadEnemyTypes(fsys fs.FS, dir string) error {
+	entries, err := fs.ReadDir(fsys, dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read enemy definitions: %w", err)
+	}
+
+	defs := map[string]enemyDefinition{}
+	for _, entry := range entries {
+		if entry.IsDir() || path.Ext(entry.Name()) != ".json" {
+			continue
+		}
+		file := path.Join(dir, entry.Name())
+		data, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", file, err)
+		}
+		def, err := parseEnemyDefinition(data)
+		if err != nil {
+			return fmt.Errorf("%s: %w", file, err)
+		}
+		def.file = file
+		if other, ok := defs[def.Name]; ok {
+			return fmt.Errorf("%s: enemy type %q is already defined in %s", file, def.Name, other.file)
+		}
+		defs[def.Name] = def
+	}
+
+	types, err := resolveEnemyTypes(Enemy.Types, defs)
+	if err != nil {
+		return err
+	}
+	Enemy.Types = types
+	return nil
+}
+
+// enemyDefinition is a parsed definition file, not yet applied to its base type
+type enemyDefinition struct {
+	enemyTypeFile
+	file string
+	raw  []byte
+}
+
+func parseEnemyDefinition(data []byte) (enemyDefinition, error) {
+	var def enemyDefinition
+	if err := decodeStrictJSON(data, &def.enemyTypeFile); err != nil {
+		return def, err
+	}
+	if def.Name == "" {
+		return def, errors.New(`"name" is required`)
+	}
+	if def.Extends == def.Name {
+		return def, fmt.Errorf("%q cannot extend itself", def.Name)
+	}
+	def.raw = data
+	return def, nil
+}
+
+// resolveEnemyTypes applies defs on top of their base types and validates the
+// results. Definitions may extend each other in any order.
+func resolveEnemyTypes(builtin map[string]EnemyTypeConfig, defs map[string]enemyDefinition) (map[string]EnemyTypeConfig, error) {
+	types := make(map[string]EnemyTypeConfig, len(builtin)+len(defs))
+	for name, t := range builtin {
+		types[name] = t
+	}
+
+	resolved := map[string]bool{}
+	var resolve func(name string, chain []string) error
+	resolve = func(name string, chain []string) error {
+		def := defs[name]
+		if resolved[name] {
+			return nil
+		}
+		for _, seen := range chain {
+			if seen == name {
+				return fmt.Errorf("%s: %q extends itself through %s", def.file, name, strings.Join(append(chain, name), " -> "))
+			}
+		}
+
+		base, hasBase := builtin[name]
+		if def.Extends != "" {
+			if _, isDef := defs[def.Extends]; isDef {
+				if err := resolve(def.Extends, append(chain, name)); err != nil {
+					return err
+				}
+			} else if _, isBuiltin := builtin[def.Extends]; !isBuiltin {
+				return fmt.Errorf("%s: %q extends unknown enemy type %q", def.file, name, def.Extends)
+			}
+			base, hasBase = types[def.Extends], true
+		}
+
+		file := enemyTypeFile{enemyTypeFields: enemyTypeFields(base)}
+		if err := decodeStrictJSON(def.raw, &file); err != nil {
+			return fmt.Errorf("%s: %w", def.file, err)
+		}
+		t := EnemyTypeConfig(file.enemyTypeFields)
+		if file.Tint != nil {
+			tint, err := parseTint(*file.Tint)
+			if err != nil {
+				return fmt.Errorf("%s: %w", def.file, err)
+			}
+			t.TintColor = tint
+		} else if !hasBase {
+			t.TintColor = White
+		}
+		if err := ValidateEnemyType(t); err != nil {
+			if !hasBase {
+				return fmt.Errorf("%s: %w (new types must set every field or extend an existing type)", def.file, err)
+			}
+			return fmt.Errorf("%s: %w", def.file, err)
+		}
+		types[name] = t
+		resolved[name] = true
+		return nil
+	}
+
+	names := make([]string, 0, len(defs))
+	for name := range defs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err := resolve(name, nil); err != nil {
+			return nil, err
+		}
+	}
+	return types, nil
+}
+
+// enemyField is a numeric definition field, named as in definition files
+type enemyField struct {
+	name  string
+	value float64
+}
+
+// ValidateEnemyType reports the first value in t that the game can't use
+func ValidateEnemyType(t EnemyTypeConfig) error {
+	positive := []enemyField{
+		{"health", float64(t.Health)},
+		{"frameWidth", float64(t.FrameWidth)},
+		{"frameHeight", float64(t.FrameHeight)},
+		{"collisionWidth", float64(t.CollisionWidth)},
+		{"collisionHeight", float64(t.CollisionHeight)},
+		{"spriteScale", t.SpriteScale},
+		{"maxSpeed", t.MaxSpeed},
+	}
+	if t.IsRanged {
+		positive = append(positive, enemyField{"throwRange", t.ThrowRange}, enemyField{"throwCooldown", float64(t.ThrowCooldown)})
+	} else {
+		positive = append(positive, enemyField{"attackRange", t.AttackRange}, enemyField{"attackDuration", float64(t.AttackDuration)})
+	}
+	for _, f := range positive {
+		if f.value <= 0 {
+			return fmt.Errorf("%q must be positive, got %v", f.name, f.value)
+		}
+	}
+
+	nonNegative := []enemyField{
+		{"patrolSpeed", t.PatrolSpeed},
+		{"chaseSpeed", t.ChaseSpeed},
+		{"chaseRange", t.ChaseRange},
+		{"stoppingDistance", t.StoppingDistance},
+		{"maxVerticalChase", t.MaxVerticalChase},
+		{"attackCooldown", float64(t.AttackCooldown)},
+		{"invulnFrames", float64(t.InvulnFrames)},
+		{"hitstunDuration", float64(t.HitstunDuration)},
+		{"damage", float64(t.Damage)},
+		{"knockbackForce", t.KnockbackForce},
+		{"gravity", t.Gravity},
+		{"friction", t.Friction},
+		{"throwWindupTime", float64(t.ThrowWindupTime)},
+		{"minVerticalToThrow", t.MinVerticalToThrow},
+		{"edgeApproachSpeed", t.EdgeApproachSpeed},
+		{"edgeThrowDistance", t.EdgeThrowDistance},
+		{"spawnWeight", float64(t.SpawnWeight)},
+		{"minDifficulty", float64(t.MinDifficulty)},
+	}
+	for _, f := range nonNegative {
+		if f.value < 0 {
+			return fmt.Errorf("%q must not be negative, got %v", f.name, f.value)
+		}
+	}
+
+	if t.CollisionWidth > t.FrameWidth || t.CollisionHeight > t.FrameHeight {
+		return fmt.Errorf("collision box %dx%d must fit in the %dx%d frame",
+			t.CollisionWidth, t.CollisionHeight, t.FrameWidth, t.FrameHeight)
+	}
+	if _, ok := CharacterAnimations[t.SpriteSheetKey]; !ok {
+		keys := make([]string, 0, len(CharacterAnimations))
+		for key := range CharacterAnimations {
+			keys = append(keys, strconv.Quote(key))
+		}
+		sort.Strings(keys)
+		return fmt.Errorf("unknown spriteSheet %q, expected one of %s", t.SpriteSheetKey, strings.Join(keys, ", "))
+	}
+	return nil
+}
+
+// parseTint reads a "#RRGGBB" or "#RRGGBBAA" color
+func parseTint(s string) (color.RGBA, error) {
+	hex := strings.TrimPrefix(s, "#")
+	if len(hex) == 6 {
+		hex += "ff"
+	}
+	v, err := strconv.ParseUint(hex, 16, 32)
+	if len(hex) != 8 || err != nil {
+		return color.RGBA{}, fmt.Errorf(`"tint" must look like "#RRGGBB" or "#RRGGBBAA", got %q`, s)
+	}
+	return color.RGBA{R: uint8(v >> 24), G: uint8(v >> 16), B: uint8(v >> 8), A: uint8(v)}, nil
+}
@@ -0,0 +1,116 @@
+package config_test
+
+import (
+	"fmt"
+	"image/color"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/automoto/doomerang/assets"
+	"github.com/automoto/doomerang/config"
+)
+
+// withBuiltinEnemies restores the built-in enemy types after a test loads definitions
+func withBuiltinEnemies(t *testing.T) {
+	saved := config.Enemy.Types
+	t.Cleanup(func() { config.Enemy.Types = saved })
+}
+
+func TestBuiltinEnemyTypesAreValid(t *testing.T) {
+	for name, et := range config.Enemy.Types {
+		if err := config.ValidateEnemyType(et); err != nil {
+			t.Errorf("%s: %v", name, err)
+		}
+	}
+}
+
+func TestLoadShippedEnemyTypes(t *testing.T) {
+	withBuiltinEnemies(t)
+	if err := config.LoadEnemyTypes(assets.GetAssetFS(), "enemies"); err != nil {
+		t.Fatalf("shipped enemy definitions failed to load: %v", err)
+	}
+	heavy, ok := config.Enemy.Types["HeavyKnifeThrower"]
+	if !ok {
+		t.Fatal("expected HeavyKnifeThrower to be defined")
+	}
+	if !heavy.IsRanged || heavy.ThrowRange != config.Enemy.Types["KnifeThrower"].ThrowRange {
+		t.Errorf("expected HeavyKnifeThrower to keep KnifeThrower's ranged settings, got %+v", heavy)
+	}
+}
+
+func TestLoadEnemyTypesExtendsAndOverrides(t *testing.T) {
+	withBuiltinEnemies(t)
+	guard := config.Enemy.Types["Guard"]
+	fsys := fstest.MapFS{
+		// Extends a type that is itself defined in a file loaded after it
+		"enemies/a_elite.json": {Data: []byte(`{"name": "EliteBrute", "extends": "Brute", "health": 300}`)},
+		"enemies/brute.json":   {Data: []byte(`{"name": "Brute", "extends": "Guard", "damage": 50, "tint": "#FF000080"}`)},
+		"enemies/guard.json":   {Data: []byte(`{"name": "Guard", "health": 80}`)},
+		"enemies/notes.txt":    {Data: []byte("not a definition")},
+	}
+	if err := config.LoadEnemyTypes(fsys, "enemies"); err != nil {
+		t.Fatalf("LoadEnemyTypes failed: %v", err)
+	}
+
+	types := config.Enemy.Types
+	if got := types["Guard"]; got.Health != 80 || got.Damage != guard.Damage {
+		t.Errorf("expected the Guard override to change only health, got %+v", got)
+	}
+	brute := types["Brute"]
+	if brute.Health != 80 || brute.Damage != 50 || brute.ChaseSpeed != guard.ChaseSpeed {
+		t.Errorf("expected Brute to extend the overridden Guard, got %+v", brute)
+	}
+	if want := (color.RGBA{R: 255, A: 128}); brute.TintColor != want {
+		t.Errorf("expected tint %v, got %v", want, brute.TintColor)
+	}
+	if elite := types["EliteBrute"]; elite.Health != 300 || elite.Damage != 50 || elite.Name != "EliteBrute" {
+		t.Errorf("expected EliteBrute to extend Brute, got %+v", elite)
+	}
+}
+
+func TestLoadEnemyTypesErrors(t *testing.T) {
+	guard := config.Enemy.Types["Guard"]
+	tests := []struct {
+		name string
+		file string
+		want string
+	}{
+		{"syntax", "{\n  \"name\": \"Bad\",\n  \"health\" 10\n}", "enemies/bad.json: line 3"},
+		{"unknown field", `{"name": "Guard", "helth": 10}`, `unknown field "helth"`},
+		{"wrong type", `{"name": "Guard", "health": "lots"}`, `"health" must be a whole number, got string`},
+		{"missing name", `{"health": 10}`, `"name" is required`},
+		{"negative", `{"name": "Guard", "gravity": -1}`, `"gravity" must not be negative`},
+		{"unknown base", `{"name": "Bad", "extends": "Dragon"}`, `unknown enemy type "Dragon"`},
+		{"bad tint", `{"name": "Guard", "tint": "red"}`, `"tint" must look like`},
+		{"bad sprite", `{"name": "Guard", "spriteSheet": "dragon"}`, `unknown spriteSheet "dragon"`},
+		{"oversized box", `{"name": "Guard", "collisionHeight": 200}`, fmt.Sprintf("must fit in the %dx%d frame", guard.FrameWidth, guard.FrameHeight)},
+		{"incomplete new type", `{"name": "Blob", "health": 10}`, "new types must set every field"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			withBuiltinEnemies(t)
+			before := len(config.Enemy.Types)
+			fsys := fstest.MapFS{"enemies/bad.json": {Data: []byte(tt.file)}}
+			err := config.LoadEnemyTypes(fsys, "enemies")
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("expected an error containing %q, got %v", tt.want, err)
+			}
+			if len(config.Enemy.Types) != before || config.Enemy.Types["Guard"] != guard {
+				t.Error("expected a failed load to leave the enemy types unchanged")
+			}
+		})
+	}
+
+	withBuiltinEnemies(t)
+	cycle := fstest.MapFS{
+		"enemies/a.json": {Data: []byte(`{"name": "A", "extends": "B"}`)},
+		"enemies/b.json": {Data: []byte(`{"name": "B", "extends": "A"}`)},
+	}
+	if err := config.LoadEnemyTypes(cycle, "enemies"); err == nil || !strings.Contains(err.Error(), "A -> B -> A") {
+		t.Errorf("expected a cycle error, got %v", err)
+	}
+	if err := config.LoadEnemyTypes(fstest.MapFS{}, "enemies"); err != nil {
+		t.Errorf("expected a missing directory to be ignored, got %v", err)
+	}
+}
@@ -24,6 +24,10 @@ const (
 	ActionCount // Must be last - used for array sizing
 )
 
+// AimSteps is how many directions free aim is rounded to, so that a replay
+// can store it in a byte and play it back exactly
+const AimSteps = 256
+
 // InputBinding represents a single key or button binding for an action
 type InputBinding struct {
 	Keys                   []ebiten.Key
@@ -0,0 +1,53 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// decodeStrictJSON decodes the single JSON value in data over the values
+// already in v. Unknown fields are rejected so typos don't silently keep a
+// default, and errors name the line they were found on.
+func decodeStrictJSON(data []byte, v interface{}) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	err := dec.Decode(v)
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &syntaxErr):
+		return fmt.Errorf("line %d: %v", lineOf(data, syntaxErr.Offset), syntaxErr)
+	case errors.As(err, &typeErr):
+		return fmt.Errorf("line %d: %q must be %s, got %s", lineOf(data, typeErr.Offset), typeErr.Field, jsonKindName(typeErr.Type), typeErr.Value)
+	case err != nil:
+		return err
+	}
+	if dec.More() {
+		return errors.New("expected a single JSON object")
+	}
+	return nil
+}
+
+// jsonKindName describes the JSON value a field of type t takes
+func jsonKindName(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.Int, reflect.Int64:
+		return "a whole number"
+	case reflect.Float64:
+		return "a number"
+	case reflect.Bool:
+		return "true or false"
+	}
+	return "a string"
+}
+
+// lineOf returns the 1-based line of byte offset in data
+func lineOf(data []byte, offset int64) int {
+	if offset > int64(len(data)) {
+		offset = int64(len(data))
+	}
+	return bytes.Count(data[:offset], []byte("\n")) + 1
+}
@@ -17,6 +17,7 @@ type ProcgenConfig struct {
 	EnemyBudgetBase       float64 // Base budget for enemy placement
 	EnemyBudgetMultiplier float64 // Budget multiplier per difficulty level
 	EnemyMinSpacing       float64 // Minimum pixels between enemies
+	EnemyMinSpawnWeight   int     // Lowest pool weight of an enemy type that can spawn in a room
 
 	// Enemy point costs
 	EnemyCosts map[string]int
@@ -30,6 +31,10 @@ type ProcgenConfig struct {
 	MinRunLength     int
 	MaxRunLength     int
 
+	// Daily runs pick their length from this range
+	DailyMinLength int
+	DailyMaxLength int
+
 	// Connection points
 	StandardConnectionHeight int // Standard Y-offset for connection points (in tiles)
 	ConnectionOpeningWidth   int // Standard opening width (in tiles)
@@ -37,6 +42,11 @@ type ProcgenConfig struct {
 	// Level layout
 	ChunkHeadroomFactor float64 // Fraction of screen height added above chunks for camera headroom
 
+	// Branches off the main path (chance per middle node)
+	SidePathChance  float64  // Risky side path that bypasses the next room and rejoins
+	BonusRoomChance float64  // Dead-end bonus room
+	RewardTypes     []string // Rewards placed at the end of each branch, one picked per branch
+
 	// Biomes available for graph generation
 	Biomes []string
 }
@@ -58,6 +68,7 @@ func init() {
 		EnemyBudgetBase:       4.0,
 		EnemyBudgetMultiplier: 3.0,
 		EnemyMinSpacing:       120.0,
+		EnemyMinSpawnWeight:   5,
 
 		EnemyCosts: map[string]int{
 			"LightGuard":   2,
@@ -73,10 +84,17 @@ func init() {
 		MinRunLength:     8,
 		MaxRunLength:     25,
 
+		DailyMinLength: 8,
+		DailyMaxLength: 15,
+
 		StandardConnectionHeight: 20,  // tiles from top
 		ConnectionOpeningWidth:   3,   // tiles wide
 		ChunkHeadroomFactor:      0.15, // 15% of screen height
 
+		SidePathChance:  0.12,
+		BonusRoomChance: 0.08,
+		RewardTypes:     []string{"health", "range", "pierce", "fire", "twin", "return", "lifesteal", "ricochet", "heavy"},
+
 		Biomes: []string{"cyberpunk", "industrial", "neon"},
 	}
 }
@@ -0,0 +1,54 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+)
+
+// TuningFile is the data file in an asset directory that overrides the
+// Player, Combat, Physics and Boomerang values while developing:
+//
+//	{
+//	  "player": {"jumpSpeed": 16},
+//	  "boomerang": {"throwSpeed": 9, "baseRange": 220}
+//	}
+//
+// Field names match the config structs in lower camel case. Values left out
+// keep their built-in defaults, including ones removed since the last load.
+const TuningFile = "tuning.json"
+
+// tuningValues is the layout of TuningFile
+type tuningValues struct {
+	Player    PlayerConfig    `json:"player"`
+	Combat    CombatConfig    `json:"combat"`
+	Physics   PhysicsConfig   `json:"physics"`
+	Boomerang BoomerangConfig `json:"boomerang"`
+}
+
+// tuningDefaults holds the built-in values, saved before the first load
+var tuningDefaults *tuningValues
+
+// LoadTuning applies the tuning file at path on top of the built-in values.
+// Nothing is changed if the file is invalid, and a missing file restores the
+// built-in values.
+func LoadTuning(fsys fs.FS, path string) error {
+	if tuningDefaults == nil {
+		tuningDefaults = &tuningValues{Player: Player, Combat: Combat, Physics: Physics, Boomerang: Boomerang}
+	}
+
+	values := *tuningDefaults
+	data, err := fs.ReadFile(fsys, path)
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+	case err != nil:
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	default:
+		if err := decodeStrictJSON(data, &values); err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+	}
+
+	Player, Combat, Physics, Boomerang = values.Player, values.Combat, values.Physics, values.Boomerang
+	return nil
+}
@@ -0,0 +1,52 @@
+package config_test
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/automoto/doomerang/config"
+)
+
+func TestLoadTuning(t *testing.T) {
+	player, boomerang := config.Player, config.Boomerang
+	t.Cleanup(func() { config.Player, config.Boomerang = player, boomerang })
+
+	fsys := fstest.MapFS{config.TuningFile: {Data: []byte(`{
+		"player": {"jumpSpeed": 99},
+		"boomerang": {"throwSpeed": 12.5, "baseDamage": 3}
+	}`)}}
+	if err := config.LoadTuning(fsys, config.TuningFile); err != nil {
+		t.Fatalf("LoadTuning failed: %v", err)
+	}
+	if config.Player.JumpSpeed != 99 || config.Player.MaxSpeed != player.MaxSpeed {
+		t.Errorf("expected only jumpSpeed to change, got %+v", config.Player)
+	}
+	if config.Boomerang.ThrowSpeed != 12.5 || config.Boomerang.BaseDamage != 3 {
+		t.Errorf("expected boomerang values to change, got %+v", config.Boomerang)
+	}
+
+	// Removing a value from the file restores its default
+	fsys[config.TuningFile] = &fstest.MapFile{Data: []byte(`{"boomerang": {"baseDamage": 4}}`)}
+	if err := config.LoadTuning(fsys, config.TuningFile); err != nil {
+		t.Fatalf("LoadTuning failed: %v", err)
+	}
+	if config.Player.JumpSpeed != player.JumpSpeed || config.Boomerang.ThrowSpeed != boomerang.ThrowSpeed {
+		t.Errorf("expected values left out of the file to return to their defaults")
+	}
+
+	// A broken file changes nothing
+	fsys[config.TuningFile] = &fstest.MapFile{Data: []byte(`{"boomerang": {"baseDamage": 5, "thrwSpeed": 1}}`)}
+	err := config.LoadTuning(fsys, config.TuningFile)
+	if err == nil || !strings.Contains(err.Error(), `unknown field "thrwSpeed"`) {
+		t.Fatalf("expected an unknown field error, got %v", err)
+	}
+	if config.Boomerang.BaseDamage != 4 {
+		t.Errorf("expected a failed load to keep the previous values, got baseDamage %d", config.Boomerang.BaseDamage)
+	}
+
+	delete(fsys, config.TuningFile)
+	if err := config.LoadTuning(fsys, config.TuningFile); err != nil || config.Boomerang.BaseDamage != boomerang.BaseDamage {
+		t.Errorf("expected a deleted file to restore the defaults, got %v and baseDamage %d", err, config.Boomerang.BaseDamage)
+	}
+}
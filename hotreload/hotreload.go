@@ -0,0 +1,122 @@
+// Package hotreload watches an on-disk asset directory while developing and
+// reloads tuning values and procgen chunks when their files change, so a
+// tweak shows up without rebuilding or replaying back to the same spot.
+//
+// Tuning values are applied in place and take effect on the next frame that
+// reads them; values copied into entities when they spawn apply to the next
+// spawn. Chunks are read from disk by every generation, so a changed chunk is
+// used from the next run; reloading here parses it straight away so mistakes
+// are reported while the file is still open in the editor.
+package hotreload
+
+import (
+	"io/fs"
+	"log"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/automoto/doomerang/config"
+	"github.com/automoto/doomerang/procgen"
+)
+
+// PollInterval is how many frames pass between checks for changed files
+const PollInterval = 30
+
+// chunkDir is where procgen chunks live in the asset directory
+const chunkDir = "chunks"
+
+// Watcher polls an asset directory for changes to the tuning file and chunks
+type Watcher struct {
+	fsys   fs.FS
+	mtimes map[string]time.Time
+	frame  int
+}
+
+// NewWatcher starts watching fsys. Files already there are not reported as changed.
+func NewWatcher(fsys fs.FS) *Watcher {
+	w := &Watcher{fsys: fsys}
+	w.mtimes = w.scan()
+	return w
+}
+
+// Update checks for changed files every PollInterval frames and reloads them
+func (w *Watcher) Update() {
+	w.frame++
+	if w.frame%PollInterval != 0 {
+		return
+	}
+	for _, file := range w.Changed() {
+		Reload(w.fsys, file)
+	}
+}
+
+// Changed returns the watched files created, modified or deleted since the
+// last call, sorted by path
+func (w *Watcher) Changed() []string {
+	mtimes := w.scan()
+	var changed []string
+	for file, mtime := range mtimes {
+		if old, ok := w.mtimes[file]; !ok || !old.Equal(mtime) {
+			changed = append(changed, file)
+		}
+	}
+	for file := range w.mtimes {
+		if _, ok := mtimes[file]; !ok {
+			changed = append(changed, file)
+		}
+	}
+	w.mtimes = mtimes
+	sort.Strings(changed)
+	return changed
+}
+
+// scan returns the modification time of every watched file
+func (w *Watcher) scan() map[string]time.Time {
+	mtimes := map[string]time.Time{}
+	if info, err := fs.Stat(w.fsys, config.TuningFile); err == nil {
+		mtimes[config.TuningFile] = info.ModTime()
+	}
+	entries, err := fs.ReadDir(w.fsys, chunkDir)
+	if err != nil {
+		return mtimes
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".tmx") {
+			continue
+		}
+		if info, err := entry.Info(); err == nil {
+			mtimes[path.Join(chunkDir, entry.Name())] = info.ModTime()
+		}
+	}
+	return mtimes
+}
+
+// Reload applies a changed file from fsys and logs the outcome. Errors are
+// logged rather than returned so a half-saved file never stops the game. A
+// tuning file that fails to load leaves the previous values in use.
+func Reload(fsys fs.FS, file string) {
+	if file == config.TuningFile {
+		if err := config.LoadTuning(fsys, file); err != nil {
+			log.Printf("Hot reload: %v", err)
+			return
+		}
+		log.Printf("Hot reload: applied %s", file)
+		return
+	}
+
+	if _, err := fs.Stat(fsys, file); err != nil {
+		log.Printf("Hot reload: %s was removed and is left out of the next run", file)
+		return
+	}
+	chunk, err := procgen.NewChunkLoaderWithFS(fsys).LoadChunk(file)
+	if err != nil {
+		log.Printf("Hot reload: %v", err)
+		return
+	}
+	for _, issue := range procgen.LintChunk(chunk) {
+		log.Printf("Hot reload: %s: %s", file, issue)
+	}
+	log.Printf("Hot reload: chunk %s from %s is used from the next run", chunk.ID, file)
+}
@@ -0,0 +1,59 @@
+package hotreload
+
+import (
+	"reflect"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/automoto/doomerang/config"
+)
+
+func TestWatcherChanged(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	fsys := fstest.MapFS{
+		"chunks/combat_01.tmx": {ModTime: start},
+		"chunks/start_01.tmx":  {ModTime: start},
+		"chunks/notes.txt":     {ModTime: start},
+		"levels/level1.tmx":    {ModTime: start},
+	}
+	w := NewWatcher(fsys)
+	if changed := w.Changed(); len(changed) != 0 {
+		t.Fatalf("expected nothing changed right after starting, got %v", changed)
+	}
+
+	later := start.Add(time.Second)
+	fsys["chunks/combat_01.tmx"] = &fstest.MapFile{ModTime: later}
+	fsys[config.TuningFile] = &fstest.MapFile{ModTime: later}
+	fsys["chunks/notes.txt"] = &fstest.MapFile{ModTime: later}
+	fsys["levels/level1.tmx"] = &fstest.MapFile{ModTime: later}
+	delete(fsys, "chunks/start_01.tmx")
+
+	want := []string{"chunks/combat_01.tmx", "chunks/start_01.tmx", config.TuningFile}
+	if changed := w.Changed(); !reflect.DeepEqual(changed, want) {
+		t.Errorf("expected %v, got %v", want, changed)
+	}
+	if changed := w.Changed(); len(changed) != 0 {
+		t.Errorf("expected changes to be reported once, got %v", changed)
+	}
+}
+
+func TestUpdateReloadsTuning(t *testing.T) {
+	physics := config.Physics
+	t.Cleanup(func() { config.Physics = physics })
+
+	fsys := fstest.MapFS{}
+	w := NewWatcher(fsys)
+	fsys[config.TuningFile] = &fstest.MapFile{Data: []byte(`{"physics": {"gravity": 2}}`), ModTime: time.Now()}
+
+	for i := 0; i < PollInterval-1; i++ {
+		w.Update()
+	}
+	if config.Physics.Gravity != physics.Gravity {
+		t.Fatal("expected no reload before the poll interval")
+	}
+	w.Update()
+	if config.Physics.Gravity != 2 {
+		t.Errorf("expected gravity 2 after the reload, got %v", config.Physics.Gravity)
+	}
+}
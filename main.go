@@ -5,9 +5,13 @@ import (
 	"flag"
 	"image"
 	"log"
+	"os"
 
+	"github.com/automoto/doomerang/assets"
 	"github.com/automoto/doomerang/config"
 	"github.com/automoto/doomerang/fonts"
+	"github.com/automoto/doomerang/hotreload"
+	"github.com/automoto/doomerang/procgen"
 	"github.com/automoto/doomerang/scenes"
 	"github.com/automoto/doomerang/systems"
 	"github.com/hajimehoshi/ebiten/v2"
@@ -22,12 +26,18 @@ type Scene interface {
 }
 
 type Game struct {
-	bounds image.Rectangle
-	scene  Scene
+	bounds  image.Rectangle
+	scene   Scene
+	watcher *hotreload.Watcher // set when assets are read from disk
 }
 
 // ChangeScene switches to a new scene
 func (g *Game) ChangeScene(scene interface{}) {
+	// A recording covers a single scene; save it before the scene is replaced
+	systems.FlushReplayRecording()
+	// A run left any other way than dying or clearing it counts as abandoned
+	systems.FlushRunHistory()
+	systems.FlushTelemetry()
 	g.scene = scene.(Scene)
 }
 
@@ -40,8 +50,23 @@ func NewGame() *Game {
 	g := &Game{
 		bounds: image.Rectangle{},
 	}
+	if config.Debug.AssetDir != "" {
+		g.watcher = hotreload.NewWatcher(assets.GetAssetFS())
+	}
 
-	if config.Debug.SkipMenu {
+	if config.Debug.PlayReplayPath != "" {
+		replay, err := systems.LoadReplayFile(config.Debug.PlayReplayPath)
+		if err != nil {
+			log.Fatalf("Could not load replay: %v", err)
+		}
+		g.scene = scenes.NewReplayScene(g, replay).(Scene)
+	} else if config.Debug.SeedCode != "" {
+		opts, err := procgen.DecodeSeedCode(config.Debug.SeedCode)
+		if err != nil {
+			log.Fatalf("Could not start seed code %q: %v", config.Debug.SeedCode, err)
+		}
+		g.scene = scenes.NewRogueliteSceneWithOptions(g, opts)
+	} else if config.Debug.SkipMenu {
 		g.scene = scenes.NewPlatformerScene(g)
 	} else {
 		g.scene = scenes.NewMenuScene(g)
@@ -51,6 +76,9 @@ func NewGame() *Game {
 }
 
 func (g *Game) Update() error {
+	if g.watcher != nil {
+		g.watcher.Update()
+	}
 	g.scene.Update()
 	return nil
 }
@@ -68,13 +96,49 @@ func main() {
 	// Parse command-line flags for debug/testing
 	checkpoint := flag.Float64("checkpoint", -1, "Checkpoint ID to spawn at (skips menu)")
 	flag.Float64Var(checkpoint, "c", -1, "Checkpoint ID (shorthand)")
+	record := flag.String("record", "", "Record gameplay input to a replay file")
+	replay := flag.String("replay", "", "Play back a replay file (skips menu)")
+	code := flag.String("code", "", "Start the roguelite run for a shared seed code (skips menu)")
+	telemetry := flag.String("telemetry", "", "Append gameplay events to a JSONL file")
+	assetDir := flag.String("assets", "", "Read assets from this directory and reload tuning and chunks when they change")
+	enemies := flag.String("enemies", "", "Directory of enemy definition files applied over the built-in ones")
+	heatmap := flag.String("heatmap", "", "Telemetry log to show as a heatmap in debug mode (default: -telemetry)")
 	flag.Parse()
 
+	config.Debug.RecordReplayPath = *record
+	config.Debug.PlayReplayPath = *replay
+	config.Debug.SeedCode = *code
+	config.Debug.TelemetryPath = *telemetry
+	config.Debug.HeatmapPath = *heatmap
+	config.Debug.AssetDir = *assetDir
+
 	if *checkpoint >= 0 {
 		config.Debug.StartCheckpoint = *checkpoint
 		config.Debug.SkipMenu = true
 	}
 
+	if config.Debug.AssetDir != "" {
+		if err := assets.UseAssetDir(config.Debug.AssetDir); err != nil {
+			log.Fatalf("Could not use asset directory: %v", err)
+		}
+		if err := config.LoadTuning(assets.GetAssetFS(), config.TuningFile); err != nil {
+			log.Fatalf("Could not load tuning: %v", err)
+		}
+	}
+
+	// Enemy definitions in the asset tree, then any from -enemies on top
+	if err := assets.LoadEnemyTypes(); err != nil {
+		log.Fatalf("Could not load enemy definitions: %v", err)
+	}
+	if *enemies != "" {
+		if _, err := os.Stat(*enemies); err != nil {
+			log.Fatalf("Could not load enemy definitions: %v", err)
+		}
+		if err := config.LoadEnemyTypes(os.DirFS(*enemies), "."); err != nil {
+			log.Fatalf("Could not load enemy definitions: %v", err)
+		}
+	}
+
 	// Start pprof server for memory profiling
 	// Usage: go tool pprof http://localhost:6060/debug/pprof/heap
 	// go func() {
@@ -94,9 +158,13 @@ func main() {
 	if saved, err := systems.LoadSettings(); err == nil && saved != nil {
 		systems.ApplySavedSettingsGlobal(saved)
 	}
+	systems.RestoreLevelPack()
 
 
 	if err := ebiten.RunGame(NewGame()); err != nil {
 		log.Fatal(err)
 	}
+	systems.FlushReplayRecording()
+	systems.FlushRunHistory()
+	systems.FlushTelemetry()
 }
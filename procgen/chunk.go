@@ -1,8 +1,8 @@
 package procgen
 
 import (
-	"embed"
 	"fmt"
+	"io/fs"
 	"math"
 	"strings"
 
@@ -14,10 +14,33 @@ import (
 type ConnectionEdge string
 
 const (
-	EdgeLeft  ConnectionEdge = "left"
-	EdgeRight ConnectionEdge = "right"
+	EdgeLeft   ConnectionEdge = "left"
+	EdgeRight  ConnectionEdge = "right"
+	EdgeTop    ConnectionEdge = "top"
+	EdgeBottom ConnectionEdge = "bottom"
 )
 
+// Opposite returns the edge a neighbouring chunk must connect through
+// (right pairs with left, bottom with top).
+func (e ConnectionEdge) Opposite() ConnectionEdge {
+	switch e {
+	case EdgeLeft:
+		return EdgeRight
+	case EdgeRight:
+		return EdgeLeft
+	case EdgeTop:
+		return EdgeBottom
+	case EdgeBottom:
+		return EdgeTop
+	}
+	return ""
+}
+
+// IsVertical reports whether the edge is the top or bottom of a chunk.
+func (e ConnectionEdge) IsVertical() bool {
+	return e == EdgeTop || e == EdgeBottom
+}
+
 // ConnectionPoint defines an entry/exit point on a chunk's edge
 type ConnectionPoint struct {
 	Edge      ConnectionEdge
@@ -35,6 +58,7 @@ const (
 	TagTraversal ChunkTag = "traversal"
 	TagBreak     ChunkTag = "break"
 	TagHazard    ChunkTag = "hazard"
+	TagBonus     ChunkTag = "bonus"
 	TagStart     ChunkTag = "start"
 	TagExit      ChunkTag = "exit"
 )
@@ -94,22 +118,32 @@ func (c *Chunk) GetConnections(edge ConnectionEdge) []ConnectionPoint {
 	return result
 }
 
+// Connection returns the connection point on edge with the given slot index
+func (c *Chunk) Connection(edge ConnectionEdge, slot int) (ConnectionPoint, bool) {
+	for _, cp := range c.Connections {
+		if cp.Edge == edge && cp.SlotIndex == slot {
+			return cp, true
+		}
+	}
+	return ConnectionPoint{}, false
+}
+
 // ChunkLoader loads and parses chunk TMX files into Chunk structs
 type ChunkLoader struct {
-	fs embed.FS
+	fs fs.FS
 }
 
-// NewChunkLoader creates a ChunkLoader using the assets embedded filesystem
+// NewChunkLoader creates a ChunkLoader using the asset filesystem
 func NewChunkLoader() *ChunkLoader {
 	return &ChunkLoader{fs: assets.GetAssetFS()}
 }
 
 // NewChunkLoaderWithFS creates a ChunkLoader with a custom filesystem (for testing)
-func NewChunkLoaderWithFS(fs embed.FS) *ChunkLoader {
-	return &ChunkLoader{fs: fs}
+func NewChunkLoaderWithFS(fsys fs.FS) *ChunkLoader {
+	return &ChunkLoader{fs: fsys}
 }
 
-// LoadChunk loads a single chunk from a TMX file path (relative to the embedded FS root)
+// LoadChunk loads a single chunk from a TMX file path (relative to the asset FS root)
 func (cl *ChunkLoader) LoadChunk(path string) (*Chunk, error) {
 	tiledMap, err := tiled.LoadFile(path, tiled.WithFileSystem(cl.fs))
 	if err != nil {
@@ -137,7 +171,7 @@ func (cl *ChunkLoader) LoadChunk(path string) (*Chunk, error) {
 
 // LoadAllChunks loads all TMX files from the given directory path
 func (cl *ChunkLoader) LoadAllChunks(dir string) ([]*Chunk, error) {
-	entries, err := cl.fs.ReadDir(dir)
+	entries, err := fs.ReadDir(cl.fs, dir)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read chunks directory %s: %w", dir, err)
 	}
@@ -239,7 +273,7 @@ func parseConnections(og *tiled.ObjectGroup, c *Chunk) {
 	for _, o := range og.Objects {
 		edge := ConnectionEdge(o.Properties.GetString("edge"))
 		if edge == "" {
-			edge = inferEdge(o.X, float64(c.Width))
+			edge = inferEdge(o, float64(c.Width), float64(c.Height))
 		}
 
 		cp := ConnectionPoint{
@@ -256,10 +290,19 @@ func parseConnections(og *tiled.ObjectGroup, c *Chunk) {
 	}
 }
 
-func inferEdge(x, chunkW float64) ConnectionEdge {
+// inferEdge guesses the edge of a connection object without an "edge" property
+// from which side of the chunk it touches.
+func inferEdge(o *tiled.Object, chunkW, chunkH float64) ConnectionEdge {
 	const threshold = 2.0
-	if x >= chunkW-threshold {
+	switch {
+	case o.X <= threshold:
+		return EdgeLeft
+	case o.X+o.Width >= chunkW-threshold:
 		return EdgeRight
+	case o.Y <= threshold:
+		return EdgeTop
+	case o.Y+o.Height >= chunkH-threshold:
+		return EdgeBottom
 	}
 	return EdgeLeft
 }
@@ -9,7 +9,11 @@ import (
 )
 
 // Compiler converts a GenerationResult into a playable assets.Level
-type Compiler struct{}
+type Compiler struct {
+	// SkipBackground leaves Level.Background nil instead of rendering tiles into it.
+	// Tools that only need the level data set this to run without a graphics context.
+	SkipBackground bool
+}
 
 // NewCompiler creates a new level compiler
 func NewCompiler() *Compiler {
@@ -40,11 +44,13 @@ func (c *Compiler) Compile(result *GenerationResult, opts *DecorationOptions) (*
 	}
 
 	// Create the background image
-	level.Background = ebiten.NewImage(result.TotalWidth, result.TotalHeight)
+	if !c.SkipBackground {
+		level.Background = ebiten.NewImage(result.TotalWidth, result.TotalHeight)
 
-	// Draw the decorative background image (tiled horizontally) before tile layers
-	if opts != nil && opts.BackgroundImage != nil {
-		c.renderDecorativeBackground(level.Background, opts, result.TotalWidth, result.TotalHeight)
+		// Draw the decorative background image (tiled horizontally) before tile layers
+		if opts != nil && opts.BackgroundImage != nil {
+			c.renderDecorativeBackground(level.Background, opts, result.TotalWidth, result.TotalHeight)
+		}
 	}
 
 	for _, pc := range result.PlacedChunks {
@@ -63,12 +69,22 @@ func (c *Compiler) Compile(result *GenerationResult, opts *DecorationOptions) (*
 		}
 
 		// Render chunk tiles into the background
-		if err := c.renderChunkBackground(level.Background, pc); err != nil {
-			return nil, fmt.Errorf("failed to render chunk %s background: %w", pc.Chunk.ID, err)
+		if level.Background != nil {
+			if err := c.renderChunkBackground(level.Background, pc); err != nil {
+				return nil, fmt.Errorf("failed to render chunk %s background: %w", pc.Chunk.ID, err)
+			}
 		}
 
 		// Process object groups for each chunk
 		c.compileObjectGroups(level, pc)
+
+		level.Rooms = append(level.Rooms, assets.Room{
+			X:       ox,
+			Y:       oy,
+			Width:   float64(pc.Chunk.Width),
+			Height:  float64(pc.Chunk.Height),
+			ChunkID: pc.Chunk.ID,
+		})
 	}
 
 	// Ensure we have a player spawn
@@ -0,0 +1,33 @@
+package procgen
+
+import (
+	"hash/fnv"
+	"math/rand"
+	"time"
+
+	"github.com/automoto/doomerang/config"
+)
+
+// DailyKey returns the calendar date a daily run is keyed by, e.g. "2026-10-17".
+// Days start at midnight UTC, so players in every time zone share the same run.
+func DailyKey(date time.Time) string {
+	return date.UTC().Format(time.DateOnly)
+}
+
+// DailyRunOptions returns the run everyone plays on date. The seed, run length
+// and biome all derive from the calendar date, so every player gets the same
+// challenge without any online service.
+func DailyRunOptions(date time.Time) RunOptions {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(DailyKey(date)))
+	rng := rand.New(rand.NewSource(int64(h.Sum64())))
+
+	opts := RunOptions{
+		Seed:   rng.Int63n(1 << 32),
+		Length: config.Procgen.DailyMinLength + rng.Intn(config.Procgen.DailyMaxLength-config.Procgen.DailyMinLength+1),
+	}
+	if len(config.Procgen.Biomes) > 0 {
+		opts.Biome = config.Procgen.Biomes[rng.Intn(len(config.Procgen.Biomes))]
+	}
+	return opts
+}
@@ -0,0 +1,52 @@
+package procgen_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/automoto/doomerang/config"
+	"github.com/automoto/doomerang/procgen"
+)
+
+func TestDailyRunOptionsSameDay(t *testing.T) {
+	morning := time.Date(2026, 10, 17, 6, 0, 0, 0, time.UTC)
+	night := time.Date(2026, 10, 17, 23, 59, 0, 0, time.UTC)
+
+	if a, b := procgen.DailyRunOptions(morning), procgen.DailyRunOptions(night); a != b {
+		t.Errorf("expected the same run all day, got %+v and %+v", a, b)
+	}
+	if key := procgen.DailyKey(morning); key != "2026-10-17" {
+		t.Errorf("expected key 2026-10-17, got %s", key)
+	}
+}
+
+func TestDailyKeySameEverywhere(t *testing.T) {
+	// Late evening in New York is already the next day in UTC
+	newYork := time.FixedZone("EDT", -4*60*60)
+	evening := time.Date(2026, 10, 17, 22, 0, 0, 0, newYork)
+	if key := procgen.DailyKey(evening); key != "2026-10-18" {
+		t.Errorf("expected key 2026-10-18, got %s", key)
+	}
+	if a, b := procgen.DailyRunOptions(evening), procgen.DailyRunOptions(evening.UTC()); a != b {
+		t.Errorf("expected the same run in every time zone, got %+v and %+v", a, b)
+	}
+}
+
+func TestDailyRunOptionsVaryByDay(t *testing.T) {
+	seeds := make(map[int64]bool)
+	day := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	for i := 0; i < 30; i++ {
+		opts := procgen.DailyRunOptions(day.AddDate(0, 0, i))
+		seeds[opts.Seed] = true
+
+		if opts.Length < config.Procgen.DailyMinLength || opts.Length > config.Procgen.DailyMaxLength {
+			t.Errorf("day %d: length %d outside the daily range", i, opts.Length)
+		}
+		if _, err := procgen.EncodeSeedCode(opts); err != nil {
+			t.Errorf("day %d: options can't be shared as a seed code: %v", i, err)
+		}
+	}
+	if len(seeds) != 30 {
+		t.Errorf("expected 30 distinct daily seeds, got %d", len(seeds))
+	}
+}
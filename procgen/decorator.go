@@ -5,6 +5,7 @@ import (
 	"image"
 	_ "image/jpeg"
 	_ "image/png"
+	"io/fs"
 	"log"
 	"math/rand"
 
@@ -87,10 +88,9 @@ func DeriveDecoration(seed int64, biome string) DecorationOptions {
 	return opts
 }
 
-// loadBackgroundImage reads an image from the embedded asset FS. Returns nil on failure.
+// loadBackgroundImage reads an image from the asset FS. Returns nil on failure.
 func loadBackgroundImage(path string) *ebiten.Image {
-	fs := assets.GetAssetFS()
-	data, err := fs.ReadFile(path)
+	data, err := fs.ReadFile(assets.GetAssetFS(), path)
 	if err != nil {
 		log.Printf("procgen: background image not found %q: %v", path, err)
 		return nil
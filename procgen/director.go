@@ -7,6 +7,7 @@ func ValidateGraph(graph *ConceptGraph) {
 	}
 
 	validateCombatPacing(graph)
+	validateBranches(graph)
 }
 
 func validateCombatPacing(graph *ConceptGraph) {
@@ -48,3 +49,47 @@ func validateCombatPacing(graph *ConceptGraph) {
 		}
 	}
 }
+
+// validateBranches drops branches that don't leave from and rejoin the middle
+// of the main path, and applies the combat streak rule along each side route,
+// counting the main-line combat rooms leading up to the fork.
+func validateBranches(graph *ConceptGraph) {
+	exit := len(graph.Nodes) - 1
+	valid := graph.Branches[:0]
+	for _, b := range graph.Branches {
+		if b.From < 1 || b.From >= exit || len(b.Nodes) == 0 {
+			continue
+		}
+		if b.Rejoin != -1 && (b.Rejoin <= b.From+1 || b.Rejoin >= exit) {
+			continue
+		}
+		valid = append(valid, b)
+	}
+	graph.Branches = valid
+
+	for _, b := range graph.Branches {
+		combatStreak := 0
+		for i := b.From; i > 0 && isCombat(graph.Nodes[i].Type); i-- {
+			combatStreak++
+		}
+
+		for i := range b.Nodes {
+			node := &b.Nodes[i]
+			node.Optional = true
+			if !isCombat(node.Type) {
+				combatStreak = 0
+				continue
+			}
+			combatStreak++
+			if combatStreak > 2 {
+				node.Type = NodeTraversal
+				node.Tag = TagTraversal
+				combatStreak = 0
+			}
+		}
+	}
+}
+
+func isCombat(t NodeType) bool {
+	return t == NodeCombat || t == NodeArena
+}
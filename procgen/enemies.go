@@ -48,7 +48,7 @@ func (ep *EnemyPlacer) PlaceEnemies(pc PlacedChunk, difficulty int) ([]assets.En
 	types := ep.selectEnemyTypes(count, difficulty, platforms)
 
 	// Distribute enemies across platforms
-	return ep.distributeEnemies(types, platforms, pc)
+	return ep.distributeEnemies(types, groundEnemyType(difficulty), platforms, pc)
 }
 
 func (ep *EnemyPlacer) enemyCountFromBudget(budget float64, minE, maxE int) int {
@@ -85,38 +85,65 @@ func (ep *EnemyPlacer) selectEnemyTypes(count, difficulty int, platforms []platf
 		}
 	}
 
+	pool := enemyPool(difficulty, hasElevated)
+	if len(pool) == 0 {
+		return types
+	}
 	for i := 0; i < count; i++ {
-		types = append(types, ep.pickEnemyType(difficulty, hasElevated))
+		types = append(types, ep.pickEnemyType(pool))
 	}
 	return types
 }
 
-func (ep *EnemyPlacer) pickEnemyType(difficulty int, hasElevated bool) string {
-	// Build weighted pool based on difficulty
-	type choice struct {
-		name   string
-		weight int
-	}
+// enemyChoice is an enemy type in a room's pool and its chance of being picked
+type enemyChoice struct {
+	name   string
+	weight int
+}
 
-	pool := []choice{
-		{"LightGuard", 30 - difficulty*3},
-		{"Guard", 30},
+// enemyPool returns the enemy types that can spawn in a room of the given
+// difficulty, weighted by their spawn settings in config.Enemy.Types. Ranged
+// types need a raised platform to throw from.
+func enemyPool(difficulty int, hasElevated bool) []enemyChoice {
+	names := make([]string, 0, len(config.Enemy.Types))
+	for name := range config.Enemy.Types {
+		names = append(names, name)
 	}
+	sort.Strings(names)
 
-	if difficulty >= 2 {
-		pool = append(pool, choice{"HeavyGuard", difficulty * 5})
+	var pool []enemyChoice
+	for _, name := range names {
+		et := config.Enemy.Types[name]
+		if et.SpawnWeight <= 0 && et.SpawnWeightPerDifficulty <= 0 {
+			continue
+		}
+		if difficulty < et.MinDifficulty || (et.IsRanged && !hasElevated) {
+			continue
+		}
+		// Types that can spawn always keep some chance
+		weight := max(et.SpawnWeight+et.SpawnWeightPerDifficulty*difficulty, config.Procgen.EnemyMinSpawnWeight)
+		pool = append(pool, enemyChoice{name, weight})
 	}
-	if difficulty >= 2 && hasElevated {
-		pool = append(pool, choice{"KnifeThrower", difficulty * 4})
+	return pool
+}
+
+// groundEnemyType returns the most likely melee type in a room of the given
+// difficulty, for ranged enemies left without a raised platform. It returns ""
+// if no melee type can spawn there.
+func groundEnemyType(difficulty int) string {
+	best := enemyChoice{}
+	for _, c := range enemyPool(difficulty, false) {
+		if !config.Enemy.Types[c.name].IsRanged && c.weight > best.weight {
+			best = c
+		}
 	}
+	return best.name
+}
 
-	// Clamp weights
+func (ep *EnemyPlacer) pickEnemyType(pool []enemyChoice) string {
 	total := 0
-	for i := range pool {
-		if pool[i].weight < 5 {
-			pool[i].weight = 5
-		}
-		total += pool[i].weight
+	for _, c := range pool {
+		total += c.weight
 	}
 
 	roll := ep.rng.Intn(total)
@@ -127,7 +154,7 @@ func (ep *EnemyPlacer) pickEnemyType(difficulty int, hasElevated bool) string {
 			return c.name
 		}
 	}
-	return "Guard"
+	return pool[len(pool)-1].name
 }
 
 type platform struct {
@@ -227,7 +254,7 @@ type spawnRecord struct {
 	platformIdx int // index into the sorted platforms slice
 }
 
-func (ep *EnemyPlacer) distributeEnemies(types []string, platforms []platform, pc PlacedChunk) ([]assets.EnemySpawn, map[string]assets.PatrolPath) {
+func (ep *EnemyPlacer) distributeEnemies(types []string, groundType string, platforms []platform, pc PlacedChunk) ([]assets.EnemySpawn, map[string]assets.PatrolPath) {
 	minSpacing := config.Procgen.EnemyMinSpacing
 	usedPositions := make([]float64, 0)
 
@@ -242,11 +269,11 @@ func (ep *EnemyPlacer) distributeEnemies(types []string, platforms []platform, p
 	// First pass: place enemies and record platform assignments
 	var records []spawnRecord
 	for _, enemyType := range types {
-		isKnifeThrower := enemyType == "KnifeThrower"
+		isRanged := config.Enemy.Types[enemyType].IsRanged
 
 		placed := false
 		for pi, p := range platforms {
-			if isKnifeThrower && !p.elevated {
+			if isRanged && !p.elevated {
 				continue
 			}
 
@@ -274,15 +301,15 @@ func (ep *EnemyPlacer) distributeEnemies(types []string, platforms []platform, p
 			break
 		}
 
-		// If KnifeThrower couldn't find elevated platform, place on ground
-		if !placed && isKnifeThrower {
+		// If a ranged enemy couldn't find an elevated platform, place it on the ground
+		if !placed && isRanged && groundType != "" {
 			for pi, p := range platforms {
 				x := ep.findSpawnX(p, usedPositions, minSpacing)
 				if x < 0 {
 					continue
 				}
 				collisionH := 40.0
-				if et, ok := config.Enemy.Types["Guard"]; ok {
+				if et, ok := config.Enemy.Types[groundType]; ok {
 					collisionH = float64(et.CollisionHeight)
 				}
 				spawnY := p.y - collisionH
@@ -290,7 +317,7 @@ func (ep *EnemyPlacer) distributeEnemies(types []string, platforms []platform, p
 					spawn: assets.EnemySpawn{
 						X:         x + pc.OffsetX,
 						Y:         spawnY + pc.OffsetY,
-						EnemyType: "Guard", // Downgrade to Guard on ground
+						EnemyType: groundType, // Downgrade to a melee type on the ground
 					},
 					platformIdx: pi,
 				})
@@ -88,14 +88,45 @@ func TestEnemyTypesValidate(t *testing.T) {
 	placer := procgen.NewEnemyPlacer(rng)
 
 	spawns, _ := placer.PlaceEnemies(pc, 3)
-	validTypes := map[string]bool{
-		"Guard": true, "LightGuard": true,
-		"HeavyGuard": true, "KnifeThrower": true,
+	for _, s := range spawns {
+		if _, ok := config.Enemy.Types[s.EnemyType]; !ok {
+			t.Errorf("invalid enemy type: %s", s.EnemyType)
+		}
 	}
+}
+
+func TestEnemyPoolFromConfig(t *testing.T) {
+	types := config.Enemy.Types
+	t.Cleanup(func() { config.Enemy.Types = types })
 
+	// Only a type added to the config, and only from its minimum difficulty
+	brute := types["Guard"]
+	brute.Name = "Brute"
+	brute.SpawnWeight = 10
+	brute.MinDifficulty = 3
+	config.Enemy.Types = map[string]config.EnemyTypeConfig{"Brute": brute}
+	for name, et := range types {
+		et.SpawnWeight, et.SpawnWeightPerDifficulty = 0, 0
+		config.Enemy.Types[name] = et
+	}
+
+	loader := procgen.NewChunkLoader()
+	chunk, err := loader.LoadChunk("chunks/combat_01.tmx")
+	if err != nil {
+		t.Fatalf("LoadChunk failed: %v", err)
+	}
+	pc := procgen.PlacedChunk{Chunk: chunk}
+
+	if spawns, _ := procgen.NewEnemyPlacer(rand.New(rand.NewSource(42))).PlaceEnemies(pc, 2); len(spawns) != 0 {
+		t.Errorf("expected no enemies below the Brute's minimum difficulty, got %d", len(spawns))
+	}
+	spawns, _ := procgen.NewEnemyPlacer(rand.New(rand.NewSource(42))).PlaceEnemies(pc, 3)
+	if len(spawns) == 0 {
+		t.Fatal("expected Brutes to be placed")
+	}
 	for _, s := range spawns {
-		if !validTypes[s.EnemyType] {
-			t.Errorf("invalid enemy type: %s", s.EnemyType)
+		if s.EnemyType != "Brute" {
+			t.Errorf("expected only Brutes, got %s", s.EnemyType)
 		}
 	}
 }
@@ -0,0 +1,408 @@
+package procgen
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/automoto/doomerang/assets"
+	tiled "github.com/lafriks/go-tiled"
+)
+
+// Tile GID flip flags as stored in TMX layer data
+const (
+	tmxFlipHorizontal = 0x80000000
+	tmxFlipVertical   = 0x40000000
+	tmxFlipDiagonal   = 0x20000000
+)
+
+type tmxMap struct {
+	XMLName      xml.Name         `xml:"map"`
+	Version      string           `xml:"version,attr"`
+	Orientation  string           `xml:"orientation,attr"`
+	RenderOrder  string           `xml:"renderorder,attr"`
+	Width        int              `xml:"width,attr"`
+	Height       int              `xml:"height,attr"`
+	TileWidth    int              `xml:"tilewidth,attr"`
+	TileHeight   int              `xml:"tileheight,attr"`
+	Infinite     int              `xml:"infinite,attr"`
+	NextLayerID  int              `xml:"nextlayerid,attr"`
+	NextObjectID int              `xml:"nextobjectid,attr"`
+	Tilesets     []tmxTileset     `xml:"tileset"`
+	Layers       []tmxLayer       `xml:"layer"`
+	ObjectGroups []tmxObjectGroup `xml:"objectgroup"`
+}
+
+type tmxTileset struct {
+	FirstGID uint32 `xml:"firstgid,attr"`
+	Source   string `xml:"source,attr"`
+}
+
+type tmxProperty struct {
+	Name  string `xml:"name,attr"`
+	Type  string `xml:"type,attr,omitempty"`
+	Value string `xml:"value,attr"`
+}
+
+type tmxLayer struct {
+	ID         int           `xml:"id,attr"`
+	Name       string        `xml:"name,attr"`
+	Width      int           `xml:"width,attr"`
+	Height     int           `xml:"height,attr"`
+	Opacity    string        `xml:"opacity,attr,omitempty"`
+	Visible    string        `xml:"visible,attr,omitempty"`
+	Properties []tmxProperty `xml:"properties>property,omitempty"`
+	Data       tmxData       `xml:"data"`
+}
+
+type tmxData struct {
+	Encoding string `xml:"encoding,attr"`
+	CSV      string `xml:",chardata"`
+}
+
+type tmxObjectGroup struct {
+	ID      int         `xml:"id,attr"`
+	Name    string      `xml:"name,attr"`
+	Objects []tmxObject `xml:"object"`
+}
+
+type tmxObject struct {
+	ID         int           `xml:"id,attr"`
+	Name       string        `xml:"name,attr,omitempty"`
+	Type       string        `xml:"type,attr,omitempty"`
+	X          float64       `xml:"x,attr"`
+	Y          float64       `xml:"y,attr"`
+	Width      float64       `xml:"width,attr,omitempty"`
+	Height     float64       `xml:"height,attr,omitempty"`
+	Properties []tmxProperty `xml:"properties>property,omitempty"`
+	PolyLine   *tmxPolyLine  `xml:"polyline,omitempty"`
+}
+
+type tmxPolyLine struct {
+	Points string `xml:"points,attr"`
+}
+
+// tmxExport accumulates the stitched map while chunks and objects are added
+type tmxExport struct {
+	m          tmxMap
+	tileW      float64
+	tileH      float64
+	shiftY     float64
+	tilesets   map[string]uint32 // asset-relative tileset path -> first GID
+	nextGID    uint32
+	layers     map[string]int // layer name -> index in m.Layers
+	grids      [][]uint32     // tile GIDs per layer
+	nextObject int
+}
+
+// ExportTMX writes a generated level as a single Tiled map that
+// assets.LevelLoader.MustLoadLevel can load. Each chunk's tile layers are
+// stitched at its placed offset and the compiled level's spawns, patrol paths,
+// hazards, checkpoints and finish lines are written as object groups.
+//
+// dir is the asset directory the map will be saved in (e.g. "levels"); tileset
+// references are written relative to it. Chunk offsets are snapped to the tile
+// grid by shifting the whole map down by less than one tile, so object
+// positions may differ from the generated level by that amount.
+func ExportTMX(w io.Writer, result *GenerationResult, level *assets.Level, dir string) error {
+	if len(result.PlacedChunks) == 0 {
+		return fmt.Errorf("no chunks to export")
+	}
+	first := result.PlacedChunks[0].Chunk.TiledMap
+	if first == nil {
+		return fmt.Errorf("chunk %s has no tile map", result.PlacedChunks[0].Chunk.ID)
+	}
+
+	ex := &tmxExport{
+		tileW:      float64(first.TileWidth),
+		tileH:      float64(first.TileHeight),
+		tilesets:   make(map[string]uint32),
+		nextGID:    1,
+		layers:     make(map[string]int),
+		nextObject: 1,
+	}
+
+	// Snap the topmost chunk onto the tile grid; the rest share its alignment
+	// because chunk heights and connection offsets are whole tiles.
+	minY := result.PlacedChunks[0].OffsetY
+	for _, pc := range result.PlacedChunks {
+		minY = math.Min(minY, pc.OffsetY)
+	}
+	ex.shiftY = math.Ceil(minY/ex.tileH)*ex.tileH - minY
+
+	ex.m = tmxMap{
+		Version:     "1.10",
+		Orientation: "orthogonal",
+		RenderOrder: "right-down",
+		Width:       int(math.Ceil(float64(result.TotalWidth) / ex.tileW)),
+		Height:      int(math.Ceil((float64(result.TotalHeight) + ex.shiftY) / ex.tileH)),
+		TileWidth:   first.TileWidth,
+		TileHeight:  first.TileHeight,
+	}
+
+	for _, pc := range result.PlacedChunks {
+		if err := ex.addChunk(pc, dir); err != nil {
+			return err
+		}
+	}
+
+	for i := range ex.m.Layers {
+		ex.m.Layers[i].Data = tmxData{Encoding: "csv", CSV: ex.csv(ex.grids[i])}
+	}
+
+	ex.addObjects(level)
+	ex.m.NextLayerID = len(ex.m.Layers) + len(ex.m.ObjectGroups) + 1
+	ex.m.NextObjectID = ex.nextObject
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", " ")
+	if err := enc.Encode(ex.m); err != nil {
+		return fmt.Errorf("failed to encode TMX: %w", err)
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// addChunk copies a chunk's tile layers into the stitched layers at its offset
+func (ex *tmxExport) addChunk(pc PlacedChunk, dir string) error {
+	tm := pc.Chunk.TiledMap
+	if tm == nil {
+		return fmt.Errorf("chunk %s has no tile map", pc.Chunk.ID)
+	}
+	if float64(tm.TileWidth) != ex.tileW || float64(tm.TileHeight) != ex.tileH {
+		return fmt.Errorf("chunk %s uses %dx%d tiles, expected %vx%v", pc.Chunk.ID, tm.TileWidth, tm.TileHeight, ex.tileW, ex.tileH)
+	}
+
+	col := int(math.Round(pc.OffsetX / ex.tileW))
+	row := int(math.Round((pc.OffsetY + ex.shiftY) / ex.tileH))
+
+	for _, layer := range tm.Layers {
+		idx, ok := ex.layers[layer.Name]
+		if !ok {
+			idx = len(ex.m.Layers)
+			ex.layers[layer.Name] = idx
+			ex.m.Layers = append(ex.m.Layers, newTMXLayer(idx+1, layer.Name, ex.m.Width, ex.m.Height, layer.Opacity, layer.Visible, layer.Properties))
+			ex.grids = append(ex.grids, make([]uint32, ex.m.Width*ex.m.Height))
+		}
+		grid := ex.grids[idx]
+
+		for i, tile := range layer.Tiles {
+			if tile == nil || tile.IsNil() {
+				continue
+			}
+			x := col + i%tm.Width
+			y := row + i/tm.Width
+			if x < 0 || x >= ex.m.Width || y < 0 || y >= ex.m.Height {
+				continue
+			}
+
+			firstGID, err := ex.tilesetGID(pc.Chunk, tile.Tileset.Source, tile.Tileset.TileCount, dir)
+			if err != nil {
+				return err
+			}
+			gid := firstGID + tile.ID
+			if tile.HorizontalFlip {
+				gid |= tmxFlipHorizontal
+			}
+			if tile.VerticalFlip {
+				gid |= tmxFlipVertical
+			}
+			if tile.DiagonalFlip {
+				gid |= tmxFlipDiagonal
+			}
+			grid[y*ex.m.Width+x] = gid
+		}
+	}
+
+	return nil
+}
+
+// tilesetGID returns the first GID of a chunk's tileset in the exported map,
+// registering the tileset on first use. Only external .tsx tilesets can be
+// shared between chunks, so embedded tilesets are rejected.
+func (ex *tmxExport) tilesetGID(chunk *Chunk, source string, tileCount int, dir string) (uint32, error) {
+	if source == "" {
+		return 0, fmt.Errorf("chunk %s uses an embedded tileset; only external .tsx tilesets can be exported", chunk.ID)
+	}
+
+	assetPath := path.Join(path.Dir(chunk.SourcePath), source)
+	if gid, ok := ex.tilesets[assetPath]; ok {
+		return gid, nil
+	}
+
+	gid := ex.nextGID
+	ex.tilesets[assetPath] = gid
+	ex.nextGID += uint32(tileCount)
+	ex.m.Tilesets = append(ex.m.Tilesets, tmxTileset{FirstGID: gid, Source: relativeAssetPath(dir, assetPath)})
+	return gid, nil
+}
+
+// addObjects writes the level's objects as the object groups MustLoadLevel parses
+func (ex *tmxExport) addObjects(level *assets.Level) {
+	var players, enemies, paths, deadZones, checkpoints, obstacles, messages, finishLines, rewards []tmxObject
+
+	for _, s := range level.PlayerSpawns {
+		obj := ex.object(s.X, s.Y, 0, 0)
+		if s.SpawnPoint != "" {
+			obj.Properties = []tmxProperty{{Name: "spawnPoint", Value: s.SpawnPoint}}
+		}
+		players = append(players, obj)
+	}
+
+	for _, s := range level.EnemySpawns {
+		obj := ex.object(s.X, s.Y, 0, 0)
+		obj.Properties = []tmxProperty{{Name: "enemyType", Value: s.EnemyType}}
+		if s.PatrolPath != "" {
+			obj.Properties = append(obj.Properties, tmxProperty{Name: "pathName", Value: s.PatrolPath})
+		}
+		enemies = append(enemies, obj)
+	}
+
+	// Patrol paths are stored in a map; sort by name for stable output
+	names := make([]string, 0, len(level.PatrolPaths))
+	for name, p := range level.PatrolPaths {
+		if len(p.Points) >= 2 {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		points := level.PatrolPaths[name].Points
+		origin := points[0]
+		coords := make([]string, len(points))
+		for i, p := range points {
+			coords[i] = formatTMXFloat(p.X-origin.X) + "," + formatTMXFloat(p.Y-origin.Y)
+		}
+		obj := ex.object(origin.X, origin.Y, 0, 0)
+		obj.Name = name
+		obj.PolyLine = &tmxPolyLine{Points: strings.Join(coords, " ")}
+		paths = append(paths, obj)
+	}
+
+	for _, dz := range level.DeadZones {
+		deadZones = append(deadZones, ex.object(dz.X, dz.Y, dz.Width, dz.Height))
+	}
+
+	for _, cp := range level.Checkpoints {
+		obj := ex.object(cp.X, cp.Y, cp.Width, cp.Height)
+		obj.Properties = []tmxProperty{{Name: "checkpointID", Type: "float", Value: formatTMXFloat(cp.CheckpointID)}}
+		checkpoints = append(checkpoints, obj)
+	}
+
+	for _, f := range level.Fires {
+		obj := ex.object(f.X, f.Y, 0, 0)
+		obj.Type = f.FireType
+		obj.Properties = []tmxProperty{{Name: "Direction", Value: f.Direction}}
+		obstacles = append(obstacles, obj)
+	}
+
+	for _, m := range level.Messages {
+		obj := ex.object(m.X, m.Y, 0, 0)
+		obj.Properties = []tmxProperty{{Name: "message_id", Type: "float", Value: formatTMXFloat(m.MessageID)}}
+		messages = append(messages, obj)
+	}
+
+	for _, fl := range level.FinishLines {
+		finishLines = append(finishLines, ex.object(fl.X, fl.Y, fl.Width, fl.Height))
+	}
+
+	for _, r := range level.Rewards {
+		obj := ex.object(r.X, r.Y, r.Width, r.Height)
+		obj.Properties = []tmxProperty{{Name: "rewardType", Value: r.RewardType}}
+		rewards = append(rewards, obj)
+	}
+
+	groups := []struct {
+		name    string
+		objects []tmxObject
+	}{
+		{"PlayerSpawn", players},
+		{"EnemySpawn", enemies},
+		{"PatrolPaths", paths},
+		{"DeadZones", deadZones},
+		{"Checkpoint", checkpoints},
+		{"Obstacles", obstacles},
+		{"Messages", messages},
+		{"FinishLine", finishLines},
+		{"Rewards", rewards},
+	}
+	for _, g := range groups {
+		if len(g.objects) == 0 {
+			continue
+		}
+		ex.m.ObjectGroups = append(ex.m.ObjectGroups, tmxObjectGroup{
+			ID:      len(ex.m.Layers) + len(ex.m.ObjectGroups) + 1,
+			Name:    g.name,
+			Objects: g.objects,
+		})
+	}
+}
+
+// object creates a map object with the next free ID, applying the grid shift
+func (ex *tmxExport) object(x, y, w, h float64) tmxObject {
+	obj := tmxObject{ID: ex.nextObject, X: x, Y: y + ex.shiftY, Width: w, Height: h}
+	ex.nextObject++
+	return obj
+}
+
+// csv formats a layer grid as Tiled's CSV layer data, one map row per line
+func (ex *tmxExport) csv(grid []uint32) string {
+	var sb strings.Builder
+	sb.WriteString("\n")
+	for y := 0; y < ex.m.Height; y++ {
+		for x := 0; x < ex.m.Width; x++ {
+			sb.WriteString(strconv.FormatUint(uint64(grid[y*ex.m.Width+x]), 10))
+			if x < ex.m.Width-1 || y < ex.m.Height-1 {
+				sb.WriteString(",")
+			}
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+func newTMXLayer(id int, name string, width, height int, opacity float32, visible bool, props tiled.Properties) tmxLayer {
+	layer := tmxLayer{ID: id, Name: name, Width: width, Height: height}
+	if opacity != 1 {
+		layer.Opacity = formatTMXFloat(float64(opacity))
+	}
+	if !visible {
+		layer.Visible = "0"
+	}
+	for _, p := range props {
+		layer.Properties = append(layer.Properties, tmxProperty{Name: p.Name, Type: p.Type, Value: p.Value})
+	}
+	return layer
+}
+
+// relativeAssetPath returns assetPath relative to the asset directory dir
+func relativeAssetPath(dir, assetPath string) string {
+	dirParts := strings.Split(path.Clean(dir), "/")
+	pathParts := strings.Split(path.Clean(assetPath), "/")
+
+	common := 0
+	for common < len(dirParts) && common < len(pathParts)-1 && dirParts[common] == pathParts[common] {
+		common++
+	}
+
+	var rel []string
+	for i := common; i < len(dirParts); i++ {
+		if dirParts[i] != "." {
+			rel = append(rel, "..")
+		}
+	}
+	rel = append(rel, pathParts[common:]...)
+	return strings.Join(rel, "/")
+}
+
+func formatTMXFloat(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
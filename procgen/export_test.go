@@ -0,0 +1,98 @@
+package procgen
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/automoto/doomerang/assets"
+	tiled "github.com/lafriks/go-tiled"
+)
+
+func TestExportTMXRoundTrip(t *testing.T) {
+	run, err := GenerateRun(RunOptions{Seed: 42, Headless: true})
+	if err != nil {
+		t.Fatalf("GenerateRun failed: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := ExportTMX(&buf, run.Result, run.Level, "levels"); err != nil {
+		t.Fatalf("ExportTMX failed: %v", err)
+	}
+
+	m, err := tiled.LoadReader("levels", &buf, tiled.WithFileSystem(assets.GetAssetFS()))
+	if err != nil {
+		t.Fatalf("exported map does not load: %v", err)
+	}
+
+	groups := make(map[string]*tiled.ObjectGroup)
+	for _, og := range m.ObjectGroups {
+		groups[og.Name] = og
+	}
+	counts := map[string]int{
+		"PlayerSpawn": len(run.Level.PlayerSpawns),
+		"EnemySpawn":  len(run.Level.EnemySpawns),
+		"PatrolPaths": len(run.Level.PatrolPaths),
+		"DeadZones":   len(run.Level.DeadZones),
+		"Checkpoint":  len(run.Level.Checkpoints),
+		"Obstacles":   len(run.Level.Fires),
+		"FinishLine":  len(run.Level.FinishLines),
+		"Rewards":     len(run.Level.Rewards),
+	}
+	for name, want := range counts {
+		got := 0
+		if og, ok := groups[name]; ok {
+			got = len(og.Objects)
+		}
+		if got != want {
+			t.Errorf("%s: expected %d objects, got %d", name, want, got)
+		}
+	}
+
+	// The whole map is shifted down by the same amount to snap onto the tile grid
+	shift := groups["PlayerSpawn"].Objects[0].Y - run.Level.PlayerSpawns[0].Y
+	if shift < 0 || shift >= float64(m.TileHeight) {
+		t.Fatalf("expected a grid shift under one tile, got %v", shift)
+	}
+
+	var wgTiles *tiled.Layer
+	for _, layer := range m.Layers {
+		if layer.Name == "wg-tiles" {
+			wgTiles = layer
+		}
+	}
+	if wgTiles == nil {
+		t.Fatal("exported map has no wg-tiles layer")
+	}
+
+	solid := 0
+	for _, tile := range wgTiles.Tiles {
+		if !tile.IsNil() {
+			solid++
+		}
+	}
+	if solid != len(run.Level.SolidTiles) {
+		t.Errorf("expected %d solid tiles, got %d", len(run.Level.SolidTiles), solid)
+	}
+	for _, st := range run.Level.SolidTiles {
+		col := int(st.X) / m.TileWidth
+		row := int(st.Y+shift) / m.TileHeight
+		if wgTiles.Tiles[row*m.Width+col].IsNil() {
+			t.Fatalf("solid tile at (%v, %v) missing from exported map", st.X, st.Y)
+		}
+	}
+}
+
+func TestRelativeAssetPath(t *testing.T) {
+	tests := []struct {
+		dir, path, want string
+	}{
+		{"levels", "levels/tilesets/cyberpunk-tiles.tsx", "tilesets/cyberpunk-tiles.tsx"},
+		{"chunks", "levels/tilesets/cyberpunk-tiles.tsx", "../levels/tilesets/cyberpunk-tiles.tsx"},
+		{".", "levels/tilesets/cyberpunk-tiles.tsx", "levels/tilesets/cyberpunk-tiles.tsx"},
+	}
+	for _, tt := range tests {
+		if got := relativeAssetPath(tt.dir, tt.path); got != tt.want {
+			t.Errorf("relativeAssetPath(%q, %q) = %q, want %q", tt.dir, tt.path, got, tt.want)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package procgen
 
 import (
 	"fmt"
+	"math"
 	"math/rand"
 
 	"github.com/automoto/doomerang/config"
@@ -9,18 +10,45 @@ import (
 
 // PlacedChunk represents a chunk positioned in world space
 type PlacedChunk struct {
-	Chunk   *Chunk
-	OffsetX float64 // World X offset (pixels)
-	OffsetY float64 // World Y offset to align connection points
+	Chunk     *Chunk
+	OffsetX   float64        // World X offset (pixels)
+	OffsetY   float64        // World Y offset to align connection points
+	EntryEdge ConnectionEdge // Edge the previous chunk connects to (empty for the first chunk)
+	ExitEdge  ConnectionEdge // Edge the next chunk connects to (empty for the last chunk)
+	Node      *GraphNode     // Concept graph node the chunk was chosen for (nil without a graph)
 }
 
-// GenerationResult contains the output of chunk generation
+// Optional reports whether the chunk is an optional room off the main path
+func (pc PlacedChunk) Optional() bool {
+	return pc.Node != nil && pc.Node.Optional
+}
+
+// ChunkLink records that the player can pass between two placed chunks
+type ChunkLink struct {
+	From, To int            // Indices into PlacedChunks
+	Edge     ConnectionEdge // Edge of From the link leaves through
+}
+
+// GenerationResult contains the output of chunk generation. The main path
+// comes first in PlacedChunks, in order; optional branch rooms follow it.
 type GenerationResult struct {
 	PlacedChunks []PlacedChunk
+	Links        []ChunkLink
 	TotalWidth   int
 	TotalHeight  int
 }
 
+// MainPathLength returns the number of placed chunks on the main path
+func (r *GenerationResult) MainPathLength() int {
+	n := 0
+	for _, pc := range r.PlacedChunks {
+		if !pc.Optional() {
+			n++
+		}
+	}
+	return n
+}
+
 // maxChunkReuse is the maximum number of times the same chunk ID may appear in one run.
 const maxChunkReuse = 2
 
@@ -65,45 +93,246 @@ func (g *ChunkGenerator) Generate(chunks []*Chunk, numMiddle int) (*GenerationRe
 }
 
 // GenerateFromGraph selects chunks matching concept graph node requirements
-// and places them left-to-right. Enforces max 2 reuses of the same chunk ID.
+// and places them, following right, top or bottom connections. Branches are
+// placed after the main path and dropped if they don't fit. Enforces max 2
+// reuses of the same chunk ID.
 func (g *ChunkGenerator) GenerateFromGraph(chunks []*Chunk, graph *ConceptGraph) (*GenerationResult, error) {
 	usageCount := make(map[string]int)
-	sequence := make([]*Chunk, 0, len(graph.Nodes))
+	placed := make([]PlacedChunk, 0, len(graph.Nodes))
+	var links []ChunkLink
 
-	for _, node := range graph.Nodes {
-		candidates := g.matchChunks(chunks, node, usageCount)
-		if len(candidates) == 0 {
-			// Fallback: relax biome constraint
-			candidates = g.matchChunksRelaxed(chunks, node, usageCount)
-		}
+	for i := range graph.Nodes {
+		node := graph.Nodes[i]
+		in, out := mainDegree(graph, i)
+		candidates := g.candidates(chunks, node, in, out, usageCount)
 		if len(candidates) == 0 {
 			return nil, fmt.Errorf("no chunk found for node type=%s tag=%s biome=%s", node.Type, node.Tag, node.Biome)
 		}
 
-		selected := candidates[g.rng.Intn(len(candidates))]
-		sequence = append(sequence, selected)
-		usageCount[selected.ID]++
+		var next *GraphNode
+		var nextIn, nextOut int
+		if i+1 < len(graph.Nodes) {
+			next = &graph.Nodes[i+1]
+			nextIn, nextOut = mainDegree(graph, i+1)
+		}
+
+		// Start from a random candidate and take the first that connects to the
+		// previous chunk without overlapping, and can still be followed by the next node
+		first := g.rng.Intn(len(candidates))
+		found := false
+		for j := 0; j < len(candidates) && !found; j++ {
+			candidate := candidates[(first+j)%len(candidates)]
+			pc, ok := placeAfter(placed, candidate)
+			if !ok || (next != nil && !canContinue(candidate, pc.EntryEdge, chunks, *next, nextIn, nextOut)) {
+				continue
+			}
+			pc.Node = &graph.Nodes[i]
+			if len(placed) > 0 {
+				placed[len(placed)-1].ExitEdge = pc.EntryEdge.Opposite()
+				links = append(links, ChunkLink{From: len(placed) - 1, To: len(placed), Edge: pc.EntryEdge.Opposite()})
+			}
+			placed = append(placed, pc)
+			usageCount[candidate.ID]++
+			found = true
+		}
+		if !found {
+			return nil, fmt.Errorf("no chunk connects for node type=%s tag=%s at position %d", node.Type, node.Tag, i)
+		}
 	}
 
-	return g.placeChunks(sequence)
+	placed, links = g.placeBranches(chunks, graph, placed, links, usageCount)
+
+	result := finishPlacement(placed)
+	result.Links = links
+	return result, nil
+}
+
+// mainDegree returns how many connections main node i needs on each side:
+// one to the previous and next node, plus one per branch leaving or rejoining.
+func mainDegree(graph *ConceptGraph, i int) (in, out int) {
+	if i > 0 {
+		in = 1 + graph.BranchesInto(i)
+	}
+	if i < len(graph.Nodes)-1 {
+		out = 1 + graph.BranchesFrom(i)
+	}
+	return in, out
+}
+
+// candidates returns the chunks that can be used for node, relaxing the biome
+// constraint if none match it
+func (g *ChunkGenerator) candidates(chunks []*Chunk, node GraphNode, in, out int, usage map[string]int) []*Chunk {
+	candidates := g.matchChunks(chunks, node, in, out, usage)
+	if len(candidates) == 0 {
+		candidates = g.matchChunksRelaxed(chunks, node, in, out, usage)
+	}
+	return candidates
+}
+
+// placeBranches places the rooms of each branch in a row off the fork room's
+// second right connection. A branch that rejoins the main path must end
+// exactly at the rejoin room's second left connection. Branches that can't
+// be placed without overlapping are left out of the level.
+func (g *ChunkGenerator) placeBranches(chunks []*Chunk, graph *ConceptGraph, placed []PlacedChunk, links []ChunkLink, usage map[string]int) ([]PlacedChunk, []ChunkLink) {
+	for bi := range graph.Branches {
+		b := &graph.Branches[bi]
+		if b.From >= len(placed) || b.Rejoin >= len(placed) {
+			continue
+		}
+		startPlaced, startLinks := len(placed), len(links)
+
+		parent, parentSlot := b.From, 1
+		complete := true
+		for k := range b.Nodes {
+			last := k == len(b.Nodes)-1
+			out := 1
+			if last && b.Rejoin < 0 {
+				out = 0
+			}
+			candidates := g.candidates(chunks, b.Nodes[k], 1, out, usage)
+
+			found := false
+			first := 0
+			if len(candidates) > 0 {
+				first = g.rng.Intn(len(candidates))
+			}
+			for j := 0; j < len(candidates) && !found; j++ {
+				candidate := candidates[(first+j)%len(candidates)]
+				pc, ok := placeRight(placed, placed[parent], parentSlot, candidate, 0)
+				if !ok || (last && b.Rejoin >= 0 && !rejoins(pc, placed[b.Rejoin])) {
+					continue
+				}
+				pc.Node = &b.Nodes[k]
+				links = append(links, ChunkLink{From: parent, To: len(placed), Edge: EdgeRight})
+				if parent >= startPlaced {
+					placed[parent].ExitEdge = EdgeRight
+				}
+				placed = append(placed, pc)
+				usage[candidate.ID]++
+				found = true
+			}
+			if !found {
+				complete = false
+				break
+			}
+			parent, parentSlot = len(placed)-1, 0
+		}
+
+		if !complete {
+			for _, pc := range placed[startPlaced:] {
+				usage[pc.Chunk.ID]--
+			}
+			placed, links = placed[:startPlaced], links[:startLinks]
+			continue
+		}
+		if b.Rejoin >= 0 {
+			placed[parent].ExitEdge = EdgeRight
+			links = append(links, ChunkLink{From: parent, To: b.Rejoin, Edge: EdgeRight})
+		}
+	}
+	return placed, links
+}
+
+// placeRight positions curr to the right of parent, joining the parent's right
+// connection in parentSlot to curr's left connection in currSlot. Returns false
+// if either connection is missing or curr would overlap a placed chunk.
+func placeRight(placed []PlacedChunk, parent PlacedChunk, parentSlot int, curr *Chunk, currSlot int) (PlacedChunk, bool) {
+	parentConn, ok := parent.Chunk.Connection(EdgeRight, parentSlot)
+	if !ok {
+		return PlacedChunk{}, false
+	}
+	currConn, ok := curr.Connection(EdgeLeft, currSlot)
+	if !ok {
+		return PlacedChunk{}, false
+	}
+
+	pc := PlacedChunk{
+		Chunk:     curr,
+		OffsetX:   parent.OffsetX + float64(parent.Chunk.Width),
+		OffsetY:   parent.OffsetY + parentConn.YOffset - currConn.YOffset,
+		EntryEdge: EdgeLeft,
+	}
+	for _, other := range placed {
+		if chunksOverlap(pc, other) {
+			return PlacedChunk{}, false
+		}
+	}
+	return pc, true
+}
+
+// rejoins reports whether the right connection of pc lines up with the
+// second left connection of the main path room it rejoins
+func rejoins(pc, target PlacedChunk) bool {
+	const eps = 0.01
+	exit, ok := pc.Chunk.Connection(EdgeRight, 0)
+	if !ok {
+		return false
+	}
+	entry, ok := target.Chunk.Connection(EdgeLeft, 1)
+	if !ok {
+		return false
+	}
+	return math.Abs(pc.OffsetX+float64(pc.Chunk.Width)-target.OffsetX) < eps &&
+		math.Abs(pc.OffsetY+exit.YOffset-(target.OffsetY+entry.YOffset)) < eps
+}
+
+// entryEdges are the edges a chunk can be entered through; exits mirror them
+var (
+	entryEdges = []ConnectionEdge{EdgeLeft, EdgeTop, EdgeBottom}
+	exitEdges  = []ConnectionEdge{EdgeRight, EdgeBottom, EdgeTop}
+)
+
+// fitsRole reports whether a chunk has the connections a node with in
+// entries and out exits needs: start chunks an exit, dead ends an entry, and
+// everything else an entry plus a different exit to leave through. Branches
+// fork through a second right connection and rejoin through a second left one;
+// chunks with extra connections are only used where a branch needs them.
+func fitsRole(c *Chunk, in, out int) bool {
+	if (out > 1) != (len(c.GetConnections(EdgeRight)) > 1) || (in > 1) != (len(c.GetConnections(EdgeLeft)) > 1) {
+		return false
+	}
+	switch {
+	case in == 0:
+		return hasAnyEdge(c, exitEdges, "")
+	case out == 0:
+		return hasAnyEdge(c, entryEdges, "")
+	}
+	for _, entry := range entryEdges {
+		if len(c.GetConnections(entry)) > 0 && hasAnyEdge(c, exitEdges, entry) {
+			return true
+		}
+	}
+	return false
 }
 
-// requiredEdges returns the connection edges a chunk must have for its tag type.
-func requiredEdges(tag ChunkTag) (required []ConnectionEdge) {
-	switch tag {
-	case TagStart:
-		return []ConnectionEdge{EdgeRight}
-	case TagExit:
-		return []ConnectionEdge{EdgeLeft}
-	default:
-		// Standard horizontal chunks
-		return []ConnectionEdge{EdgeLeft, EdgeRight}
+// hasAnyEdge reports whether c has a connection on one of edges, other than except
+func hasAnyEdge(c *Chunk, edges []ConnectionEdge, except ConnectionEdge) bool {
+	for _, e := range edges {
+		if e != except && len(c.GetConnections(e)) > 0 {
+			return true
+		}
 	}
+	return false
+}
+
+// canContinue reports whether a chunk entered through entry has an exit that
+// some chunk for the next node, with nextIn entries and nextOut exits, can connect to.
+func canContinue(c *Chunk, entry ConnectionEdge, chunks []*Chunk, next GraphNode, nextIn, nextOut int) bool {
+	for _, exit := range exitEdges {
+		if exit == entry || len(c.GetConnections(exit)) == 0 {
+			continue
+		}
+		for _, other := range chunks {
+			if other.HasTag(next.Tag) && fitsRole(other, nextIn, nextOut) && len(other.GetConnections(exit.Opposite())) > 0 {
+				return true
+			}
+		}
+	}
+	return false
 }
 
 // matchChunks finds chunks that match a graph node's requirements
-func (g *ChunkGenerator) matchChunks(chunks []*Chunk, node GraphNode, usage map[string]int) []*Chunk {
-	edges := requiredEdges(node.Tag)
+func (g *ChunkGenerator) matchChunks(chunks []*Chunk, node GraphNode, in, out int, usage map[string]int) []*Chunk {
 	var result []*Chunk
 	for _, c := range chunks {
 		if !c.HasTag(node.Tag) {
@@ -115,7 +344,7 @@ func (g *ChunkGenerator) matchChunks(chunks []*Chunk, node GraphNode, usage map[
 		if node.Biome != "" && c.Biome != node.Biome {
 			continue
 		}
-		if !hasAllEdges(c, edges) {
+		if !fitsRole(c, in, out) {
 			continue
 		}
 		result = append(result, c)
@@ -124,8 +353,7 @@ func (g *ChunkGenerator) matchChunks(chunks []*Chunk, node GraphNode, usage map[
 }
 
 // matchChunksRelaxed finds chunks matching tag only (ignoring biome)
-func (g *ChunkGenerator) matchChunksRelaxed(chunks []*Chunk, node GraphNode, usage map[string]int) []*Chunk {
-	edges := requiredEdges(node.Tag)
+func (g *ChunkGenerator) matchChunksRelaxed(chunks []*Chunk, node GraphNode, in, out int, usage map[string]int) []*Chunk {
 	var result []*Chunk
 	for _, c := range chunks {
 		if !c.HasTag(node.Tag) {
@@ -134,7 +362,7 @@ func (g *ChunkGenerator) matchChunksRelaxed(chunks []*Chunk, node GraphNode, usa
 		if usage[c.ID] >= maxChunkReuse {
 			continue
 		}
-		if !hasAllEdges(c, edges) {
+		if !fitsRole(c, in, out) {
 			continue
 		}
 		result = append(result, c)
@@ -142,68 +370,111 @@ func (g *ChunkGenerator) matchChunksRelaxed(chunks []*Chunk, node GraphNode, usa
 	return result
 }
 
-func hasAllEdges(c *Chunk, edges []ConnectionEdge) bool {
-	for _, e := range edges {
-		if len(c.GetConnections(e)) == 0 {
-			return false
-		}
-	}
-	return true
-}
-
 // FindMatchingEdges determines which edges to use for connecting two adjacent chunks.
-// All chunks connect horizontally: Right→Left.
+// Right→Left is preferred, then Bottom→Top (dropping down) and Top→Bottom (climbing up).
 func FindMatchingEdges(prev, curr *Chunk) (prevEdge, currEdge ConnectionEdge, err error) {
-	if len(prev.GetConnections(EdgeRight)) > 0 && len(curr.GetConnections(EdgeLeft)) > 0 {
-		return EdgeRight, EdgeLeft, nil
+	return matchEdges(prev, "", curr)
+}
+
+// matchEdges is FindMatchingEdges for a previous chunk that was entered through
+// prevEntry, which can't also be its exit.
+func matchEdges(prev *Chunk, prevEntry ConnectionEdge, curr *Chunk) (prevEdge, currEdge ConnectionEdge, err error) {
+	for _, exit := range exitEdges {
+		if exit == prevEntry {
+			continue
+		}
+		if len(prev.GetConnections(exit)) > 0 && len(curr.GetConnections(exit.Opposite())) > 0 {
+			return exit, exit.Opposite(), nil
+		}
 	}
 	return "", "", fmt.Errorf("no compatible edges between chunk %q and %q", prev.ID, curr.ID)
 }
 
-// placeChunks positions chunks using directional connection matching
-func (g *ChunkGenerator) placeChunks(sequence []*Chunk) (*GenerationResult, error) {
-	if len(sequence) == 0 {
-		return nil, fmt.Errorf("empty chunk sequence")
+// placeAfter positions curr against the last placed chunk, aligning their
+// connection points. Returns false if they can't connect or curr would overlap
+// an already placed chunk.
+func placeAfter(placed []PlacedChunk, curr *Chunk) (PlacedChunk, bool) {
+	if len(placed) == 0 {
+		return PlacedChunk{Chunk: curr}, true
 	}
+	prev := placed[len(placed)-1]
 
-	placed := make([]PlacedChunk, len(sequence))
-
-	// Place first chunk at origin
-	placed[0] = PlacedChunk{
-		Chunk:   sequence[0],
-		OffsetX: 0,
-		OffsetY: 0,
+	prevEdge, currEdge, err := matchEdges(prev.Chunk, prev.EntryEdge, curr)
+	if err != nil {
+		return PlacedChunk{}, false
 	}
+	prevConn, _ := prev.Chunk.Connection(prevEdge, 0)
+	currConn, _ := curr.Connection(currEdge, 0)
 
-	for i := 1; i < len(sequence); i++ {
-		prev := placed[i-1]
-		curr := sequence[i]
+	pc := PlacedChunk{Chunk: curr, EntryEdge: currEdge}
+	switch prevEdge {
+	case EdgeRight:
+		// Horizontal: place to the right, align Y via connection YOffset
+		pc.OffsetX = prev.OffsetX + float64(prev.Chunk.Width)
+		pc.OffsetY = prev.OffsetY + prevConn.YOffset - currConn.YOffset
+	case EdgeBottom:
+		// Drop down: place below, align X via connection XOffset
+		pc.OffsetX = prev.OffsetX + prevConn.XOffset - currConn.XOffset
+		pc.OffsetY = prev.OffsetY + float64(prev.Chunk.Height)
+	case EdgeTop:
+		// Climb up: place above, align X via connection XOffset
+		pc.OffsetX = prev.OffsetX + prevConn.XOffset - currConn.XOffset
+		pc.OffsetY = prev.OffsetY - float64(curr.Height)
+	}
 
-		prevEdge, currEdge, err := FindMatchingEdges(prev.Chunk, curr)
-		if err != nil {
-			return nil, err
+	for _, other := range placed {
+		if chunksOverlap(pc, other) {
+			return PlacedChunk{}, false
 		}
+	}
+	return pc, true
+}
 
-		prevConn := prev.Chunk.GetConnections(prevEdge)[0]
-		currConn := curr.GetConnections(currEdge)[0]
+// chunksOverlap reports whether two placed chunks share any area
+func chunksOverlap(a, b PlacedChunk) bool {
+	const eps = 0.01
+	return a.OffsetX < b.OffsetX+float64(b.Chunk.Width)-eps &&
+		b.OffsetX < a.OffsetX+float64(a.Chunk.Width)-eps &&
+		a.OffsetY < b.OffsetY+float64(b.Chunk.Height)-eps &&
+		b.OffsetY < a.OffsetY+float64(a.Chunk.Height)-eps
+}
 
-		// Horizontal: place to the right, align Y via connection YOffset
-		offsetX := prev.OffsetX + float64(prev.Chunk.Width)
-		offsetY := prev.OffsetY + prevConn.YOffset - currConn.YOffset
+// placeChunks positions a fixed chunk sequence using directional connection matching
+func (g *ChunkGenerator) placeChunks(sequence []*Chunk) (*GenerationResult, error) {
+	if len(sequence) == 0 {
+		return nil, fmt.Errorf("empty chunk sequence")
+	}
 
-		placed[i] = PlacedChunk{
-			Chunk:   curr,
-			OffsetX: offsetX,
-			OffsetY: offsetY,
+	placed := make([]PlacedChunk, 0, len(sequence))
+	var links []ChunkLink
+	for _, curr := range sequence {
+		pc, ok := placeAfter(placed, curr)
+		if !ok {
+			prev := placed[len(placed)-1].Chunk
+			return nil, fmt.Errorf("chunk %q cannot be placed after %q", curr.ID, prev.ID)
+		}
+		if len(placed) > 0 {
+			placed[len(placed)-1].ExitEdge = pc.EntryEdge.Opposite()
+			links = append(links, ChunkLink{From: len(placed) - 1, To: len(placed), Edge: pc.EntryEdge.Opposite()})
 		}
+		placed = append(placed, pc)
 	}
 
-	// Calculate total dimensions
-	var maxRight float64
-	var minY, maxBottom float64
+	result := finishPlacement(placed)
+	result.Links = links
+	return result, nil
+}
+
+// finishPlacement normalizes offsets so the level starts at the origin, adds
+// camera headroom and computes the total level size.
+func finishPlacement(placed []PlacedChunk) *GenerationResult {
+	var minX, minY, maxRight, maxBottom float64
 	for i, p := range placed {
 		right := p.OffsetX + float64(p.Chunk.Width)
 		bottom := p.OffsetY + float64(p.Chunk.Height)
+		if i == 0 || p.OffsetX < minX {
+			minX = p.OffsetX
+		}
 		if i == 0 || right > maxRight {
 			maxRight = right
 		}
@@ -215,13 +486,14 @@ func (g *ChunkGenerator) placeChunks(sequence []*Chunk) (*GenerationResult, erro
 		}
 	}
 
-	// Normalize Y offsets so minimum is 0
-	if minY < 0 {
-		for i := range placed {
-			placed[i].OffsetY -= minY
-		}
-		maxBottom -= minY
+	// Normalize offsets so the minimum is 0 (climbing chunks can extend above
+	// the start, and vertical connections can shift chunks left of it)
+	for i := range placed {
+		placed[i].OffsetX -= minX
+		placed[i].OffsetY -= minY
 	}
+	maxRight -= minX
+	maxBottom -= minY
 
 	// Add minimal headroom above chunks so the camera can follow jumps
 	// without clipping the player off-screen. Less headroom = camera
@@ -239,7 +511,7 @@ func (g *ChunkGenerator) placeChunks(sequence []*Chunk) (*GenerationResult, erro
 		PlacedChunks: placed,
 		TotalWidth:   int(maxRight),
 		TotalHeight:  int(maxBottom),
-	}, nil
+	}
 }
 
 func filterByTag(chunks []*Chunk, tag ChunkTag) []*Chunk {
@@ -258,8 +530,8 @@ func filterMiddle(chunks []*Chunk) []*Chunk {
 		if c.HasTag(TagStart) || c.HasTag(TagExit) {
 			continue
 		}
-		// Must have both left and right connections
-		if len(c.GetConnections(EdgeLeft)) > 0 && len(c.GetConnections(EdgeRight)) > 0 {
+		// Must have both left and right connections, and no branch connections
+		if len(c.GetConnections(EdgeLeft)) == 1 && len(c.GetConnections(EdgeRight)) == 1 {
 			result = append(result, c)
 		}
 	}
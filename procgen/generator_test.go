@@ -1,6 +1,7 @@
 package procgen_test
 
 import (
+	"math/rand"
 	"testing"
 
 	"github.com/automoto/doomerang/procgen"
@@ -207,6 +208,19 @@ func TestFindMatchingEdges(t *testing.T) {
 			wantPrev: procgen.EdgeRight,
 			wantCurr: procgen.EdgeLeft,
 		},
+		{
+			name: "vertical bottom-top",
+			prev: &procgen.Chunk{ID: "a", Connections: []procgen.ConnectionPoint{
+				{Edge: procgen.EdgeLeft, YOffset: 224, Width: 48},
+				{Edge: procgen.EdgeBottom, XOffset: 160, Width: 96},
+			}},
+			curr: &procgen.Chunk{ID: "b", Connections: []procgen.ConnectionPoint{
+				{Edge: procgen.EdgeTop, XOffset: 160, Width: 96},
+				{Edge: procgen.EdgeRight, YOffset: 224, Width: 48},
+			}},
+			wantPrev: procgen.EdgeBottom,
+			wantCurr: procgen.EdgeTop,
+		},
 		{
 			name: "no compatible edges",
 			prev: &procgen.Chunk{ID: "a", Connections: []procgen.ConnectionPoint{
@@ -247,3 +261,109 @@ func abs(x float64) float64 {
 	}
 	return x
 }
+
+func TestGenerateFromGraphVerticalPlacement(t *testing.T) {
+	chunks := loadTestChunks(t)
+
+	drops := 0
+	for seed := int64(0); seed < 50; seed++ {
+		rng := rand.New(rand.NewSource(seed))
+		graph := procgen.GenerateGraph(rng, 8, []string{"cyberpunk"})
+		procgen.ValidateGraph(graph)
+
+		gen := procgen.NewChunkGenerator(seed)
+		result, err := gen.GenerateFromGraph(chunks, graph)
+		if err != nil {
+			continue
+		}
+
+		placed := result.PlacedChunks
+		for i := 1; i < result.MainPathLength(); i++ {
+			prev, curr := placed[i-1], placed[i]
+			if prev.ExitEdge != curr.EntryEdge.Opposite() {
+				t.Errorf("seed %d chunk %d: exit %s does not match entry %s", seed, i, prev.ExitEdge, curr.EntryEdge)
+			}
+			if prev.ExitEdge == procgen.EdgeBottom {
+				drops++
+				prevConn := prev.Chunk.GetConnections(procgen.EdgeBottom)[0]
+				currConn := curr.Chunk.GetConnections(procgen.EdgeTop)[0]
+				if curr.OffsetY != prev.OffsetY+float64(prev.Chunk.Height) {
+					t.Errorf("seed %d chunk %d: expected to start at Y %v, got %v", seed, i, prev.OffsetY+float64(prev.Chunk.Height), curr.OffsetY)
+				}
+				if prev.OffsetX+prevConn.XOffset != curr.OffsetX+currConn.XOffset {
+					t.Errorf("seed %d chunk %d: openings misaligned", seed, i)
+				}
+			}
+
+			// Placed chunks never overlap
+			for j := 0; j < i; j++ {
+				other := placed[j]
+				if curr.OffsetX < other.OffsetX+float64(other.Chunk.Width) && other.OffsetX < curr.OffsetX+float64(curr.Chunk.Width) &&
+					curr.OffsetY < other.OffsetY+float64(other.Chunk.Height) && other.OffsetY < curr.OffsetY+float64(curr.Chunk.Height) {
+					t.Errorf("seed %d: chunks %d and %d overlap", seed, j, i)
+				}
+			}
+		}
+	}
+
+	if drops == 0 {
+		t.Error("expected at least one seed to place a drop-down connection")
+	}
+}
+
+func TestGenerateFromGraphBranches(t *testing.T) {
+	chunks := loadTestChunks(t)
+
+	branches := 0
+	for seed := int64(0); seed < 50; seed++ {
+		rng := rand.New(rand.NewSource(seed))
+		graph := procgen.GenerateGraph(rng, 12, []string{"cyberpunk"})
+		procgen.ValidateGraph(graph)
+
+		gen := procgen.NewChunkGenerator(seed)
+		result, err := gen.GenerateFromGraph(chunks, graph)
+		if err != nil {
+			continue
+		}
+
+		placed := result.PlacedChunks
+		if result.MainPathLength() != len(graph.Nodes) {
+			t.Fatalf("seed %d: expected %d main path chunks, got %d", seed, len(graph.Nodes), result.MainPathLength())
+		}
+		for i := result.MainPathLength(); i < len(placed); i++ {
+			branches++
+			if !placed[i].Optional() {
+				t.Errorf("seed %d chunk %d: branch chunk should be optional", seed, i)
+			}
+
+			// Every branch chunk is reached from an earlier chunk
+			reached := false
+			for _, l := range result.Links {
+				if l.To == i && l.From < i {
+					reached = true
+				}
+			}
+			if !reached {
+				t.Errorf("seed %d chunk %d: no link into branch chunk", seed, i)
+			}
+
+			for j := 0; j < len(placed); j++ {
+				other := placed[j]
+				if j != i && placed[i].OffsetX < other.OffsetX+float64(other.Chunk.Width) &&
+					other.OffsetX < placed[i].OffsetX+float64(placed[i].Chunk.Width) &&
+					placed[i].OffsetY < other.OffsetY+float64(other.Chunk.Height) &&
+					other.OffsetY < placed[i].OffsetY+float64(placed[i].Chunk.Height) {
+					t.Errorf("seed %d: branch chunk %d overlaps chunk %d", seed, i, j)
+				}
+			}
+		}
+
+		if vr := procgen.NewValidator().Validate(result); !vr.Solvable {
+			t.Errorf("seed %d: layout with branches should be solvable, unreachable: %v", seed, vr.Unreachable)
+		}
+	}
+
+	if branches == 0 {
+		t.Error("expected at least one branch to be placed")
+	}
+}
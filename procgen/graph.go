@@ -2,6 +2,8 @@ package procgen
 
 import (
 	"math/rand"
+
+	"github.com/automoto/doomerang/config"
 )
 
 // NodeType represents the gameplay purpose of a concept graph node
@@ -13,6 +15,7 @@ const (
 	NodeTraversal NodeType = "traversal"
 	NodeBreakRoom NodeType = "break"
 	NodeArena     NodeType = "arena"
+	NodeBonus     NodeType = "bonus"
 	NodeExit      NodeType = "exit"
 )
 
@@ -22,11 +25,51 @@ type GraphNode struct {
 	Difficulty int
 	Biome      string
 	Tag        ChunkTag // Required chunk tag for selection
+	Optional   bool     // Off the main path; the run can be finished without it
+	Reward     bool     // Holds a reward for the player who detours here
+}
+
+// Branch is an optional path off the main line. It leaves main node From and
+// rejoins the main line at node Rejoin, or ends in a dead end when Rejoin is -1.
+type Branch struct {
+	From   int
+	Rejoin int
+	Nodes  []GraphNode
 }
 
-// ConceptGraph is an ordered sequence of nodes describing the run structure
+// ConceptGraph describes the run structure: the main path from start to exit,
+// in order, plus optional branches off it
 type ConceptGraph struct {
-	Nodes []GraphNode
+	Nodes    []GraphNode
+	Branches []Branch
+}
+
+// MainPath returns the graph without its branches. The nodes are shared, so
+// rooms placed from it still point into g.Nodes.
+func (g *ConceptGraph) MainPath() *ConceptGraph {
+	return &ConceptGraph{Nodes: g.Nodes}
+}
+
+// BranchesFrom returns how many branches leave main node i
+func (g *ConceptGraph) BranchesFrom(i int) int {
+	n := 0
+	for _, b := range g.Branches {
+		if b.From == i {
+			n++
+		}
+	}
+	return n
+}
+
+// BranchesInto returns how many branches rejoin the main path at node i
+func (g *ConceptGraph) BranchesInto(i int) int {
+	n := 0
+	for _, b := range g.Branches {
+		if b.Rejoin == i {
+			n++
+		}
+	}
+	return n
 }
 
 // GenerateGraph creates a concept graph with pacing rules applied.
@@ -86,7 +129,66 @@ func GenerateGraph(rng *rand.Rand, length int, biomes []string) *ConceptGraph {
 		Tag:        TagExit,
 	})
 
-	return &ConceptGraph{Nodes: nodes}
+	graph := &ConceptGraph{Nodes: nodes}
+	addBranches(rng, graph)
+	return graph
+}
+
+// addBranches forks optional paths off middle nodes of the main line: risky
+// side paths that bypass the next room and rejoin after it, and dead-end bonus
+// rooms. The fork and rejoin rooms become traversal rooms, since climbing to
+// the upper route is their gameplay. Branches never share main nodes.
+func addBranches(rng *rand.Rand, graph *ConceptGraph) {
+	// Arenas and break rooms keep their role; the rooms around a branch are retyped
+	fixed := func(i int) bool {
+		return graph.Nodes[i].Type == NodeArena || graph.Nodes[i].Type == NodeBreakRoom
+	}
+
+	exit := len(graph.Nodes) - 1
+	for i := 1; i < exit-1; i++ {
+		roll := rng.Float64()
+		if fixed(i) {
+			continue
+		}
+		switch {
+		case roll < config.Procgen.SidePathChance && i+2 < exit && !fixed(i+2):
+			// Side path above the bypassed node, one step harder than the main line
+			diff := graph.Nodes[i+1].Difficulty + 1
+			if diff > config.Procgen.MaxDifficulty {
+				diff = config.Procgen.MaxDifficulty
+			}
+			graph.Nodes[i].Type, graph.Nodes[i].Tag = NodeTraversal, TagTraversal
+			graph.Nodes[i+2].Type, graph.Nodes[i+2].Tag = NodeTraversal, TagTraversal
+			graph.Branches = append(graph.Branches, Branch{
+				From:   i,
+				Rejoin: i + 2,
+				Nodes: []GraphNode{{
+					Type:       NodeCombat,
+					Difficulty: diff,
+					Biome:      graph.Nodes[i+1].Biome,
+					Tag:        TagCombat,
+					Optional:   true,
+					Reward:     true,
+				}},
+			})
+			i += 2
+		case roll < config.Procgen.SidePathChance+config.Procgen.BonusRoomChance:
+			graph.Nodes[i].Type, graph.Nodes[i].Tag = NodeTraversal, TagTraversal
+			graph.Branches = append(graph.Branches, Branch{
+				From:   i,
+				Rejoin: -1,
+				Nodes: []GraphNode{{
+					Type:       NodeBonus,
+					Difficulty: graph.Nodes[i].Difficulty,
+					Biome:      graph.Nodes[i].Biome,
+					Tag:        TagBonus,
+					Optional:   true,
+					Reward:     true,
+				}},
+			})
+			i++
+		}
+	}
 }
 
 func pickNodeType(rng *rand.Rand, position, middleCount, combatStreak, combatsSinceBreak, difficulty int) NodeType {
@@ -139,6 +241,8 @@ func nodeTypeToTag(nt NodeType) ChunkTag {
 		return TagTraversal
 	case NodeBreakRoom:
 		return TagBreak
+	case NodeBonus:
+		return TagBonus
 	case NodeExit:
 		return TagExit
 	default:
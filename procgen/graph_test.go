@@ -117,15 +117,15 @@ func TestGenerateFromGraph(t *testing.T) {
 		t.Fatalf("GenerateFromGraph failed: %v", err)
 	}
 
-	if len(result.PlacedChunks) != len(graph.Nodes) {
-		t.Errorf("expected %d placed chunks, got %d", len(graph.Nodes), len(result.PlacedChunks))
+	if result.MainPathLength() != len(graph.Nodes) {
+		t.Errorf("expected %d main path chunks, got %d", len(graph.Nodes), result.MainPathLength())
 	}
 
 	// First should be start, last should be exit
 	if !result.PlacedChunks[0].Chunk.HasTag(procgen.TagStart) {
 		t.Error("first chunk should be start")
 	}
-	last := result.PlacedChunks[len(result.PlacedChunks)-1]
+	last := result.PlacedChunks[result.MainPathLength()-1]
 	if !last.Chunk.HasTag(procgen.TagExit) {
 		t.Error("last chunk should be exit")
 	}
@@ -155,3 +155,88 @@ func TestGenerateFromGraphChunkReuse(t *testing.T) {
 		}
 	}
 }
+
+func TestGraphBranches(t *testing.T) {
+	sidePaths, bonusRooms := 0, 0
+	for seed := int64(0); seed < 100; seed++ {
+		rng := rand.New(rand.NewSource(seed))
+		graph := procgen.GenerateGraph(rng, 12, []string{"cyberpunk"})
+		procgen.ValidateGraph(graph)
+
+		exit := len(graph.Nodes) - 1
+		for _, b := range graph.Branches {
+			if b.From < 1 || b.From >= exit {
+				t.Errorf("seed %d: branch leaves from invalid node %d", seed, b.From)
+			}
+			if b.Rejoin >= 0 && (b.Rejoin <= b.From+1 || b.Rejoin >= exit) {
+				t.Errorf("seed %d: branch from %d rejoins at invalid node %d", seed, b.From, b.Rejoin)
+			}
+			for _, node := range b.Nodes {
+				if !node.Optional {
+					t.Errorf("seed %d: branch node %s should be optional", seed, node.Type)
+				}
+			}
+			if b.Rejoin < 0 {
+				bonusRooms++
+			} else {
+				sidePaths++
+			}
+		}
+	}
+
+	if sidePaths == 0 || bonusRooms == 0 {
+		t.Errorf("expected both side paths and bonus rooms, got %d and %d", sidePaths, bonusRooms)
+	}
+}
+
+func TestGenerateFromBranchedGraphs(t *testing.T) {
+	chunks := loadTestChunks(t)
+
+	failures := 0
+	for seed := int64(0); seed < 200; seed++ {
+		rng := rand.New(rand.NewSource(seed))
+		graph := procgen.GenerateGraph(rng, 10, []string{"cyberpunk"})
+		procgen.ValidateGraph(graph)
+
+		result, err := procgen.NewChunkGenerator(seed).GenerateFromGraph(chunks, graph)
+		if err != nil {
+			failures++
+			continue
+		}
+		for i, pc := range result.PlacedChunks {
+			if pc.Node == nil {
+				t.Errorf("seed %d: room %d has no graph node", seed, i)
+			}
+		}
+	}
+
+	// Forks and merges have their own chunks; running out of them used to
+	// fail a few percent of seeds
+	if failures > 2 {
+		t.Errorf("expected branched placement to fail at most 1%% of seeds, failed %d of 200", failures)
+	}
+}
+
+func TestGenerateFromGraphMainPath(t *testing.T) {
+	chunks := loadTestChunks(t)
+	rng := rand.New(rand.NewSource(7))
+	graph := procgen.GenerateGraph(rng, 10, []string{"cyberpunk"})
+	procgen.ValidateGraph(graph)
+	if len(graph.Branches) == 0 {
+		t.Fatal("expected the test graph to have branches")
+	}
+
+	result, err := procgen.NewChunkGenerator(7).GenerateFromGraph(chunks, graph.MainPath())
+	if err != nil {
+		t.Fatalf("GenerateFromGraph failed: %v", err)
+	}
+
+	if len(result.PlacedChunks) != len(graph.Nodes) {
+		t.Fatalf("expected only the %d main path rooms, got %d", len(graph.Nodes), len(result.PlacedChunks))
+	}
+	for i, pc := range result.PlacedChunks {
+		if pc.Node != &graph.Nodes[i] {
+			t.Errorf("room %d: expected main path node %d", i, i)
+		}
+	}
+}
@@ -0,0 +1,297 @@
+package procgen
+
+import (
+	"fmt"
+	"io/fs"
+	"math"
+	"path"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/automoto/doomerang/config"
+	"github.com/lafriks/go-tiled"
+)
+
+// The loader is forgiving so a rough chunk still plays: it clamps difficulty,
+// guesses connection edges and defaults hazard types. The linter reports
+// those guesses, and the rules no loader checks, as authoring mistakes.
+
+// knownChunkTags are the tags the concept graph asks for
+var knownChunkTags = []ChunkTag{TagCombat, TagTraversal, TagBreak, TagHazard, TagBonus, TagStart, TagExit}
+
+// knownHazardTypes are the hazard_type values HazardPlacer spawns
+var knownHazardTypes = []string{"deadzone", "fire_pulsing", "fire_continuous"}
+
+// lintEdgeTolerance is how far in pixels a connection may sit from its edge
+const lintEdgeTolerance = 2.0
+
+// LintIssue is one authoring mistake in a chunk file
+type LintIssue struct {
+	Path    string
+	Message string
+}
+
+func (i LintIssue) String() string {
+	return i.Path + ": " + i.Message
+}
+
+// LintChunks checks every chunk in dir. Each file is loaded on its own so a
+// chunk that fails to load is reported without hiding problems in the rest.
+// Issues are sorted by file.
+func (cl *ChunkLoader) LintChunks(dir string) ([]LintIssue, error) {
+	entries, err := fs.ReadDir(cl.fs, dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read chunks directory %s: %w", dir, err)
+	}
+
+	var issues []LintIssue
+	seen := map[string]string{} // chunk ID -> first file using it
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".tmx") {
+			continue
+		}
+		file := path.Join(dir, entry.Name())
+		chunk, err := cl.LoadChunk(file)
+		if err != nil {
+			issues = append(issues, LintIssue{file, err.Error()})
+			continue
+		}
+		if first, ok := seen[chunk.ID]; ok {
+			issues = append(issues, LintIssue{file, fmt.Sprintf("chunk_id %q is already used by %s", chunk.ID, first)})
+		} else {
+			seen[chunk.ID] = file
+		}
+		for _, msg := range LintChunk(chunk) {
+			issues = append(issues, LintIssue{file, msg})
+		}
+	}
+
+	sort.SliceStable(issues, func(i, j int) bool { return issues[i].Path < issues[j].Path })
+	return issues, nil
+}
+
+// LintChunk returns the authoring mistakes in a loaded chunk
+func LintChunk(c *Chunk) []string {
+	var issues []string
+	add := func(format string, args ...interface{}) {
+		issues = append(issues, fmt.Sprintf(format, args...))
+	}
+	props := c.TiledMap.Properties
+
+	if c.Biome == "default" && props.GetString("biome") == "" {
+		add("biome is missing, so the chunk is only used by runs without a biome")
+	} else if !slices.Contains(config.Procgen.Biomes, c.Biome) {
+		add("biome %q is not one of %s", c.Biome, strings.Join(config.Procgen.Biomes, ", "))
+	}
+
+	if raw := props.GetString("difficulty"); raw == "" {
+		add("difficulty is missing and defaults to 1")
+	} else if d, err := strconv.Atoi(raw); err != nil || d < 1 || d > 5 {
+		add("difficulty %q must be 1-5; it is treated as 1", raw)
+	}
+
+	if len(c.Tags) == 0 {
+		add("tags is missing, so the chunk is never placed")
+	}
+	for _, tag := range c.Tags {
+		if !slices.Contains(knownChunkTags, tag) {
+			add("unknown tag %q", tag)
+		}
+	}
+
+	if c.MinEnemies < 0 || c.MaxEnemies < 0 {
+		add("min_enemies and max_enemies must not be negative")
+	}
+	if c.MinEnemies > c.MaxEnemies {
+		add("min_enemies %d is more than max_enemies %d", c.MinEnemies, c.MaxEnemies)
+	}
+
+	if !hasLayer(c.TiledMap, "wg-tiles") {
+		add(`no "wg-tiles" layer, so the chunk has no collision`)
+	}
+
+	issues = append(issues, lintConnections(c)...)
+	issues = append(issues, lintEnemySlots(c)...)
+	issues = append(issues, lintHazardSlots(c)...)
+	issues = append(issues, lintReachability(c)...)
+	return issues
+}
+
+func hasLayer(m *tiled.Map, name string) bool {
+	for _, layer := range m.Layers {
+		if layer.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// objects returns the objects of the named object group
+func objects(m *tiled.Map, group string) []*tiled.Object {
+	for _, og := range m.ObjectGroups {
+		if og.Name == group {
+			return og.Objects
+		}
+	}
+	return nil
+}
+
+// objectName identifies an object in an issue
+func objectName(o *tiled.Object) string {
+	if o.Name != "" {
+		return fmt.Sprintf("%q", o.Name)
+	}
+	return fmt.Sprintf("object %d", o.ID)
+}
+
+func lintConnections(c *Chunk) []string {
+	var issues []string
+	w, h := float64(c.Width), float64(c.Height)
+	conns := objects(c.TiledMap, "Connections")
+	if len(conns) == 0 {
+		issues = append(issues, "no Connections, so the chunk can't be linked to its neighbours")
+	}
+
+	type slotKey struct {
+		edge ConnectionEdge
+		slot int
+	}
+	slots := map[slotKey]string{}
+	for _, o := range conns {
+		name := objectName(o)
+		edge := ConnectionEdge(o.Properties.GetString("edge"))
+		switch edge {
+		case "":
+			edge = inferEdge(o, w, h)
+			issues = append(issues, fmt.Sprintf("connection %s has no edge property; it is read as %s", name, edge))
+		case EdgeLeft, EdgeRight, EdgeTop, EdgeBottom:
+		default:
+			issues = append(issues, fmt.Sprintf("connection %s has unknown edge %q", name, edge))
+			continue
+		}
+
+		var flush bool
+		switch edge {
+		case EdgeLeft:
+			flush = math.Abs(o.X) <= lintEdgeTolerance
+		case EdgeRight:
+			flush = math.Abs(o.X+o.Width-w) <= lintEdgeTolerance
+		case EdgeTop:
+			flush = math.Abs(o.Y) <= lintEdgeTolerance
+		case EdgeBottom:
+			flush = math.Abs(o.Y+o.Height-h) <= lintEdgeTolerance
+		}
+		if !flush {
+			issues = append(issues, fmt.Sprintf("connection %s is not flush with the %s edge", name, edge))
+		}
+
+		if bottom := o.Y + o.Height; bottom <= 0 || bottom > h {
+			issues = append(issues, fmt.Sprintf("connection %s has Y offset %v outside the chunk height %v", name, bottom, h))
+		}
+		if o.X < 0 || o.X+o.Width > w {
+			issues = append(issues, fmt.Sprintf("connection %s extends outside the chunk width %v", name, w))
+		}
+
+		key := slotKey{edge, o.Properties.GetInt("slot")}
+		if other, ok := slots[key]; ok {
+			issues = append(issues, fmt.Sprintf("connection %s reuses slot %d on the %s edge of %s", name, key.slot, edge, other))
+		} else {
+			slots[key] = name
+		}
+	}
+	return issues
+}
+
+// lintEnemySlots checks that each enemy slot stands on ground: a solid tile
+// under the slot, no more than a tile below it
+func lintEnemySlots(c *Chunk) []string {
+	var issues []string
+	for _, o := range objects(c.TiledMap, "EnemySlots") {
+		feet := o.Y + o.Height
+		supported := false
+		for _, t := range c.SolidTiles {
+			if t.X+t.Width > o.X && t.X < o.X+math.Max(o.Width, 1) &&
+				t.Y >= feet-t.Height/2 && t.Y <= feet+t.Height {
+				supported = true
+				break
+			}
+		}
+		if !supported {
+			issues = append(issues, fmt.Sprintf("enemy slot %s at (%v, %v) is not standing on solid ground", objectName(o), o.X, feet))
+		}
+	}
+	return issues
+}
+
+func lintHazardSlots(c *Chunk) []string {
+	var issues []string
+	for _, o := range objects(c.TiledMap, "HazardSlots") {
+		if t := o.Properties.GetString("hazard_type"); t == "" {
+			issues = append(issues, fmt.Sprintf("hazard slot %s has no hazard_type; it defaults to fire_pulsing", objectName(o)))
+		} else if !slices.Contains(knownHazardTypes, t) {
+			issues = append(issues, fmt.Sprintf("hazard slot %s has unknown hazard_type %q, expected one of %s",
+				objectName(o), t, strings.Join(knownHazardTypes, ", ")))
+		}
+	}
+	return issues
+}
+
+// lintReachability checks by playing the chunk on its own, as the validator
+// plays a level, that every platform can be reached from one of its connections
+func lintReachability(c *Chunk) []string {
+	v := NewValidator()
+	result := &GenerationResult{PlacedChunks: []PlacedChunk{{Chunk: c}}}
+	platforms := v.discoverPlatforms(result)
+
+	tileH := float64(c.TiledMap.TileHeight)
+	var from []int
+	for i, p := range platforms {
+		if nearConnection(c, p, tileH) {
+			from = append(from, i)
+		}
+	}
+	reachable := v.reachablePlatforms(result, platforms, from, -1)
+	for i, p := range platforms {
+		// Surfaces on the chunk's top row are the tops of walls and ceilings
+		if p.Y < tileH {
+			reachable[i] = true
+		}
+	}
+
+	var issues []string
+	for i, p := range platforms {
+		if !reachable[i] {
+			issues = append(issues, fmt.Sprintf("platform at (%v, %v) %v wide can't be reached from any connection", p.X, p.Y, p.Width))
+		}
+	}
+	return issues
+}
+
+// nearConnection reports whether the player entering through one of the
+// chunk's connections lands on or can step onto p
+func nearConnection(c *Chunk, p Platform, tileH float64) bool {
+	for _, cp := range c.Connections {
+		var x, y float64
+		switch cp.Edge {
+		case EdgeLeft:
+			x, y = 0, cp.YOffset
+		case EdgeRight:
+			x, y = float64(c.Width), cp.YOffset
+		default:
+			// Entering through the floor or ceiling drops the player straight
+			// down the opening, onto the first platform under it
+			x, y = cp.XOffset+cp.Width/2, 0
+			if p.X <= x && x <= p.X+p.Width && p.Y >= y {
+				return true
+			}
+			continue
+		}
+		dx := math.Max(0, math.Max(p.X-x, x-(p.X+p.Width)))
+		if dx <= tileH*2 && math.Abs(p.Y-y) <= tileH*2 {
+			return true
+		}
+	}
+	return false
+}
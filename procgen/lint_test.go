@@ -0,0 +1,126 @@
+package procgen_test
+
+import (
+	"io/fs"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/automoto/doomerang/assets"
+	"github.com/automoto/doomerang/procgen"
+)
+
+func TestShippedChunksLintClean(t *testing.T) {
+	issues, err := procgen.NewChunkLoader().LintChunks("chunks")
+	if err != nil {
+		t.Fatalf("LintChunks failed: %v", err)
+	}
+	for _, issue := range issues {
+		t.Error(issue)
+	}
+}
+
+// lintFS returns a filesystem holding the built-in tileset and the given chunks
+func lintFS(t *testing.T, chunks map[string]string) fstest.MapFS {
+	t.Helper()
+	tileset, err := fs.ReadFile(assets.GetAssetFS(), "levels/tilesets/cyberpunk-tiles.tsx")
+	if err != nil {
+		t.Fatalf("failed to read tileset: %v", err)
+	}
+	fsys := fstest.MapFS{"levels/tilesets/cyberpunk-tiles.tsx": {Data: tileset}}
+	for name, tmx := range chunks {
+		fsys["chunks/"+name] = &fstest.MapFile{Data: []byte(tmx)}
+	}
+	return fsys
+}
+
+// moveTileRows moves n rows of the wg-tiles layer from row from to row to,
+// leaving the rows they came from empty
+func moveTileRows(t *testing.T, tmx string, from, to, n int) string {
+	t.Helper()
+	start := strings.Index(tmx, `<data encoding="csv">`) + len(`<data encoding="csv">`) + 1
+	end := strings.Index(tmx, "\n</data>")
+	rows := strings.Split(tmx[start:end], "\n")
+	empty := rows[0]
+	moved := append([]string(nil), rows[from:from+n]...)
+	for i := 0; i < n; i++ {
+		rows[from+i] = empty
+	}
+	copy(rows[to:], moved)
+	return tmx[:start] + strings.Join(rows, "\n") + tmx[end:]
+}
+
+func TestLintChunkIssues(t *testing.T) {
+	data, err := fs.ReadFile(assets.GetAssetFS(), "chunks/combat_02.tmx")
+	if err != nil {
+		t.Fatalf("failed to read chunk: %v", err)
+	}
+	base := string(data)
+
+	tests := []struct {
+		name     string
+		old, new string
+		want     string
+	}{
+		{"missing id", `<property name="chunk_id" value="combat_02"/>`, "", "missing required 'chunk_id'"},
+		{"unknown tag", `value="combat"/>`, `value="combat,boss"/>`, `unknown tag "boss"`},
+		{"bad difficulty", `name="difficulty" type="int" value="2"`, `name="difficulty" type="int" value="9"`, `difficulty "9" must be 1-5`},
+		{"unknown biome", `value="cyberpunk"`, `value="jungle"`, `biome "jungle" is not one of`},
+		{"min over max", `name="min_enemies" type="int" value="2"`, `name="min_enemies" type="int" value="6"`, "min_enemies 6 is more than max_enemies 5"},
+		{"inferred edge", `<property name="edge" value="left"/>`, "", `"entry_left" has no edge property; it is read as left`},
+		{"not flush", `name="exit_right" x="592"`, `name="exit_right" x="560"`, `"exit_right" is not flush with the right edge`},
+		{"outside height", `name="entry_left" x="0" y="224"`, `name="entry_left" x="0" y="300"`, `"entry_left" has Y offset 348 outside the chunk height 320`},
+		{"duplicate slot", `<property name="edge" value="right"/>`, `<property name="edge" value="left"/>`, `reuses slot 0 on the left edge`},
+		{"floating enemy", `name="enemy_ground_1" x="160" y="232"`, `name="enemy_ground_1" x="160" y="150"`, `enemy slot "enemy_ground_1" at (160, 190) is not standing on solid ground`},
+		{"unknown hazard", `<property name="hazard_type" value="fire_pulsing"/>`, `<property name="hazard_type" value="lava"/>`, `unknown hazard_type "lava"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmx := strings.Replace(base, tt.old, tt.new, 1)
+			if tmx == base {
+				t.Fatalf("%q not found in the chunk", tt.old)
+			}
+			loader := procgen.NewChunkLoaderWithFS(lintFS(t, map[string]string{"bad.tmx": tmx}))
+			issues, err := loader.LintChunks("chunks")
+			if err != nil {
+				t.Fatalf("LintChunks failed: %v", err)
+			}
+			for _, issue := range issues {
+				if strings.Contains(issue.Message, tt.want) {
+					return
+				}
+			}
+			t.Errorf("expected an issue containing %q, got %v", tt.want, issues)
+		})
+	}
+}
+
+func TestLintChunkUnreachablePlatformsAndDuplicates(t *testing.T) {
+	data, err := fs.ReadFile(assets.GetAssetFS(), "chunks/combat_02.tmx")
+	if err != nil {
+		t.Fatalf("failed to read chunk: %v", err)
+	}
+	// Lift the two floating platforms well out of jump range of the floor
+	high := moveTileRows(t, string(data), 9, 3, 2)
+	loader := procgen.NewChunkLoaderWithFS(lintFS(t, map[string]string{
+		"a.tmx": string(data),
+		"b.tmx": high,
+	}))
+	issues, err := loader.LintChunks("chunks")
+	if err != nil {
+		t.Fatalf("LintChunks failed: %v", err)
+	}
+
+	var unreachable, duplicate int
+	for _, issue := range issues {
+		switch {
+		case issue.Path == "chunks/b.tmx" && strings.Contains(issue.Message, "can't be reached from any connection"):
+			unreachable++
+		case issue.Path == "chunks/b.tmx" && strings.Contains(issue.Message, `"combat_02" is already used by chunks/a.tmx`):
+			duplicate++
+		}
+	}
+	if unreachable != 2 || duplicate != 1 {
+		t.Errorf("expected 2 unreachable platforms and a duplicate ID in b.tmx, got %v", issues)
+	}
+}
@@ -0,0 +1,618 @@
+package procgen
+
+import (
+	"math"
+	"slices"
+	"sort"
+
+	"github.com/automoto/doomerang/components"
+	"github.com/automoto/doomerang/config"
+)
+
+// The validator decides what the player can reach by moving a copy of them
+// through the level frame by frame: the same input handling, physics and
+// collision steps the game runs, against the same tiles, ramps and dead zones.
+// The game's systems need an ECS world and import this package, so this file
+// mirrors the movement parts of systems/player.go, systems/physics.go and
+// systems/collision.go. Keep it in step with them.
+
+// simCellSize is the resolv cell size the roguelite scene builds its space with.
+// Collision checks find everything in the cells the player covers, so the cell
+// size shapes how the player collides with tiles that aren't aligned to it.
+const simCellSize = 16
+
+// simSlopeOffset keeps the player just above a ramp, like slopeSurfaceOffset
+const simSlopeOffset = 0.1
+
+// simMaxFrames is how long a single move is followed before giving up on it
+const simMaxFrames = 300
+
+// simKind is the resolv tag of a collision object
+type simKind uint8
+
+const (
+	simSolid simKind = 1 << iota
+	simRamp
+	simDeadZone
+)
+
+type simRect struct {
+	x, y, w, h float64
+}
+
+func (r simRect) overlaps(o simRect) bool {
+	return r.x < o.x+o.w && o.x < r.x+r.w && r.y < o.y+o.h && o.y < r.y+r.h
+}
+
+type simObject struct {
+	simRect
+	kind  simKind
+	slope string // SlopeType of a ramp
+	span  int    // Span on top of a tile, -1 if the player can't stand there
+}
+
+// simSpan is a run of tiles the player can walk along, crouching where the
+// ceiling is low
+type simSpan struct {
+	x, y, w float64
+	tiles   []int32
+}
+
+// simSpace is the level as the game's resolv space sees it: objects listed in
+// every cell they overlap, in the order the level adds them
+type simSpace struct {
+	cols, rows int
+	cells      [][]int32
+	objects    []simObject
+	fires      []simRect // Fire hitboxes the player shouldn't land in
+	height     float64   // Falling below this is death
+	spans      []simSpan
+	tileAt     map[[2]float64]int32
+	found      []int32 // Reused by check
+}
+
+// newSimSpace builds the collision space for a generated level, including
+// every hazard slot since the validator runs before hazards are rolled
+func newSimSpace(result *GenerationResult) *simSpace {
+	width, height := float64(result.TotalWidth), float64(result.TotalHeight)
+	for _, pc := range result.PlacedChunks {
+		width = math.Max(width, pc.OffsetX+float64(pc.Chunk.Width))
+		height = math.Max(height, pc.OffsetY+float64(pc.Chunk.Height))
+	}
+
+	s := &simSpace{
+		cols:   int(width) / simCellSize,
+		rows:   int(height) / simCellSize,
+		height: height,
+		tileAt: map[[2]float64]int32{},
+	}
+	s.cells = make([][]int32, s.cols*s.rows)
+
+	// Tiles go in first, in the order the compiler merges them, then dead zones
+	for _, pc := range result.PlacedChunks {
+		for _, t := range pc.Chunk.SolidTiles {
+			kind := simSolid
+			if t.SlopeType != "" {
+				kind = simRamp
+			}
+			r := simRect{t.X + pc.OffsetX, t.Y + pc.OffsetY, t.Width, t.Height}
+			s.tileAt[[2]float64{r.x, r.y}] = s.add(simObject{simRect: r, kind: kind, slope: t.SlopeType})
+		}
+	}
+	for _, pc := range result.PlacedChunks {
+		for _, r := range chunkDeadZones(pc) {
+			s.add(simObject{simRect: r, kind: simDeadZone})
+		}
+		s.fires = append(s.fires, chunkFires(pc)...)
+	}
+
+	s.findSpans()
+	return s
+}
+
+// chunkDeadZones returns the world-space dead zones the compiler and the
+// hazard placer can give a placed chunk
+func chunkDeadZones(pc PlacedChunk) []simRect {
+	var zones []simRect
+	if pc.Chunk.TiledMap != nil {
+		for _, o := range objects(pc.Chunk.TiledMap, "DeadZones") {
+			zones = append(zones, simRect{o.X + pc.OffsetX, o.Y + pc.OffsetY, o.Width, o.Height})
+		}
+	}
+	for _, slot := range pc.Chunk.HazardSlots {
+		if slot.SlotType == "deadzone" {
+			zones = append(zones, simRect{slot.X + pc.OffsetX, slot.Y + pc.OffsetY, slot.Width, slot.Height})
+		}
+	}
+	return zones
+}
+
+// chunkFires returns the world-space hitboxes of the fires the compiler and the
+// hazard placer can give a placed chunk
+func chunkFires(pc PlacedChunk) []simRect {
+	var fires []simRect
+	if pc.Chunk.TiledMap != nil {
+		for _, o := range objects(pc.Chunk.TiledMap, "Obstacles") {
+			fireType := o.Class
+			if fireType == "" {
+				fireType = o.Type //nolint:staticcheck
+			}
+			if fireType != "fire_pulsing" && fireType != "fire_continuous" {
+				continue
+			}
+			direction := o.Properties.GetString("Direction")
+			if direction == "" {
+				direction = "right"
+			}
+			fires = append(fires, fireHitbox(o.X+pc.OffsetX, o.Y+pc.OffsetY, fireType, direction))
+		}
+	}
+	for _, slot := range pc.Chunk.HazardSlots {
+		if slot.SlotType == "fire_pulsing" || slot.SlotType == "fire_continuous" {
+			fires = append(fires, fireHitbox(slot.X+pc.OffsetX, slot.Y+pc.OffsetY, slot.SlotType, "up"))
+		}
+	}
+	return fires
+}
+
+// fireHitbox is the full-size hitbox factory.CreateFire gives a fire
+func fireHitbox(x, y float64, fireType, direction string) simRect {
+	fireCfg, ok := config.Fire.Types[fireType]
+	if !ok {
+		fireCfg = config.Fire.Types["fire_continuous"]
+	}
+	scale := fireCfg.HitboxScale
+	if scale == 0 {
+		scale = 1.0
+	}
+	w := float64(fireCfg.FrameWidth) * scale
+	h := float64(fireCfg.FrameHeight) * scale
+	if direction == "up" || direction == "down" {
+		w, h = h, w
+	}
+	cx, cy := components.FireSpriteCenter(x, y, float64(fireCfg.FrameWidth), direction)
+	return simRect{cx - w/2, cy - h/2, w, h}
+}
+
+// cellRange returns the cells r covers the way resolv's BoundsToSpace does
+func cellRange(r simRect) (cx, cy, ex, ey int) {
+	return int(math.Floor(r.x / simCellSize)), int(math.Floor(r.y / simCellSize)),
+		int(math.Floor((r.x + r.w - 1) / simCellSize)), int(math.Floor((r.y + r.h - 1) / simCellSize))
+}
+
+func (s *simSpace) add(o simObject) int32 {
+	i := int32(len(s.objects))
+	o.span = -1
+	s.objects = append(s.objects, o)
+	cx, cy, ex, ey := cellRange(o.simRect)
+	for y := max(cy, 0); y <= ey && y < s.rows; y++ {
+		for x := max(cx, 0); x <= ex && x < s.cols; x++ {
+			s.cells[y*s.cols+x] = append(s.cells[y*s.cols+x], i)
+		}
+	}
+	return i
+}
+
+// check returns the objects of the given kinds in the cells r would cover
+// after moving by dx, dy, in the order resolv's Object.Check finds them. The
+// result is only valid until the next check.
+func (s *simSpace) check(r simRect, dx, dy float64, kinds simKind) []int32 {
+	if dx < 0 {
+		dx = math.Min(dx, -1)
+	} else if dx > 0 {
+		dx = math.Max(dx, 1)
+	}
+	if dy < 0 {
+		dy = math.Min(dy, -1)
+	} else if dy > 0 {
+		dy = math.Max(dy, 1)
+	}
+	r.x += dx
+	r.y += dy
+
+	s.found = s.found[:0]
+	cx, cy, ex, ey := cellRange(r)
+	for y := max(cy, 0); y <= ey && y < s.rows; y++ {
+		for x := max(cx, 0); x <= ex && x < s.cols; x++ {
+			for _, i := range s.cells[y*s.cols+x] {
+				if s.objects[i].kind&kinds != 0 && !slices.Contains(s.found, i) {
+					s.found = append(s.found, i)
+				}
+			}
+		}
+	}
+	return s.found
+}
+
+// first returns the first object of kind among found, or -1
+func (s *simSpace) first(found []int32, kind simKind) int32 {
+	for _, i := range found {
+		if s.objects[i].kind == kind {
+			return i
+		}
+	}
+	return -1
+}
+
+// blocked reports whether r overlaps a tile or ramp
+func (s *simSpace) blocked(r simRect) bool {
+	for _, i := range s.check(r, 0, 0, simSolid|simRamp) {
+		if s.objects[i].overlaps(r) {
+			return true
+		}
+	}
+	return false
+}
+
+// findSpans groups the tiles the player can stand on, crouched at least and
+// clear of dead zones, into runs along each row
+func (s *simSpace) findSpans() {
+	crouchH := config.Player.SlideHitboxHeight
+	var standable []int32
+	for i, o := range s.objects {
+		if o.kind == simDeadZone {
+			continue
+		}
+		box := simRect{o.x, o.y - crouchH, o.w, crouchH}
+		if !s.blocked(box) && len(s.check(box, 0, 0, simDeadZone)) == 0 {
+			standable = append(standable, int32(i))
+		}
+	}
+	sort.Slice(standable, func(a, b int) bool {
+		oa, ob := s.objects[standable[a]], s.objects[standable[b]]
+		if oa.y != ob.y {
+			return oa.y < ob.y
+		}
+		return oa.x < ob.x
+	})
+
+	for _, i := range standable {
+		o := &s.objects[i]
+		if n := len(s.spans); n > 0 {
+			last := &s.spans[n-1]
+			if last.y == o.y && last.x+last.w == o.x {
+				last.w += o.w
+				last.tiles = append(last.tiles, i)
+				o.span = n - 1
+				continue
+			}
+		}
+		o.span = len(s.spans)
+		s.spans = append(s.spans, simSpan{x: o.x, y: o.y, w: o.w, tiles: []int32{i}})
+	}
+}
+
+// platformSpans returns the spans covering a platform's surface tiles
+func (s *simSpace) platformSpans(p Platform) []int {
+	var spans []int
+	for x := p.X; x < p.X+p.Width; x += simCellSize {
+		if i, ok := s.tileAt[[2]float64{x, p.Y}]; ok {
+			if span := s.objects[i].span; span >= 0 && !slices.Contains(spans, span) {
+				spans = append(spans, span)
+			}
+		}
+	}
+	return spans
+}
+
+// spanDistance is how far apart two spans are, edge to edge across and top to
+// top up and down
+func (s *simSpace) spanDistance(a, b int) float64 {
+	sa, sb := s.spans[a], s.spans[b]
+	dx := max(sa.x-(sb.x+sb.w), sb.x-(sa.x+sa.w), 0)
+	return math.Hypot(dx, sa.y-sb.y)
+}
+
+// simPlayer is the player state movement depends on, carried between frames
+type simPlayer struct {
+	simRect
+	vx, vy      float64
+	facing      float64 // Player.Direction.X
+	onGround    int32   // Object stood on, -1 in the air
+	wallSliding int32   // Wall being slid down, -1 if none
+	attack      int     // Frames until a wall kick's attack state ends
+	crouched    bool
+	sliding     bool
+	slideTimer  int
+}
+
+// simInput is the player's input for one frame
+type simInput struct {
+	move   float64 // -1 left, 1 right, 0 neither
+	jump   bool    // Jump pressed this frame
+	kick   bool    // Attack pressed this frame
+	crouch bool    // Crouch held
+}
+
+// wallKickFrames is how long a wall kick's attack state lasts: the frames of
+// its animation, counted from the kick until the state machine sees it loop
+func wallKickFrames() int {
+	anim := config.CharacterAnimations["player"][config.Kick02]
+	step := max(anim.Step, 1)
+	return ((anim.Last-anim.First)/step+1)*(int(anim.Speed)+1) + 1
+}
+
+// step advances the player by one frame, in the order of the gameplay
+// systems. It returns false if the player died.
+func (s *simSpace) step(p *simPlayer, in simInput) bool {
+	// UpdatePlayer: a wall kick takes priority over jumping, and an attack
+	// locks out both until it ends
+	if p.wallSliding >= 0 && in.kick && p.attack == 0 {
+		wall := s.objects[p.wallSliding]
+		p.vy = -config.Player.JumpSpeed
+		if wall.x+wall.w/2 > p.x+p.w/2 {
+			p.vx, p.facing = -config.Player.MaxSpeed, config.DirectionLeft
+		} else {
+			p.vx, p.facing = config.Player.MaxSpeed, config.DirectionRight
+		}
+		p.wallSliding = -1
+		p.attack = wallKickFrames()
+	} else if in.jump && p.attack == 0 {
+		if p.onGround >= 0 {
+			p.vy = -config.Player.JumpSpeed
+		} else if p.wallSliding >= 0 {
+			p.vy = -config.Player.JumpSpeed
+			if s.objects[p.wallSliding].x > p.x {
+				p.vx = -config.Player.MaxSpeed
+			} else {
+				p.vx = config.Player.MaxSpeed
+			}
+			p.wallSliding = -1
+		}
+	}
+	if p.wallSliding < 0 && !p.crouched && !p.sliding && in.move != 0 {
+		accel := config.Player.Acceleration
+		if p.attack > 0 {
+			accel = config.Player.AttackAccel
+		}
+		p.vx += accel * in.move
+		p.facing = in.move
+	}
+
+	// The state machine: attacks time out, crouching walks slowly and a slide
+	// stands up once it slows down or crouch is let go
+	if p.attack > 0 {
+		p.attack--
+	}
+	switch {
+	case p.crouched:
+		if in.move != 0 {
+			p.vx = config.Player.CrouchWalkSpeed * in.move
+			p.facing = in.move
+		} else {
+			simFriction(p, config.Player.Friction)
+		}
+		if !in.crouch && s.standUp(p) {
+			p.crouched = false
+		}
+	case p.sliding:
+		p.slideTimer++
+		stopped := math.Abs(p.vx) < config.Player.SlideMinSpeed
+		if stopped || (!in.crouch && p.slideTimer > config.Player.SlideRecoveryFrames) {
+			p.sliding = false
+			if !s.standUp(p) {
+				p.vx = 0
+				p.crouched = true
+			} else if stopped && in.crouch {
+				p.crouch()
+			}
+		}
+	}
+
+	// UpdatePhysics
+	friction := config.Player.Friction
+	if p.sliding {
+		friction = config.Player.SlideFriction
+	}
+	if p.attack > 0 {
+		friction = config.Player.AttackFriction
+	}
+	simFriction(p, friction)
+	p.vx = math.Max(math.Min(p.vx, config.Player.MaxSpeed), -config.Player.MaxSpeed)
+	p.vy += config.Player.Gravity
+	if p.wallSliding >= 0 && p.vy > config.Physics.WallSlideSpeed {
+		p.vy = config.Physics.WallSlideSpeed
+	}
+
+	// UpdateCollisions
+	s.moveX(p)
+	s.moveY(p)
+	if p.wallSliding >= 0 && len(s.check(p.simRect, p.facing, 0, simSolid)) == 0 {
+		p.wallSliding = -1
+	}
+	return len(s.check(p.simRect, 0, 0, simDeadZone)) == 0 && p.y <= s.height
+}
+
+func simFriction(p *simPlayer, friction float64) {
+	switch {
+	case p.vx > friction:
+		p.vx -= friction
+	case p.vx < -friction:
+		p.vx += friction
+	default:
+		p.vx = 0
+	}
+}
+
+// crouch shrinks the player to the crouching hitbox, keeping their feet in place
+func (p *simPlayer) crouch() {
+	p.crouched = true
+	if diff := p.h - config.Player.SlideHitboxHeight; diff > 0 {
+		p.h -= diff
+		p.y += diff
+	}
+}
+
+// standUp mirrors tryStandUp
+func (s *simSpace) standUp(p *simPlayer) bool {
+	height := float64(config.Player.CollisionHeight)
+	if p.h >= height {
+		return true
+	}
+	diff := height - p.h
+	if len(s.check(p.simRect, 0, -diff, simSolid)) == 0 {
+		p.h, p.y = height, p.y-diff
+		return true
+	}
+	const pushDistance = 12.0
+	for _, dir := range []float64{p.facing, -p.facing} {
+		for offset := 1.0; offset <= pushDistance; offset++ {
+			if len(s.check(p.simRect, offset*dir, -diff, simSolid)) == 0 {
+				p.x += offset * dir
+				p.h, p.y = height, p.y-diff
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// moveX mirrors resolveObjectHorizontalCollision
+func (s *simSpace) moveX(p *simPlayer) {
+	dx := p.vx
+	if dx == 0 {
+		return
+	}
+	for _, dy := range []float64{0, 1} {
+		if ramp := s.first(s.check(p.simRect, dx, dy, simRamp), simRamp); ramp >= 0 {
+			p.x += dx
+			s.snapToSlope(p, ramp)
+			return
+		}
+	}
+
+	found := s.check(p.simRect, dx, 0, simSolid)
+	if len(found) == 0 {
+		p.x += dx
+		return
+	}
+	for _, i := range found {
+		if o := s.objects[i]; p.y+p.h > o.y && p.y < o.y+o.h {
+			p.vx = 0
+			if p.onGround < 0 {
+				p.wallSliding = found[0]
+			}
+			return
+		}
+	}
+	p.x += dx
+}
+
+// moveY mirrors resolveObjectVerticalCollision
+func (s *simSpace) moveY(p *simPlayer) {
+	p.onGround = -1
+	dy := math.Max(math.Min(p.vy, 16), -16)
+	distance := dy
+	if dy >= 0 {
+		distance++
+	}
+	found := s.check(p.simRect, 0, distance, simSolid|simRamp)
+	if len(found) == 0 {
+		p.y += dy
+		return
+	}
+
+	if dy < 0 {
+		if solid := s.first(found, simSolid); solid >= 0 {
+			p.vy = 0
+			p.y = s.objects[solid].y + s.objects[solid].h
+		} else {
+			p.y += p.vy
+		}
+		return
+	}
+
+	if ramp := s.first(found, simRamp); ramp >= 0 {
+		surface := s.slopeSurfaceY(p, ramp)
+		if p.y+p.h+dy >= surface {
+			p.onGround = ramp
+			p.vy = 0
+			p.y = surface - p.h + simSlopeOffset
+			return
+		}
+	}
+	if solid := s.first(found, simSolid); solid >= 0 && p.vy >= 0 {
+		p.onGround = solid
+		p.vy = 0
+		p.wallSliding = -1
+		p.y = s.objects[solid].y - p.h
+		return
+	}
+	p.y += dy
+}
+
+func (s *simSpace) snapToSlope(p *simPlayer, ramp int32) {
+	p.y = s.slopeSurfaceY(p, ramp) - p.h + simSlopeOffset
+	p.onGround = ramp
+	p.vy = 0
+}
+
+// slopeSurfaceY mirrors getSlopeSurfaceY
+func (s *simSpace) slopeSurfaceY(p *simPlayer, ramp int32) float64 {
+	r := s.objects[ramp]
+	slope := math.Max(math.Min(p.x+p.w/2-r.x, r.w), 0) / r.w
+	switch r.slope {
+	case "45_up_right":
+		return r.y + r.h*(1-slope)
+	case "45_up_left":
+		return r.y + r.h*slope
+	}
+	return r.y
+}
+
+// groundSpan returns the span the player is standing on, or -1
+func (s *simSpace) groundSpan(p *simPlayer) int {
+	if p.onGround < 0 {
+		return -1
+	}
+	if span := s.objects[p.onGround].span; span >= 0 {
+		return span
+	}
+	// Standing across the edge of a tile the player can't stand on by itself
+	for _, i := range s.check(p.simRect, 0, 1, simSolid|simRamp) {
+		if span := s.objects[i].span; span >= 0 && math.Abs(s.objects[i].y-(p.y+p.h)) < 1 {
+			return span
+		}
+	}
+	return -1
+}
+
+// inFire reports whether the player overlaps a fire
+func (s *simSpace) inFire(p *simPlayer) bool {
+	for _, f := range s.fires {
+		if f.overlaps(p.simRect) {
+			return true
+		}
+	}
+	return false
+}
+
+// MoveInput is the player's input for one frame of TraceMove
+type MoveInput struct {
+	Move float64 // -1 left, 1 right, 0 neither
+	Jump bool    // Jump pressed this frame
+	Kick bool    // Attack pressed this frame
+}
+
+// TraceMove moves a player spawned at (x, y) through a generated layout the
+// way the validator does, one frame per input, and returns where the player is
+// after each frame. The validator starts crouches and slides itself rather
+// than from input, so there's no crouching here. It stops early if the player dies. Tests use it to check
+// the validator against the gameplay systems it mirrors.
+func TraceMove(result *GenerationResult, x, y float64, inputs []MoveInput) [][2]float64 {
+	s := newSimSpace(result)
+	p := simPlayer{
+		simRect:     simRect{x, y, float64(config.Player.CollisionWidth), float64(config.Player.CollisionHeight)},
+		facing:      config.DirectionRight,
+		onGround:    -1,
+		wallSliding: -1,
+	}
+	var path [][2]float64
+	for _, in := range inputs {
+		if !s.step(&p, simInput{move: in.Move, jump: in.Jump, kick: in.Kick}) {
+			break
+		}
+		path = append(path, [2]float64{p.x, p.y})
+	}
+	return path
+}
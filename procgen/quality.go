@@ -0,0 +1,276 @@
+package procgen
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Distribution summarizes integer samples such as enemies per room.
+type Distribution struct {
+	Min     int     `json:"min"`
+	Max     int     `json:"max"`
+	Mean    float64 `json:"mean"`
+	Samples int     `json:"samples"`
+
+	total int
+}
+
+func (d *Distribution) add(v int) {
+	if d.Samples == 0 || v < d.Min {
+		d.Min = v
+	}
+	if d.Samples == 0 || v > d.Max {
+		d.Max = v
+	}
+	d.Samples++
+	d.total += v
+	d.Mean = float64(d.total) / float64(d.Samples)
+}
+
+// QualityReport aggregates generation statistics over many seeds.
+type QualityReport struct {
+	Seeds     int   `json:"seeds"`
+	FirstSeed int64 `json:"firstSeed"`
+
+	// Seeds GenerateRun returned an error for. They count towards Seeds and
+	// every rate, but add nothing else to the report.
+	GenerationFailures    int     `json:"generationFailures"`
+	GenerationFailureRate float64 `json:"generationFailureRate"`
+	FailedSeeds           []int64 `json:"failedSeeds"`
+
+	// Solvability and remediation
+	Unsolvable      int     `json:"unsolvable"`
+	UnsolvableRate  float64 `json:"unsolvableRate"`
+	UnsolvableSeeds []int64 `json:"unsolvableSeeds"`
+	Retried         int     `json:"retried"` // Runs that needed more than one attempt
+	RetryRate       float64 `json:"retryRate"`
+	MeanAttempts    float64 `json:"meanAttempts"`
+	LastResort      int     `json:"lastResort"`
+	// Runs where an attempt couldn't place the full concept graph
+	GraphFallbacks    int     `json:"graphFallbacks"`
+	GraphFallbackRate float64 `json:"graphFallbackRate"`
+
+	// Chunk selection
+	Rooms         int                `json:"rooms"`
+	OptionalRooms int                `json:"optionalRooms"` // Side path and bonus rooms placed
+	ChunkUsage    map[string]int     `json:"chunkUsage"`    // Placements per chunk ID
+	ChunkShare    map[string]float64 `json:"chunkShare"`    // Placements per chunk ID / all placements
+	ChunkRunShare map[string]float64 `json:"chunkRunShare"` // Fraction of runs that contain the chunk
+
+	// Concept graph
+	NodeTypes  map[string]int `json:"nodeTypes"`
+	Difficulty map[int]int    `json:"difficulty"`
+
+	// Placement density, per placed room
+	EnemiesPerRoom       Distribution            `json:"enemiesPerRoom"`
+	EnemiesPerRoomByType map[string]Distribution `json:"enemiesPerRoomByType"` // Keyed by node type
+	EnemyTypes           map[string]int          `json:"enemyTypes"`
+	HazardsPerRoom       Distribution            `json:"hazardsPerRoom"`
+	HazardsPer1000px     float64                 `json:"hazardsPer1000px"`
+
+	attempts    int
+	hazards     int
+	totalWidth  float64
+	runsByChunk map[string]int
+}
+
+// NewQualityReport creates an empty report; add runs to it with AddRun.
+func NewQualityReport() *QualityReport {
+	return &QualityReport{
+		FailedSeeds:          []int64{},
+		UnsolvableSeeds:      []int64{},
+		ChunkUsage:           make(map[string]int),
+		ChunkShare:           make(map[string]float64),
+		ChunkRunShare:        make(map[string]float64),
+		NodeTypes:            make(map[string]int),
+		Difficulty:           make(map[int]int),
+		EnemiesPerRoomByType: make(map[string]Distribution),
+		EnemyTypes:           make(map[string]int),
+		runsByChunk:          make(map[string]int),
+	}
+}
+
+// AnalyzeRuns generates count consecutive seeds starting at opts.Seed and
+// aggregates their statistics. Runs are generated headless. Seeds that fail
+// to generate are recorded in the report, and the rest still analyzed.
+func AnalyzeRuns(opts RunOptions, count int) *QualityReport {
+	report := NewQualityReport()
+	opts.Headless = true
+	firstSeed := opts.Seed
+
+	for i := 0; i < count; i++ {
+		opts.Seed = firstSeed + int64(i)
+		run, err := GenerateRun(opts)
+		if err != nil {
+			report.AddFailure(opts.Seed)
+			continue
+		}
+		report.AddRun(opts.Seed, run)
+	}
+	return report
+}
+
+// AddFailure records a seed that failed to generate.
+func (r *QualityReport) AddFailure(seed int64) {
+	r.addSeed(seed)
+	r.GenerationFailures++
+	r.FailedSeeds = append(r.FailedSeeds, seed)
+	r.updateRates()
+}
+
+// AddRun folds one generated run into the report.
+func (r *QualityReport) AddRun(seed int64, run *Run) {
+	r.addSeed(seed)
+
+	if !run.Solvable {
+		r.Unsolvable++
+		r.UnsolvableSeeds = append(r.UnsolvableSeeds, seed)
+	}
+	r.attempts += run.Remediation.Attempts
+	if run.Remediation.Attempts > 1 || run.Remediation.LastResort {
+		r.Retried++
+	}
+	if run.Remediation.LastResort {
+		r.LastResort++
+	}
+	if run.Remediation.GraphFallbacks > 0 {
+		r.GraphFallbacks++
+	}
+
+	for _, node := range run.Graph.Nodes {
+		r.NodeTypes[string(node.Type)]++
+		r.Difficulty[node.Difficulty]++
+	}
+	for _, b := range run.Graph.Branches {
+		for _, node := range b.Nodes {
+			r.NodeTypes[string(node.Type)]++
+			r.Difficulty[node.Difficulty]++
+		}
+	}
+
+	placed := run.Result.PlacedChunks
+	seen := make(map[string]bool)
+	for _, pc := range placed {
+		r.Rooms++
+		if pc.Optional() {
+			r.OptionalRooms++
+		}
+		r.ChunkUsage[pc.Chunk.ID]++
+		if !seen[pc.Chunk.ID] {
+			seen[pc.Chunk.ID] = true
+			r.runsByChunk[pc.Chunk.ID]++
+		}
+		r.totalWidth += float64(pc.Chunk.Width)
+	}
+
+	// Bucket spawns and hazards into the rooms containing them
+	enemies := make([]int, len(placed))
+	hazards := make([]int, len(placed))
+	for _, e := range run.Level.EnemySpawns {
+		enemies[run.Result.RoomAt(e.X, e.Y)]++
+		r.EnemyTypes[e.EnemyType]++
+	}
+	for _, dz := range run.Level.DeadZones {
+		hazards[run.Result.RoomAt(dz.X, dz.Y)]++
+	}
+	for _, f := range run.Level.Fires {
+		hazards[run.Result.RoomAt(f.X, f.Y)]++
+	}
+
+	for i := range placed {
+		r.EnemiesPerRoom.add(enemies[i])
+		r.HazardsPerRoom.add(hazards[i])
+		r.hazards += hazards[i]
+
+		// Rooms only map to graph nodes when the graph-driven layout was used
+		nodeType := "unmatched"
+		if placed[i].Node != nil {
+			nodeType = string(placed[i].Node.Type)
+		}
+		d := r.EnemiesPerRoomByType[nodeType]
+		d.add(enemies[i])
+		r.EnemiesPerRoomByType[nodeType] = d
+	}
+
+	r.updateRates()
+}
+
+func (r *QualityReport) addSeed(seed int64) {
+	if r.Seeds == 0 {
+		r.FirstSeed = seed
+	}
+	r.Seeds++
+}
+
+func (r *QualityReport) updateRates() {
+	seeds := float64(r.Seeds)
+	r.GenerationFailureRate = float64(r.GenerationFailures) / seeds
+	r.UnsolvableRate = float64(r.Unsolvable) / seeds
+	r.RetryRate = float64(r.Retried) / seeds
+	r.GraphFallbackRate = float64(r.GraphFallbacks) / seeds
+	r.MeanAttempts = float64(r.attempts) / seeds
+
+	for id, n := range r.ChunkUsage {
+		r.ChunkShare[id] = float64(n) / float64(r.Rooms)
+	}
+	for id, n := range r.runsByChunk {
+		r.ChunkRunShare[id] = float64(n) / seeds
+	}
+	if r.totalWidth > 0 {
+		r.HazardsPer1000px = float64(r.hazards) / r.totalWidth * 1000
+	}
+}
+
+// QualityThresholds are limits a QualityReport must stay within.
+// Nil fields are not checked.
+type QualityThresholds struct {
+	MaxGenerationFailureRate *float64 `json:"maxGenerationFailureRate"`
+	MaxUnsolvableRate        *float64 `json:"maxUnsolvableRate"`
+	MaxRetryRate             *float64 `json:"maxRetryRate"`
+	MaxLastResortRate        *float64 `json:"maxLastResortRate"`
+	MaxGraphFallbackRate     *float64 `json:"maxGraphFallbackRate"`
+	MaxChunkShare            *float64 `json:"maxChunkShare"`
+	MinEnemiesPerRoomMean    *float64 `json:"minEnemiesPerRoomMean"`
+	MaxEnemiesPerRoomMean    *float64 `json:"maxEnemiesPerRoomMean"`
+	MaxEnemiesPerRoom        *int     `json:"maxEnemiesPerRoom"`
+	MaxHazardsPer1000px      *float64 `json:"maxHazardsPer1000px"`
+}
+
+// Check returns a description of every threshold the report violates.
+func (r *QualityReport) Check(t QualityThresholds) []string {
+	var violations []string
+	overMax := func(name string, value float64, limit *float64) {
+		if limit != nil && value > *limit {
+			violations = append(violations, fmt.Sprintf("%s %.4f exceeds %.4f", name, value, *limit))
+		}
+	}
+
+	overMax("generation failure rate", r.GenerationFailureRate, t.MaxGenerationFailureRate)
+	overMax("unsolvable rate", r.UnsolvableRate, t.MaxUnsolvableRate)
+	overMax("retry rate", r.RetryRate, t.MaxRetryRate)
+	overMax("graph fallback rate", r.GraphFallbackRate, t.MaxGraphFallbackRate)
+	if r.Seeds > 0 {
+		overMax("last resort rate", float64(r.LastResort)/float64(r.Seeds), t.MaxLastResortRate)
+	}
+
+	// Report chunks in a stable order
+	ids := make([]string, 0, len(r.ChunkShare))
+	for id := range r.ChunkShare {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		overMax("chunk "+id+" share", r.ChunkShare[id], t.MaxChunkShare)
+	}
+
+	overMax("mean enemies per room", r.EnemiesPerRoom.Mean, t.MaxEnemiesPerRoomMean)
+	if t.MinEnemiesPerRoomMean != nil && r.EnemiesPerRoom.Mean < *t.MinEnemiesPerRoomMean {
+		violations = append(violations, fmt.Sprintf("mean enemies per room %.4f is below %.4f", r.EnemiesPerRoom.Mean, *t.MinEnemiesPerRoomMean))
+	}
+	if t.MaxEnemiesPerRoom != nil && r.EnemiesPerRoom.Max > *t.MaxEnemiesPerRoom {
+		violations = append(violations, fmt.Sprintf("max enemies per room %d exceeds %d", r.EnemiesPerRoom.Max, *t.MaxEnemiesPerRoom))
+	}
+	overMax("hazards per 1000px", r.HazardsPer1000px, t.MaxHazardsPer1000px)
+
+	return violations
+}
@@ -0,0 +1,91 @@
+package procgen
+
+import (
+	"testing"
+)
+
+func TestAnalyzeRuns(t *testing.T) {
+	report := AnalyzeRuns(RunOptions{Seed: 100}, 5)
+	if report.GenerationFailures != 0 {
+		t.Fatalf("expected every seed to generate, failed: %v", report.FailedSeeds)
+	}
+
+	if report.Seeds != 5 || report.FirstSeed != 100 {
+		t.Errorf("expected 5 seeds from 100, got %d from %d", report.Seeds, report.FirstSeed)
+	}
+
+	placements := 0
+	for _, n := range report.ChunkUsage {
+		placements += n
+	}
+	if placements != report.Rooms {
+		t.Errorf("chunk usage sums to %d, expected %d rooms", placements, report.Rooms)
+	}
+	if report.EnemiesPerRoom.Samples != report.Rooms || report.HazardsPerRoom.Samples != report.Rooms {
+		t.Errorf("expected one enemy and hazard sample per room")
+	}
+	if report.NodeTypes[string(NodeStart)] != 5 || report.NodeTypes[string(NodeExit)] != 5 {
+		t.Errorf("expected one start and exit node per run, got %v", report.NodeTypes)
+	}
+	if report.MeanAttempts < 1 {
+		t.Errorf("expected at least one generation attempt per run, got %v", report.MeanAttempts)
+	}
+}
+
+func TestQualityReportCountsFailures(t *testing.T) {
+	report := NewQualityReport()
+	report.AddFailure(7)
+	run, err := GenerateRun(RunOptions{Seed: 8, Headless: true})
+	if err != nil {
+		t.Fatalf("GenerateRun failed: %v", err)
+	}
+	report.AddRun(8, run)
+
+	if report.Seeds != 2 || report.FirstSeed != 7 {
+		t.Errorf("expected 2 seeds from 7, got %d from %d", report.Seeds, report.FirstSeed)
+	}
+	if report.GenerationFailures != 1 || report.GenerationFailureRate != 0.5 || len(report.FailedSeeds) != 1 || report.FailedSeeds[0] != 7 {
+		t.Errorf("expected seed 7 recorded as half the seeds failing, got %d (%v) %v",
+			report.GenerationFailures, report.GenerationFailureRate, report.FailedSeeds)
+	}
+	if report.MeanAttempts > float64(run.Remediation.Attempts)/2 {
+		t.Errorf("expected the failed seed in the mean attempts, got %v", report.MeanAttempts)
+	}
+
+	maxFailures := 0.0
+	if violations := report.Check(QualityThresholds{MaxGenerationFailureRate: &maxFailures}); len(violations) != 1 {
+		t.Errorf("expected the failure rate to be a violation, got %v", violations)
+	}
+}
+
+func TestQualityReportCheck(t *testing.T) {
+	report := NewQualityReport()
+	report.Seeds = 100
+	report.UnsolvableRate = 0.05
+	report.GraphFallbackRate = 0.02
+	report.ChunkShare = map[string]float64{"combat_01": 0.4, "combat_02": 0.1}
+	report.EnemiesPerRoom = Distribution{Min: 0, Max: 9, Mean: 2}
+
+	maxUnsolvable := 0.01
+	maxShare := 0.25
+	maxEnemies := 6
+	minMean := 1.0
+	maxFallbacks := 0.01
+	violations := report.Check(QualityThresholds{
+		MaxUnsolvableRate:     &maxUnsolvable,
+		MaxGraphFallbackRate:  &maxFallbacks,
+		MaxChunkShare:         &maxShare,
+		MaxEnemiesPerRoom:     &maxEnemies,
+		MinEnemiesPerRoomMean: &minMean,
+	})
+
+	// Unsolvable rate, graph fallbacks, combat_01 share and max enemies fail;
+	// the mean is within limits
+	if len(violations) != 4 {
+		t.Errorf("expected 4 violations, got %d: %v", len(violations), violations)
+	}
+
+	if violations := report.Check(QualityThresholds{}); len(violations) != 0 {
+		t.Errorf("expected no violations without thresholds, got %v", violations)
+	}
+}
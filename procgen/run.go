@@ -0,0 +1,302 @@
+package procgen
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+
+	"github.com/automoto/doomerang/assets"
+	"github.com/automoto/doomerang/config"
+)
+
+// RunOptions selects what GenerateRun builds. Zero values fall back to the
+// same choices the roguelite scene makes.
+type RunOptions struct {
+	Seed   int64
+	Length int    // Middle nodes in the concept graph; 0 uses config.Procgen.DefaultRunLength
+	Biome  string // Biome for the whole run; empty picks one from the seed
+	// Headless skips decoration and background rendering, leaving Level.Background nil.
+	Headless bool
+}
+
+// Run is a fully generated roguelite run.
+type Run struct {
+	Options     RunOptions // What the run was generated from, with the length and biome resolved
+	Level       *assets.Level
+	Result      *GenerationResult
+	Graph       *ConceptGraph
+	Biome       string
+	Solvable    bool             // Whether the exit can be reached in the final chunk layout
+	Remediation RemediationStats // Retries needed to reach a solvable layout
+}
+
+// GenerateRunLevel builds the complete roguelite level for a seed: concept graph,
+// validated chunk layout, decoration, enemies, hazards and checkpoints.
+// The same seed always produces the same level.
+func GenerateRunLevel(seed int64) (*assets.Level, *GenerationResult, error) {
+	run, err := GenerateRun(RunOptions{Seed: seed})
+	if err != nil {
+		return nil, nil, err
+	}
+	return run.Level, run.Result, nil
+}
+
+// GenerateRun runs the full generation pipeline for opts.
+// The same options always produce the same run.
+func GenerateRun(opts RunOptions) (*Run, error) {
+	loader := NewChunkLoader()
+	chunks, err := loader.LoadAllChunks("chunks")
+	if err != nil {
+		return nil, err
+	}
+
+	seed := opts.Seed
+	rng := rand.New(rand.NewSource(seed))
+
+	// Pick a single biome for the entire run based on seed. The roll is made even
+	// when a biome is forced so the rest of the run matches the unforced seed.
+	biome := "cyberpunk"
+	if len(config.Procgen.Biomes) > 0 {
+		biome = config.Procgen.Biomes[rng.Intn(len(config.Procgen.Biomes))]
+	}
+	if opts.Biome != "" {
+		biome = opts.Biome
+	}
+
+	length := opts.Length
+	if length <= 0 {
+		length = config.Procgen.DefaultRunLength
+	}
+
+	// Generate concept graph with pacing rules
+	graph := GenerateGraph(rng, length, []string{biome})
+	ValidateGraph(graph)
+
+	// Generate chunks with solvability validation (retries up to 5 times)
+	generator := NewChunkGenerator(seed)
+	result, remediation, err := ValidateAndRemediateWithStats(generator, chunks, graph, 5)
+	if err != nil {
+		return nil, err
+	}
+	// Enemies, hazards, checkpoints and rewards all follow the graph nodes
+	if result.PlacedChunks[0].Node == nil {
+		return nil, fmt.Errorf("seed %d: no chunk layout follows the concept graph", seed)
+	}
+
+	// Compile base level, with decorative variation (background image + color tint)
+	// derived from seed + biome unless running headless
+	compiler := NewCompiler()
+	var decoration *DecorationOptions
+	if opts.Headless {
+		compiler.SkipBackground = true
+	} else {
+		d := DeriveDecoration(seed, biome)
+		decoration = &d
+		defer func() {
+			if decoration.BackgroundImage != nil {
+				decoration.BackgroundImage.Deallocate()
+			}
+		}()
+	}
+	level, err := compiler.Compile(result, decoration)
+	if err != nil {
+		return nil, err
+	}
+
+	// Dynamic enemy placement
+	enemyPlacer := NewEnemyPlacer(rng)
+	for _, pc := range result.PlacedChunks {
+		if node := pc.Node; node != nil && (node.Type == NodeCombat || node.Type == NodeArena) {
+			spawns, patrolPaths := enemyPlacer.PlaceEnemies(pc, node.Difficulty)
+			level.EnemySpawns = append(level.EnemySpawns, spawns...)
+			for name, path := range patrolPaths {
+				level.PatrolPaths[name] = path
+			}
+		}
+	}
+
+	// Dynamic hazard placement
+	hazardPlacer := NewHazardPlacer(rng)
+	for _, pc := range result.PlacedChunks {
+		diff := 1
+		if node := pc.Node; node != nil {
+			diff = node.Difficulty
+		}
+		deadZones, fires := hazardPlacer.PlaceHazards(pc, diff)
+		level.DeadZones = append(level.DeadZones, deadZones...)
+		level.Fires = append(level.Fires, fires...)
+	}
+
+	// Auto-place checkpoints at break rooms
+	checkpointID := 1.0
+	for _, pc := range result.PlacedChunks {
+		if node := pc.Node; node != nil && !node.Optional && node.Type == NodeBreakRoom {
+			level.Checkpoints = append(level.Checkpoints, assets.CheckpointSpawn{
+				X:            pc.OffsetX + float64(pc.Chunk.Width)/2,
+				Y:            pc.OffsetY + float64(pc.Chunk.Height) - 80,
+				Width:        32,
+				Height:       48,
+				CheckpointID: checkpointID,
+			})
+			checkpointID++
+		}
+	}
+
+	// Rewards for detouring into optional rooms. The types are rolled last so
+	// they don't change anything else about the seed's level.
+	for _, pc := range result.PlacedChunks {
+		if node := pc.Node; node != nil && node.Reward {
+			level.Rewards = append(level.Rewards, assets.RewardSpawn{
+				X:      pc.OffsetX + float64(pc.Chunk.Width)/2 - 8,
+				Y:      pc.OffsetY + float64(pc.Chunk.Height) - 80,
+				Width:  16,
+				Height: 16,
+			})
+		}
+	}
+	for i := range level.Rewards {
+		if types := config.Procgen.RewardTypes; len(types) > 0 {
+			level.Rewards[i].RewardType = types[rng.Intn(len(types))]
+		}
+	}
+
+	return &Run{
+		Level:       level,
+		Result:      result,
+		Graph:       graph,
+		Options:     RunOptions{Seed: seed, Length: length, Biome: biome, Headless: opts.Headless},
+		Biome:       biome,
+		Solvable:    !remediation.LastResort || NewValidator().Solvable(result),
+		Remediation: remediation,
+	}, nil
+}
+
+// Validate reports which platforms of the final chunk layout can be reached.
+// It plays every reachable platform, so it costs several times what checking
+// Solvable did.
+func (r *Run) Validate() ValidationResult {
+	return NewValidator().Validate(r.Result)
+}
+
+// RoomNode returns the concept graph node placed chunk i was chosen for.
+func (r *Run) RoomNode(i int) *GraphNode {
+	return r.Result.PlacedChunks[i].Node
+}
+
+// RoomBoundary is the line the player crosses to leave a main path room
+type RoomBoundary struct {
+	Edge ConnectionEdge // EdgeRight, EdgeBottom or EdgeTop
+	Pos  float64        // world X for right exits, world Y for top/bottom exits
+}
+
+// RoomInfo labels a main path room
+type RoomInfo struct {
+	ChunkID  string
+	NodeType NodeType // "" if the chunk wasn't placed for a graph node
+}
+
+// RoomArea is the world-space area a placed chunk covers
+type RoomArea struct {
+	X, Y, Width, Height float64
+}
+
+// RoomBoundaries returns the exit line of each main path chunk, in order.
+// Run stats use these to count rooms cleared as the player leaves each room;
+// side paths end level with the room they bypass, so crossing the line after
+// a detour still counts the bypassed room.
+func (r *GenerationResult) RoomBoundaries() []RoomBoundary {
+	boundaries := make([]RoomBoundary, 0, len(r.PlacedChunks))
+	for _, pc := range r.PlacedChunks {
+		if pc.Optional() {
+			continue
+		}
+		switch pc.ExitEdge {
+		case EdgeBottom:
+			boundaries = append(boundaries, RoomBoundary{Edge: EdgeBottom, Pos: pc.OffsetY + float64(pc.Chunk.Height)})
+		case EdgeTop:
+			boundaries = append(boundaries, RoomBoundary{Edge: EdgeTop, Pos: pc.OffsetY})
+		default:
+			boundaries = append(boundaries, RoomBoundary{Edge: EdgeRight, Pos: pc.OffsetX + float64(pc.Chunk.Width)})
+		}
+	}
+	return boundaries
+}
+
+// MainPathRooms returns each main path room, in order, labelled with the
+// room's chunk ID and node type.
+func (r *GenerationResult) MainPathRooms() []RoomInfo {
+	rooms := make([]RoomInfo, 0, r.MainPathLength())
+	for _, pc := range r.PlacedChunks {
+		if pc.Optional() {
+			continue
+		}
+		room := RoomInfo{ChunkID: pc.Chunk.ID}
+		if pc.Node != nil {
+			room.NodeType = pc.Node.Type
+		}
+		rooms = append(rooms, room)
+	}
+	return rooms
+}
+
+// MainPathChunkIDs returns the chunk ID of each main path room, in order.
+func (r *GenerationResult) MainPathChunkIDs() []string {
+	ids := make([]string, 0, r.MainPathLength())
+	for _, pc := range r.PlacedChunks {
+		if !pc.Optional() {
+			ids = append(ids, pc.Chunk.ID)
+		}
+	}
+	return ids
+}
+
+// MainPathRoomAreas returns the area of each main path room, in order, for
+// finding which room the player is in.
+func (r *GenerationResult) MainPathRoomAreas() []RoomArea {
+	rooms := make([]RoomArea, 0, r.MainPathLength())
+	for _, pc := range r.PlacedChunks {
+		if !pc.Optional() {
+			rooms = append(rooms, pc.area())
+		}
+	}
+	return rooms
+}
+
+// OptionalRooms returns the area of each optional branch room, for tracking
+// which detours the player found.
+func (r *GenerationResult) OptionalRooms() []RoomArea {
+	var rooms []RoomArea
+	for _, pc := range r.PlacedChunks {
+		if pc.Optional() {
+			rooms = append(rooms, pc.area())
+		}
+	}
+	return rooms
+}
+
+// area returns the world-space area the placed chunk covers
+func (pc PlacedChunk) area() RoomArea {
+	return RoomArea{
+		X:      pc.OffsetX,
+		Y:      pc.OffsetY,
+		Width:  float64(pc.Chunk.Width),
+		Height: float64(pc.Chunk.Height),
+	}
+}
+
+// RoomAt returns the index of the placed chunk containing world point (x, y),
+// or the nearest chunk if the point is outside all of them.
+func (r *GenerationResult) RoomAt(x, y float64) int {
+	best, bestDist := 0, math.Inf(1)
+	for i, pc := range r.PlacedChunks {
+		dx := math.Max(0, math.Max(pc.OffsetX-x, x-(pc.OffsetX+float64(pc.Chunk.Width))))
+		dy := math.Max(0, math.Max(pc.OffsetY-y, y-(pc.OffsetY+float64(pc.Chunk.Height))))
+		if dist := dx*dx + dy*dy; dist < bestDist {
+			best, bestDist = i, dist
+		}
+		if bestDist == 0 {
+			break
+		}
+	}
+	return best
+}
@@ -0,0 +1,182 @@
+package procgen
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/automoto/doomerang/config"
+)
+
+func TestGenerateRunDeterministic(t *testing.T) {
+	opts := RunOptions{Seed: 42, Headless: true}
+
+	a, err := GenerateRun(opts)
+	if err != nil {
+		t.Fatalf("GenerateRun failed: %v", err)
+	}
+	b, err := GenerateRun(opts)
+	if err != nil {
+		t.Fatalf("GenerateRun failed: %v", err)
+	}
+
+	if len(a.Result.PlacedChunks) != len(b.Result.PlacedChunks) {
+		t.Fatalf("chunk count differs: %d vs %d", len(a.Result.PlacedChunks), len(b.Result.PlacedChunks))
+	}
+	for i := range a.Result.PlacedChunks {
+		if a.Result.PlacedChunks[i].Chunk.ID != b.Result.PlacedChunks[i].Chunk.ID {
+			t.Errorf("chunk %d differs: %s vs %s", i, a.Result.PlacedChunks[i].Chunk.ID, b.Result.PlacedChunks[i].Chunk.ID)
+		}
+	}
+	if len(a.Level.EnemySpawns) != len(b.Level.EnemySpawns) {
+		t.Errorf("enemy count differs: %d vs %d", len(a.Level.EnemySpawns), len(b.Level.EnemySpawns))
+	}
+}
+
+func TestGenerateRunRewardTypes(t *testing.T) {
+	types := config.Procgen.RewardTypes
+	t.Cleanup(func() { config.Procgen.RewardTypes = types })
+
+	// Find a seed with branch rewards
+	var run *Run
+	var seed int64
+	for seed = 1; seed <= 50; seed++ {
+		var err error
+		if run, err = GenerateRun(RunOptions{Seed: seed, Headless: true}); err != nil {
+			t.Fatalf("GenerateRun failed: %v", err)
+		}
+		if len(run.Level.Rewards) > 0 {
+			break
+		}
+	}
+	if len(run.Level.Rewards) == 0 {
+		t.Skip("no seed in range has a reward room")
+	}
+	for _, r := range run.Level.Rewards {
+		if !slices.Contains(types, r.RewardType) {
+			t.Errorf("reward type %q is not in %v", r.RewardType, types)
+		}
+	}
+
+	// The reward roll leaves the rest of the level alone
+	config.Procgen.RewardTypes = []string{"health"}
+	healthOnly, err := GenerateRun(RunOptions{Seed: seed, Headless: true})
+	if err != nil {
+		t.Fatalf("GenerateRun failed: %v", err)
+	}
+	if !slices.Equal(healthOnly.Level.EnemySpawns, run.Level.EnemySpawns) || len(healthOnly.Level.Rewards) != len(run.Level.Rewards) {
+		t.Error("expected the reward types not to change the rest of the level")
+	}
+	for _, r := range healthOnly.Level.Rewards {
+		if r.RewardType != "health" {
+			t.Errorf("expected only health rewards, got %q", r.RewardType)
+		}
+	}
+}
+
+func TestGenerateRunOptions(t *testing.T) {
+	run, err := GenerateRun(RunOptions{Seed: 7, Length: 12, Biome: "industrial", Headless: true})
+	if err != nil {
+		t.Fatalf("GenerateRun failed: %v", err)
+	}
+
+	if run.Biome != "industrial" {
+		t.Errorf("expected biome industrial, got %s", run.Biome)
+	}
+	if len(run.Graph.Nodes) != 14 {
+		t.Errorf("expected 14 graph nodes (start + 12 + exit), got %d", len(run.Graph.Nodes))
+	}
+	if run.Level.Background != nil {
+		t.Error("headless run should not render a background")
+	}
+	vr := run.Validate()
+	if vr.PlatformCount != len(vr.Platforms) {
+		t.Errorf("platform count %d does not match %d platforms", vr.PlatformCount, len(vr.Platforms))
+	}
+	if run.Solvable != vr.Solvable {
+		t.Errorf("run solvable %v but validation says %v", run.Solvable, vr.Solvable)
+	}
+}
+
+func TestGenerateRunResolvesOptions(t *testing.T) {
+	run, err := GenerateRun(RunOptions{Seed: 7, Headless: true})
+	if err != nil {
+		t.Fatalf("GenerateRun failed: %v", err)
+	}
+
+	want := RunOptions{Seed: 7, Length: config.Procgen.DefaultRunLength, Biome: run.Biome, Headless: true}
+	if run.Options != want {
+		t.Errorf("expected resolved options %+v, got %+v", want, run.Options)
+	}
+
+	// The resolved options build the same run as the defaults did
+	again, err := GenerateRun(run.Options)
+	if err != nil {
+		t.Fatalf("GenerateRun failed: %v", err)
+	}
+	if len(again.Result.PlacedChunks) != len(run.Result.PlacedChunks) || again.Level.Width != run.Level.Width {
+		t.Error("expected the resolved options to reproduce the run")
+	}
+	for i, pc := range run.Result.PlacedChunks {
+		if i < len(again.Result.PlacedChunks) && again.Result.PlacedChunks[i].Chunk.ID != pc.Chunk.ID {
+			t.Errorf("room %d: expected chunk %s, got %s", i, pc.Chunk.ID, again.Result.PlacedChunks[i].Chunk.ID)
+		}
+	}
+}
+
+func TestRoomAt(t *testing.T) {
+	result := &GenerationResult{PlacedChunks: []PlacedChunk{
+		{Chunk: &Chunk{Width: 320, Height: 320}, OffsetX: 0, OffsetY: 0, ExitEdge: EdgeBottom},
+		{Chunk: &Chunk{Width: 320, Height: 320}, OffsetX: 0, OffsetY: 320, ExitEdge: EdgeRight},
+		{Chunk: &Chunk{Width: 640, Height: 320}, OffsetX: 320, OffsetY: 320},
+	}}
+
+	tests := []struct {
+		x, y float64
+		want int
+	}{
+		{100, 100, 0},
+		{100, 400, 1},
+		{500, 400, 2},
+		{600, 100, 2},  // Empty space above the last room
+		{2000, 400, 2}, // Past the end of the level
+	}
+	for _, tt := range tests {
+		if got := result.RoomAt(tt.x, tt.y); got != tt.want {
+			t.Errorf("RoomAt(%v, %v) = %d, want %d", tt.x, tt.y, got, tt.want)
+		}
+	}
+
+	boundaries := result.RoomBoundaries()
+	if boundaries[0].Edge != EdgeBottom || boundaries[0].Pos != 320 {
+		t.Errorf("expected first room to exit through its bottom at 320, got %+v", boundaries[0])
+	}
+	if boundaries[1].Edge != EdgeRight || boundaries[1].Pos != 320 {
+		t.Errorf("expected second room to exit right at 320, got %+v", boundaries[1])
+	}
+}
+
+func TestOptionalRoomsSkipBoundaries(t *testing.T) {
+	side := &GraphNode{Type: NodeCombat, Optional: true}
+	result := &GenerationResult{PlacedChunks: []PlacedChunk{
+		{Chunk: &Chunk{Width: 320, Height: 320}, ExitEdge: EdgeRight},
+		{Chunk: &Chunk{Width: 320, Height: 320}, OffsetX: 320},
+		{Chunk: &Chunk{Width: 320, Height: 320}, OffsetX: 320, OffsetY: -320, Node: side},
+	}}
+
+	if n := result.MainPathLength(); n != 2 {
+		t.Errorf("expected main path length 2, got %d", n)
+	}
+	if n := len(result.RoomBoundaries()); n != 2 {
+		t.Errorf("expected 2 room boundaries, got %d", n)
+	}
+	rooms := result.OptionalRooms()
+	if len(rooms) != 1 || rooms[0].Y != -320 {
+		t.Errorf("expected one optional room at Y -320, got %+v", rooms)
+	}
+	if areas := result.MainPathRoomAreas(); len(areas) != 2 || areas[1].X != 320 || areas[1].Y != 0 {
+		t.Errorf("expected main path rooms at X 0 and 320, got %+v", areas)
+	}
+	if ids := result.MainPathChunkIDs(); len(ids) != 2 {
+		t.Errorf("expected 2 main path chunk IDs, got %v", ids)
+	}
+}
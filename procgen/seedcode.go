@@ -0,0 +1,196 @@
+package procgen
+
+import (
+	"encoding/base32"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/automoto/doomerang/assets"
+	"github.com/automoto/doomerang/config"
+)
+
+// Seed codes are short, shareable strings that reproduce a run exactly. A code
+// packs the seed with any non-default generation options, followed by a
+// checksum byte so typos are caught instead of silently starting another run.
+//
+// Layout before encoding:
+//
+//	byte 0     version << 4 | flags
+//	[length]   present when seedCodeHasLength is set
+//	[biome]    index into config.Procgen.Biomes, present when seedCodeHasBiome is set
+//	[chunks]   ActiveChunkSet, big-endian, present when seedCodeHasChunkSet is set
+//	seed       big-endian, leading zero bytes dropped (1-8 bytes)
+//	checksum   low byte of the CRC-32 of everything before it
+//
+// The bytes are written in Crockford's base32 and grouped in fours, e.g. "42H0-BC34-GM".
+const (
+	// v2: the combat_01 and combat_02 chunk fixes and the heavy reward build
+	// other runs from the same seed, so v1 codes are refused
+	seedCodeVersion     = 2
+	seedCodeHasLength   = 1 << 0
+	seedCodeHasBiome    = 1 << 1
+	seedCodeHasChunkSet = 1 << 2
+	seedCodeGroupSize   = 4
+)
+
+// seedCodeEncoding uses Crockford's alphabet: no I, L, O or U to misread
+var seedCodeEncoding = base32.NewEncoding("0123456789ABCDEFGHJKMNPQRSTVWXYZ").WithPadding(base32.NoPadding)
+
+// ErrInvalidSeedCode is returned when a seed code cannot be decoded.
+var ErrInvalidSeedCode = errors.New("invalid seed code")
+
+// ErrSeedCodeLevelPack is returned for a seed code generated from another
+// level pack's chunks, which would build a different run.
+var ErrSeedCodeLevelPack = errors.New("seed code is for a different level pack")
+
+// ActiveChunkSet identifies the chunks runs are generated from: 0 for the
+// built-in chunks, otherwise a hash of the active level pack's ID when the
+// pack has chunks of its own.
+func ActiveChunkSet() uint16 {
+	pack := assets.ActiveLevelPack()
+	if pack == nil || pack.Chunks == 0 {
+		return 0
+	}
+	if set := uint16(crc32.ChecksumIEEE([]byte(pack.ID))); set != 0 {
+		return set
+	}
+	return 1
+}
+
+// NewRunSeed returns a fresh seed for a new run. Seeds are kept to 32 bits so
+// their codes stay short enough to read out loud.
+func NewRunSeed() int64 {
+	return rand.New(rand.NewSource(time.Now().UnixNano())).Int63n(1 << 32)
+}
+
+// EncodeSeedCode returns the seed code for a run. Headless is not part of the
+// code; a zero Length or empty Biome are left for the decoder to default. Runs
+// from a level pack's chunks are marked with the pack's ActiveChunkSet.
+func EncodeSeedCode(opts RunOptions) (string, error) {
+	flags := byte(0)
+	var header []byte
+	if opts.Length > 0 {
+		if opts.Length > 255 {
+			return "", fmt.Errorf("run length %d does not fit in a seed code", opts.Length)
+		}
+		flags |= seedCodeHasLength
+		header = append(header, byte(opts.Length))
+	}
+	if opts.Biome != "" {
+		idx := biomeIndex(opts.Biome)
+		if idx < 0 {
+			return "", fmt.Errorf("unknown biome %q", opts.Biome)
+		}
+		flags |= seedCodeHasBiome
+		header = append(header, byte(idx))
+	}
+	if set := ActiveChunkSet(); set != 0 {
+		flags |= seedCodeHasChunkSet
+		header = append(header, byte(set>>8), byte(set))
+	}
+
+	data := append([]byte{seedCodeVersion<<4 | flags}, header...)
+	seed := uint64(opts.Seed)
+	var seedBytes []byte
+	for seed > 0 || len(seedBytes) == 0 {
+		seedBytes = append([]byte{byte(seed)}, seedBytes...)
+		seed >>= 8
+	}
+	data = append(data, seedBytes...)
+	data = append(data, seedCodeChecksum(data))
+
+	return groupSeedCode(seedCodeEncoding.EncodeToString(data)), nil
+}
+
+// DecodeSeedCode parses a seed code back into the options that generated the
+// run. Case, dashes and spaces are ignored, and the letters O, I and L are read
+// as the digits they are commonly mistaken for. A code from other chunks than
+// the active ones returns ErrSeedCodeLevelPack.
+func DecodeSeedCode(code string) (RunOptions, error) {
+	normalized := strings.Map(func(r rune) rune {
+		switch r {
+		case '-', ' ':
+			return -1
+		case 'O', 'o':
+			return '0'
+		case 'I', 'i', 'L', 'l':
+			return '1'
+		}
+		return r
+	}, strings.ToUpper(code))
+
+	data, err := seedCodeEncoding.DecodeString(normalized)
+	if err != nil || len(data) < 3 {
+		return RunOptions{}, ErrInvalidSeedCode
+	}
+	body, check := data[:len(data)-1], data[len(data)-1]
+	if seedCodeChecksum(body) != check {
+		return RunOptions{}, ErrInvalidSeedCode
+	}
+	if body[0]>>4 != seedCodeVersion {
+		return RunOptions{}, fmt.Errorf("%w: unsupported version %d", ErrInvalidSeedCode, body[0]>>4)
+	}
+
+	var opts RunOptions
+	flags, rest := body[0]&0x0f, body[1:]
+	if flags&seedCodeHasLength != 0 {
+		if len(rest) == 0 {
+			return RunOptions{}, ErrInvalidSeedCode
+		}
+		opts.Length, rest = int(rest[0]), rest[1:]
+	}
+	if flags&seedCodeHasBiome != 0 {
+		if len(rest) == 0 || int(rest[0]) >= len(config.Procgen.Biomes) {
+			return RunOptions{}, ErrInvalidSeedCode
+		}
+		opts.Biome, rest = config.Procgen.Biomes[rest[0]], rest[1:]
+	}
+	var set uint16
+	if flags&seedCodeHasChunkSet != 0 {
+		if len(rest) < 2 {
+			return RunOptions{}, ErrInvalidSeedCode
+		}
+		set, rest = uint16(rest[0])<<8|uint16(rest[1]), rest[2:]
+	}
+	if len(rest) == 0 || len(rest) > 8 {
+		return RunOptions{}, ErrInvalidSeedCode
+	}
+
+	var seed uint64
+	for _, b := range rest {
+		seed = seed<<8 | uint64(b)
+	}
+	opts.Seed = int64(seed)
+	if set != ActiveChunkSet() {
+		return RunOptions{}, ErrSeedCodeLevelPack
+	}
+	return opts, nil
+}
+
+func seedCodeChecksum(data []byte) byte {
+	return byte(crc32.ChecksumIEEE(data))
+}
+
+func biomeIndex(biome string) int {
+	for i, b := range config.Procgen.Biomes {
+		if b == biome {
+			return i
+		}
+	}
+	return -1
+}
+
+func groupSeedCode(s string) string {
+	var b strings.Builder
+	for i, r := range s {
+		if i > 0 && i%seedCodeGroupSize == 0 {
+			b.WriteByte('-')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,118 @@
+package procgen_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/automoto/doomerang/assets"
+	"github.com/automoto/doomerang/config"
+	"github.com/automoto/doomerang/procgen"
+)
+
+func TestSeedCodeRoundTrip(t *testing.T) {
+	tests := []procgen.RunOptions{
+		{Seed: 0},
+		{Seed: 42},
+		{Seed: 1<<32 - 1},
+		{Seed: -7},
+		{Seed: 1234567, Length: 15},
+		{Seed: 99, Biome: config.Procgen.Biomes[len(config.Procgen.Biomes)-1]},
+		{Seed: 1 << 62, Length: 3, Biome: config.Procgen.Biomes[0]},
+	}
+	for _, opts := range tests {
+		code, err := procgen.EncodeSeedCode(opts)
+		if err != nil {
+			t.Fatalf("EncodeSeedCode(%+v) failed: %v", opts, err)
+		}
+		got, err := procgen.DecodeSeedCode(code)
+		if err != nil {
+			t.Fatalf("DecodeSeedCode(%q) failed: %v", code, err)
+		}
+		if got != opts {
+			t.Errorf("code %q decoded to %+v, want %+v", code, got, opts)
+		}
+	}
+}
+
+func TestSeedCodeShortForNewRuns(t *testing.T) {
+	code, err := procgen.EncodeSeedCode(procgen.RunOptions{Seed: procgen.NewRunSeed()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(code) > 12 {
+		t.Errorf("expected a new run code of at most 12 characters, got %q", code)
+	}
+}
+
+func TestSeedCodeForgiving(t *testing.T) {
+	opts := procgen.RunOptions{Seed: 31337, Length: 10}
+	code, _ := procgen.EncodeSeedCode(opts)
+
+	// Lower case, no dashes, and letters typed for look-alike digits
+	typed := strings.ToLower(strings.ReplaceAll(code, "-", ""))
+	typed = strings.NewReplacer("0", "o", "1", "l").Replace(typed)
+	got, err := procgen.DecodeSeedCode(" " + typed + " ")
+	if err != nil {
+		t.Fatalf("DecodeSeedCode(%q) failed: %v", typed, err)
+	}
+	if got != opts {
+		t.Errorf("decoded %+v, want %+v", got, opts)
+	}
+}
+
+func TestSeedCodeRejectsTypos(t *testing.T) {
+	code, _ := procgen.EncodeSeedCode(procgen.RunOptions{Seed: 123456})
+
+	// Swap one character for a different valid one
+	typo := []byte(code)
+	if typo[0] == 'Z' {
+		typo[0] = 'Y'
+	} else {
+		typo[0] = 'Z'
+	}
+
+	for _, bad := range []string{"", "UUUU", "!!!!-!!!!", string(typo)} {
+		if _, err := procgen.DecodeSeedCode(bad); !errors.Is(err, procgen.ErrInvalidSeedCode) {
+			t.Errorf("DecodeSeedCode(%q): expected ErrInvalidSeedCode, got %v", bad, err)
+		}
+	}
+
+	if _, err := procgen.EncodeSeedCode(procgen.RunOptions{Biome: "moon"}); err == nil {
+		t.Error("expected unknown biome to fail encoding")
+	}
+}
+
+func TestSeedCodeRefusesOlderVersions(t *testing.T) {
+	// Seed 2718281828 before the chunk fixes changed its run
+	if _, err := procgen.DecodeSeedCode("22H0-BC34-0C"); !errors.Is(err, procgen.ErrInvalidSeedCode) {
+		t.Errorf("expected a version 1 code to be refused, got %v", err)
+	}
+}
+
+func TestSeedCodeRecordsLevelPackChunks(t *testing.T) {
+	builtIn, _ := procgen.EncodeSeedCode(procgen.RunOptions{Seed: 42})
+
+	assets.UseLevelPack(&assets.LevelPack{ID: "neon", Chunks: 3})
+	defer assets.UseLevelPack(nil)
+
+	code, err := procgen.EncodeSeedCode(procgen.RunOptions{Seed: 42})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err := procgen.DecodeSeedCode(code); err != nil || got.Seed != 42 {
+		t.Errorf("DecodeSeedCode(%q) = %+v, %v; want seed 42 under the same pack", code, got, err)
+	}
+	if _, err := procgen.DecodeSeedCode(builtIn); !errors.Is(err, procgen.ErrSeedCodeLevelPack) {
+		t.Errorf("expected a built-in code to be refused under a chunk pack, got %v", err)
+	}
+
+	// A pack with only campaign levels generates the same runs as the built-in chunks
+	assets.UseLevelPack(&assets.LevelPack{ID: "story", Levels: 2})
+	if _, err := procgen.DecodeSeedCode(builtIn); err != nil {
+		t.Errorf("expected a built-in code to work under a levels-only pack, got %v", err)
+	}
+	if _, err := procgen.DecodeSeedCode(code); !errors.Is(err, procgen.ErrSeedCodeLevelPack) {
+		t.Errorf("expected a chunk pack code to be refused without the pack, got %v", err)
+	}
+}
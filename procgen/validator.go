@@ -15,6 +15,7 @@ type ValidationResult struct {
 	StartIdx     int
 	ExitIdx      int
 	PlatformCount int
+	Platforms    []Platform // Platforms discovered in the level; the indices above refer to these
 }
 
 // Platform represents a walkable surface for reachability analysis
@@ -23,31 +24,13 @@ type Platform struct {
 	ChunkIndex  int // Which placed chunk this belongs to
 }
 
-// Validator checks that a generated level is solvable using jump physics
-type Validator struct {
-	maxJumpHeight float64
-	maxJumpDist   float64
-	margin        float64 // Safety margin (0.85 = 85% of max)
-}
+// Validator checks that a generated level is solvable by simulating the
+// player moving through it with the game's physics
+type Validator struct{}
 
 // NewValidator creates a validator using game physics constants
 func NewValidator() *Validator {
-	jumpSpeed := config.Player.JumpSpeed
-	gravity := config.Player.Gravity
-	maxSpeedX := config.Player.MaxSpeed
-
-	// Max jump height: v^2 / (2g)
-	maxHeight := (jumpSpeed * jumpSpeed) / (2 * gravity)
-	// Time to peak: v/g, total air time: 2 * v/g
-	airTime := 2 * jumpSpeed / gravity
-	// Max horizontal distance: maxSpeedX * airTime
-	maxDist := maxSpeedX * airTime
-
-	return &Validator{
-		maxJumpHeight: maxHeight,
-		maxJumpDist:   maxDist,
-		margin:        0.95,
-	}
+	return &Validator{}
 }
 
 // Validate checks if the generated level is solvable.
@@ -64,14 +47,15 @@ func (v *Validator) Validate(result *GenerationResult) ValidationResult {
 	startIdx := v.findStartPlatform(platforms, result)
 	exitIdx := v.findExitPlatform(platforms, result)
 
-	// Build reachability graph
-	reachable := v.bfsReachability(platforms, startIdx)
+	// Play the level from the start platform
+	reachable := v.reachablePlatforms(result, platforms, []int{startIdx}, -1)
 
 	vr := ValidationResult{
 		Solvable:      reachable[exitIdx],
 		StartIdx:      startIdx,
 		ExitIdx:       exitIdx,
 		PlatformCount: len(platforms),
+		Platforms:     platforms,
 	}
 
 	for i, r := range reachable {
@@ -83,6 +67,19 @@ func (v *Validator) Validate(result *GenerationResult) ValidationResult {
 	return vr
 }
 
+// Solvable reports whether the exit can be reached from the start, as Validate
+// does. It heads for the exit and stops once it gets there, so it's much
+// cheaper when the rest of the report isn't needed.
+func (v *Validator) Solvable(result *GenerationResult) bool {
+	platforms := v.discoverPlatforms(result)
+	if len(platforms) == 0 {
+		return false
+	}
+	startIdx := v.findStartPlatform(platforms, result)
+	exitIdx := v.findExitPlatform(platforms, result)
+	return v.reachablePlatforms(result, platforms, []int{startIdx}, exitIdx)[exitIdx]
+}
+
 // discoverPlatforms finds all walkable surfaces from the generated level
 func (v *Validator) discoverPlatforms(result *GenerationResult) []Platform {
 	var allPlatforms []Platform
@@ -149,55 +146,6 @@ func (v *Validator) discoverPlatforms(result *GenerationResult) []Platform {
 	return allPlatforms
 }
 
-// canReach returns true if the player can jump from platform a to platform b
-func (v *Validator) canReach(a, b Platform) bool {
-	safeHeight := v.maxJumpHeight * v.margin
-	safeDist := v.maxJumpDist * v.margin
-
-	// Height difference: positive = b is below a, negative = b is above a
-	// (Y increases downward in screen coords)
-	dy := b.Y - a.Y
-
-	// If b is above us by more than max jump height, can't reach
-	if dy < -safeHeight {
-		return false
-	}
-
-	// Horizontal distance between closest edges
-	aRight := a.X + a.Width
-	bRight := b.X + b.Width
-
-	var dx float64
-	if b.X > aRight {
-		dx = b.X - aRight
-	} else if a.X > bRight {
-		dx = a.X - bRight
-	} else {
-		dx = 0 // overlapping or touching horizontally
-	}
-
-	// Touching/overlapping platforms: can walk between if reachable vertically
-	if dx == 0 {
-		return dy >= -safeHeight
-	}
-
-	if dy >= 0 {
-		// Dropping down or same level: extra fall time gives more horizontal distance
-		gravity := config.Player.Gravity
-		extraTime := math.Sqrt(2 * dy / gravity)
-		extraDist := config.Player.MaxSpeed * extraTime
-		return dx <= safeDist+extraDist
-	}
-
-	// Jumping up: height cost reduces available horizontal distance
-	heightRatio := math.Abs(dy) / safeHeight
-	if heightRatio > 1 {
-		return false
-	}
-	adjustedDist := safeDist * (1 - heightRatio*0.5)
-	return dx <= adjustedDist
-}
-
 // findStartPlatform finds the floor platform in the first chunk
 // (lowest Y value = highest on screen, but we want the floor = highest Y)
 func (v *Validator) findStartPlatform(platforms []Platform, result *GenerationResult) int {
@@ -212,9 +160,9 @@ func (v *Validator) findStartPlatform(platforms []Platform, result *GenerationRe
 	return bestIdx
 }
 
-// findExitPlatform finds the floor platform in the last chunk
+// findExitPlatform finds the floor platform in the last chunk of the main path
 func (v *Validator) findExitPlatform(platforms []Platform, result *GenerationResult) int {
-	lastChunk := len(result.PlacedChunks) - 1
+	lastChunk := result.MainPathLength() - 1
 	bestIdx := len(platforms) - 1
 	bestY := -1.0
 	for i, p := range platforms {
@@ -226,56 +174,375 @@ func (v *Validator) findExitPlatform(platforms []Platform, result *GenerationRes
 	return bestIdx
 }
 
-// bfsReachability performs BFS from startIdx to find all reachable platforms
-func (v *Validator) bfsReachability(platforms []Platform, startIdx int) []bool {
-	n := len(platforms)
-	reachable := make([]bool, n)
-	reachable[startIdx] = true
+// reachablePlatforms reports which platforms the player can get to from the
+// platforms in from, by playing moves through the level. With a goal platform
+// other than -1 it stops once the goal is reached, and only reports on the goal.
+func (v *Validator) reachablePlatforms(result *GenerationResult, platforms []Platform, from []int, goal int) []bool {
+	space := newSimSpace(result)
+	var starts, goals []int
+	for _, i := range from {
+		starts = append(starts, space.platformSpans(platforms[i])...)
+	}
+	if goal >= 0 {
+		goals = space.platformSpans(platforms[goal])
+	}
+	reachedSpans := space.reach(starts, goals)
+
+	reachable := make([]bool, len(platforms))
+	for _, i := range from {
+		reachable[i] = true
+	}
+	for i, p := range platforms {
+		for _, span := range space.platformSpans(p) {
+			if reachedSpans[span] {
+				reachable[i] = true
+			}
+		}
+	}
+	return reachable
+}
+
+// simMove is one way to play a move: when jump or attack is pressed and which
+// way is held, switching direction partway through if switchAt is set
+type simMove struct {
+	jumpAt, kickAt int     // Frame jump or attack is pressed, -1 for never
+	hold           float64 // Direction held until switchAt
+	switchAt       int     // Frame the held direction changes, -1 for never
+	then           float64
+	crouch         bool
+}
 
-	queue := []int{startIdx}
-	for len(queue) > 0 {
-		curr := queue[0]
-		queue = queue[1:]
+func (m simMove) input(frame int) simInput {
+	in := simInput{move: m.hold, jump: frame == m.jumpAt, kick: frame == m.kickAt, crouch: m.crouch}
+	if m.switchAt >= 0 && frame >= m.switchAt {
+		in.move = m.then
+	}
+	return in
+}
 
-		for i := 0; i < n; i++ {
-			if reachable[i] {
-				continue
+// steer returns a move holding dir, and variants that let go of it or turn
+// around partway through
+func steer(dir float64, switchFrames ...int) []simMove {
+	moves := []simMove{{jumpAt: -1, kickAt: -1, hold: dir, switchAt: -1}}
+	for _, frame := range switchFrames {
+		for _, then := range []float64{-1, 0, 1} {
+			if then != dir {
+				moves = append(moves, simMove{jumpAt: -1, kickAt: -1, hold: dir, switchAt: frame, then: then})
 			}
-			if v.canReach(platforms[curr], platforms[i]) {
-				reachable[i] = true
-				queue = append(queue, i)
+		}
+	}
+	return moves
+}
+
+// simOutcome is where a move left the player: on a span, or sliding down a
+// wall with another move to make
+type simOutcome struct {
+	span   int
+	wall   *simPlayer
+	frames int // Frames played before the move ended
+}
+
+// simWalkFrames is how long a move may keep the player walking on the span
+// it started from before it's given up
+const simWalkFrames = 60
+
+// play follows a move from p, which starts on span from or on a wall, until
+// the player lands, catches a wall, dies or runs out of time
+func (s *simSpace) play(p simPlayer, m simMove, from int) simOutcome {
+	airborne := p.onGround < 0
+	sliding := p.wallSliding >= 0
+	for frame := 0; frame < simMaxFrames; frame++ {
+		none := simOutcome{span: -1, frames: frame + 1}
+		if !s.step(&p, m.input(frame)) {
+			return none
+		}
+		if p.onGround >= 0 {
+			span := s.groundSpan(&p)
+			switch {
+			case airborne && (span == from || s.inFire(&p)):
+				// Back where it started, or landed in fire
+				return none
+			case airborne || span != from:
+				return simOutcome{span: span, frames: frame + 1}
+			case frame >= simWalkFrames:
+				return none
 			}
+		} else {
+			airborne = true
 		}
+		if p.wallSliding >= 0 && !sliding {
+			return simOutcome{span: -1, wall: &p, frames: frame + 1}
+		}
+		sliding = p.wallSliding >= 0
 	}
+	return simOutcome{span: -1, frames: simMaxFrames}
+}
 
-	return reachable
+// playSteered plays moves from p, the first of which never switches
+// direction. The others play the same frames as it up to their switch, so
+// they are skipped when it was already over by then.
+func (s *simSpace) playSteered(p simPlayer, moves []simMove, from int, visit func(simOutcome)) {
+	ended := simMaxFrames
+	for i, m := range moves {
+		if i > 0 && m.switchAt >= ended {
+			continue
+		}
+		o := s.play(p, m, from)
+		if i == 0 {
+			ended = o.frames
+		}
+		visit(o)
+	}
+}
+
+// wallKey identifies a wall slide for the search, so catching the same wall at
+// about the same height isn't played out again
+type wallKey struct {
+	wall      int32
+	row       int
+	facing    float64
+	attacking bool
+}
+
+// reach returns which spans the player can get to from the spans in starts.
+// Moves are played from every span reached, and from every wall the player
+// catches on the way, until there's nothing new to try or one of goals is
+// reached. With goals, the spans nearest the first one are played first.
+func (s *simSpace) reach(starts, goals []int) []bool {
+	reached := make([]bool, len(s.spans))
+	left := len(s.spans)
+	var spanQueue []int
+	var wallQueue []simPlayer
+	seenWalls := map[wallKey]bool{}
+
+	visit := func(o simOutcome) {
+		if o.wall != nil {
+			key := wallKey{o.wall.wallSliding, int(o.wall.y / 8), o.wall.facing, o.wall.attack > 0}
+			if !seenWalls[key] {
+				seenWalls[key] = true
+				wallQueue = append(wallQueue, *o.wall)
+			}
+			return
+		}
+		if o.span >= 0 && !reached[o.span] {
+			reached[o.span] = true
+			left--
+			spanQueue = append(spanQueue, o.span)
+		}
+	}
+	for _, span := range starts {
+		visit(simOutcome{span: span})
+	}
+
+	goalReached := func() bool {
+		for _, g := range goals {
+			if reached[g] {
+				return true
+			}
+		}
+		return false
+	}
+	// nextSpan takes the queued span nearest the first goal, or the oldest
+	// one without goals
+	nextSpan := func() int {
+		best := 0
+		if len(goals) > 0 {
+			bestDist := math.Inf(1)
+			for i, span := range spanQueue {
+				if d := s.spanDistance(span, goals[0]); d < bestDist {
+					best, bestDist = i, d
+				}
+			}
+		}
+		span := spanQueue[best]
+		spanQueue = append(spanQueue[:best], spanQueue[best+1:]...)
+		return span
+	}
+
+	for left > 0 && !goalReached() && (len(spanQueue) > 0 || len(wallQueue) > 0) {
+		if len(spanQueue) > 0 {
+			s.spanMoves(nextSpan(), visit)
+			continue
+		}
+		p := wallQueue[0]
+		wallQueue = wallQueue[1:]
+		s.wallMoves(p, visit)
+	}
+	return reached
+}
+
+// spanMoves plays jumps from along a span, and walks, crouches and slides off
+// its ends
+func (s *simSpace) spanMoves(span int, visit func(simOutcome)) {
+	sp := s.spans[span]
+	w := float64(config.Player.CollisionWidth)
+	h := float64(config.Player.CollisionHeight)
+	player := func(x float64) simPlayer {
+		tile := int(math.Max(math.Min((x+w/2-sp.x)/simCellSize, float64(len(sp.tiles)-1)), 0))
+		return simPlayer{
+			simRect:     simRect{x, sp.y - h, w, h},
+			facing:      config.DirectionRight,
+			onGround:    sp.tiles[tile],
+			wallSliding: -1,
+		}
+	}
+	// runUp is the speed the player can build up walking toward dir before x
+	accel := config.Player.Acceleration - config.Player.Friction
+	runUp := func(x, dir float64) float64 {
+		runway := x + w/2 - sp.x
+		if dir < 0 {
+			runway = sp.x + sp.w - (x + w/2)
+		}
+		return math.Min(math.Sqrt(2*accel*math.Max(runway, 0)), config.Player.MaxSpeed)
+	}
+	standing := func(x float64) bool {
+		return !s.blocked(simRect{x, sp.y - h, w, h})
+	}
+
+	// Jump from each end, hanging over it if there's room, and every few
+	// tiles in between
+	var xs []float64
+	for _, end := range [][2]float64{{sp.x - w/2, sp.x}, {sp.x + sp.w - w/2, sp.x + sp.w - w}} {
+		for _, x := range end {
+			if standing(x) {
+				xs = append(xs, x)
+				break
+			}
+		}
+	}
+	for x := sp.x + 4*simCellSize; x < sp.x+sp.w-w; x += 4 * simCellSize {
+		if standing(x) {
+			xs = append(xs, x)
+		}
+	}
+	for _, x := range xs {
+		for _, dir := range []float64{-1, 0, 1} {
+			speeds := []float64{0}
+			if dir != 0 {
+				for _, speed := range []float64{config.Player.MaxSpeed / 2, config.Player.MaxSpeed} {
+					if speed = math.Min(speed, runUp(x, dir)); speed > speeds[len(speeds)-1] {
+						speeds = append(speeds, speed)
+					}
+				}
+			}
+			for _, speed := range speeds {
+				p := player(x)
+				p.vx = speed * dir
+				moves := steer(dir, 10, 20)
+				for i := range moves {
+					moves[i].jumpAt = 0
+				}
+				s.playSteered(p, moves, span, visit)
+			}
+		}
+	}
+
+	// Walk, crouch-walk and slide off each end
+	for _, end := range []struct{ x, dir float64 }{{sp.x - w/2, -1}, {sp.x + sp.w - w/2, 1}} {
+		speed := runUp(end.x, end.dir)
+		if standing(end.x) {
+			for _, v := range []float64{0, speed} {
+				p := player(end.x)
+				p.vx = v * end.dir
+				s.playSteered(p, steer(end.dir, 8), span, visit)
+			}
+		}
+		p := player(end.x)
+		p.crouch()
+		visit(s.play(p, simMove{jumpAt: -1, kickAt: -1, hold: end.dir, switchAt: -1, crouch: true}, span))
+		if speed >= config.Player.SlideSpeedThreshold {
+			p.crouched, p.sliding = false, true
+			p.vx = speed * end.dir
+			visit(s.play(p, simMove{jumpAt: -1, kickAt: -1, hold: end.dir, switchAt: -1, crouch: true}, span))
+		}
+	}
+}
+
+// wallMoves plays the moves open to a player sliding down a wall: letting go,
+// or wall jumping and wall kicking off it straight away or after a moment
+func (s *simSpace) wallMoves(p simPlayer, visit func(simOutcome)) {
+	wall := s.objects[p.wallSliding]
+	toward := config.DirectionRight
+	if wall.x+wall.w/2 < p.x+p.w/2 {
+		toward = config.DirectionLeft
+	}
+
+	for _, hold := range []float64{0, toward} {
+		visit(s.play(p, simMove{jumpAt: -1, kickAt: -1, hold: hold, switchAt: -1}, -1))
+	}
+	// The first frame of a slide is still in the jump state, whose animation
+	// may already have looped and would cut a kick short
+	for _, wait := range []int{2, 24} {
+		moves := []simMove{
+			{hold: -toward, switchAt: -1},
+			{hold: toward, switchAt: -1},
+			{hold: 0, switchAt: -1},
+			{hold: -toward, switchAt: 12, then: toward},
+		}
+		for _, m := range moves {
+			jump, kick := m, m
+			jump.jumpAt, jump.kickAt = wait, -1
+			kick.jumpAt, kick.kickAt = -1, wait
+			visit(s.play(p, jump, -1))
+			visit(s.play(p, kick, -1))
+		}
+	}
+}
+
+// RemediationStats records how much work ValidateAndRemediate needed for a run.
+type RemediationStats struct {
+	Attempts       int  // Graph-driven generation attempts made
+	GraphFallbacks int  // Attempts that couldn't place the full concept graph and dropped its branches or the graph
+	LastResort     bool // No attempt was solvable; kept the last layout anyway
 }
 
 // ValidateAndRemediate validates the level and retries generation if unsolvable.
 // Returns the final generation result and any error.
 func ValidateAndRemediate(generator *ChunkGenerator, chunks []*Chunk, graph *ConceptGraph, maxAttempts int) (*GenerationResult, error) {
+	result, _, err := ValidateAndRemediateWithStats(generator, chunks, graph, maxAttempts)
+	return result, err
+}
+
+// ValidateAndRemediateWithStats is ValidateAndRemediate that also reports how many
+// attempts were needed, for quality analysis across many seeds.
+func ValidateAndRemediateWithStats(generator *ChunkGenerator, chunks []*Chunk, graph *ConceptGraph, maxAttempts int) (*GenerationResult, RemediationStats, error) {
 	validator := NewValidator()
+	var stats RemediationStats
+	var lastGraphResult *GenerationResult
 
 	for attempt := 0; attempt < maxAttempts; attempt++ {
+		stats.Attempts++
 		result, err := generator.GenerateFromGraph(chunks, graph)
 		if err != nil {
+			stats.GraphFallbacks++
+			// Forks and merges need chunks with two connections on one side;
+			// drop the branches before giving up on the graph
+			result, err = generator.GenerateFromGraph(chunks, graph.MainPath())
+		}
+		if err == nil {
+			lastGraphResult = result
+		} else {
 			// Try simple generation as fallback
 			result, err = generator.Generate(chunks, len(graph.Nodes)-2)
 			if err != nil {
-				return nil, fmt.Errorf("generation failed on attempt %d: %w", attempt, err)
+				return nil, stats, fmt.Errorf("generation failed on attempt %d: %w", attempt, err)
 			}
 		}
 
-		vr := validator.Validate(result)
-		if vr.Solvable {
-			return result, nil
+		if validator.Solvable(result) {
+			return result, stats, nil
 		}
 	}
 
-	// Last resort: return whatever we get (our chunks are hand-designed to be traversable)
+	// Last resort: return whatever we get (our chunks are hand-designed to be
+	// traversable), keeping the graph's rooms if any attempt placed them
+	stats.LastResort = true
+	if lastGraphResult != nil {
+		return lastGraphResult, stats, nil
+	}
 	result, err := generator.Generate(chunks, len(graph.Nodes)-2)
 	if err != nil {
-		return nil, fmt.Errorf("all remediation attempts failed: %w", err)
+		return nil, stats, fmt.Errorf("all remediation attempts failed: %w", err)
 	}
-	return result, nil
+	return result, stats, nil
 }
@@ -2,8 +2,11 @@ package procgen_test
 
 import (
 	"math/rand"
+	"slices"
+	"strings"
 	"testing"
 
+	"github.com/automoto/doomerang/assets"
 	"github.com/automoto/doomerang/procgen"
 )
 
@@ -88,6 +91,34 @@ func TestValidateAndRemediate(t *testing.T) {
 	}
 }
 
+func TestValidateAndRemediateRecordsGraphFallback(t *testing.T) {
+	// Without fork chunks no branch can leave the main path
+	var chunks []*procgen.Chunk
+	for _, c := range loadTestChunks(t) {
+		if !strings.Contains(c.ID, "fork") {
+			chunks = append(chunks, c)
+		}
+	}
+	rng := rand.New(rand.NewSource(7))
+	graph := procgen.GenerateGraph(rng, 10, []string{"cyberpunk"})
+	procgen.ValidateGraph(graph)
+	if len(graph.Branches) == 0 {
+		t.Fatal("expected the test graph to have branches")
+	}
+
+	result, stats, err := procgen.ValidateAndRemediateWithStats(procgen.NewChunkGenerator(7), chunks, graph, 5)
+	if err != nil {
+		t.Fatalf("ValidateAndRemediateWithStats failed: %v", err)
+	}
+
+	if stats.GraphFallbacks == 0 {
+		t.Error("expected the fallback to be recorded")
+	}
+	if result.PlacedChunks[0].Node == nil {
+		t.Error("expected the fallback to keep the main path's graph nodes")
+	}
+}
+
 func TestCanReachAdjacentFloors(t *testing.T) {
 	// Two chunks side by side with same floor height should be walkable
 	chunks := loadTestChunks(t)
@@ -107,3 +138,214 @@ func TestCanReachAdjacentFloors(t *testing.T) {
 		t.Errorf("simple level should be solvable, unreachable: %v", vr.Unreachable)
 	}
 }
+
+func TestValidatorStackedDrop(t *testing.T) {
+	loader := procgen.NewChunkLoader()
+	load := func(path string) *procgen.Chunk {
+		c, err := loader.LoadChunk(path)
+		if err != nil {
+			t.Fatalf("LoadChunk %s failed: %v", path, err)
+		}
+		return c
+	}
+	drop := load("chunks/traversal_drop_01.tmx")
+	land := load("chunks/traversal_land_01.tmx")
+
+	validator := procgen.NewValidator()
+
+	// Falling through the drop chunk's floor opening lands in the chunk below
+	result := &procgen.GenerationResult{PlacedChunks: []procgen.PlacedChunk{
+		{Chunk: drop, ExitEdge: procgen.EdgeBottom},
+		{Chunk: land, OffsetY: float64(drop.Height), EntryEdge: procgen.EdgeTop},
+	}, Links: []procgen.ChunkLink{{From: 0, To: 1, Edge: procgen.EdgeBottom}}}
+	if vr := validator.Validate(result); !vr.Solvable {
+		t.Errorf("stacked drop should be solvable, unreachable: %v", vr.Unreachable)
+	}
+
+	// Sealing the floor opening blocks the way down even though the drop is in
+	// range. The room is walled in too, or the player climbs out and walks
+	// around the outside into the chunk below.
+	sealed := *drop
+	sealed.SolidTiles = append([]assets.SolidTile(nil), drop.SolidTiles...)
+	seal := func(x, y float64) {
+		sealed.SolidTiles = append(sealed.SolidTiles, assets.SolidTile{X: x, Y: y, Width: 16, Height: 16})
+	}
+	for x := 160.0; x < 256; x += 16 {
+		seal(x, 272)
+	}
+	for y := 0.0; y < 272; y += 16 {
+		seal(0, y)
+	}
+	for x := 16.0; x < 304; x += 16 {
+		seal(x, 0)
+	}
+	result.PlacedChunks[0].Chunk = &sealed
+	if vr := validator.Validate(result); vr.Solvable {
+		t.Error("expected a chunk without a floor opening to block the drop")
+	}
+}
+
+// roomChunk builds a chunk from rows of text, '#' marking a solid tile
+func roomChunk(rows ...string) *procgen.Chunk {
+	c := &procgen.Chunk{
+		ID:         "room",
+		Width:      len(rows[0]) * 16,
+		Height:     len(rows) * 16,
+		TileWidth:  len(rows[0]),
+		TileHeight: len(rows),
+	}
+	for y, row := range rows {
+		for x, cell := range row {
+			if cell == '#' {
+				c.SolidTiles = append(c.SolidTiles, assets.SolidTile{X: float64(x * 16), Y: float64(y * 16), Width: 16, Height: 16})
+			}
+		}
+	}
+	return c
+}
+
+// reachableAt reports whether the platform whose left end is at (x, y) was reached
+func reachableAt(t *testing.T, vr procgen.ValidationResult, x, y float64) bool {
+	t.Helper()
+	for i, p := range vr.Platforms {
+		if p.X == x && p.Y == y {
+			return !slices.Contains(vr.Unreachable, i)
+		}
+	}
+	t.Fatalf("no platform at (%v, %v) in %v", x, y, vr.Platforms)
+	return false
+}
+
+func validateRooms(rooms ...*procgen.Chunk) procgen.ValidationResult {
+	result := &procgen.GenerationResult{}
+	x := 0.0
+	for _, room := range rooms {
+		result.PlacedChunks = append(result.PlacedChunks, procgen.PlacedChunk{Chunk: room, OffsetX: x})
+		x += float64(room.Width)
+	}
+	return procgen.NewValidator().Validate(result)
+}
+
+func TestValidatorWallJumpShaft(t *testing.T) {
+	// The ledge is 224px up, out of jump range, but the shaft beside it can
+	// be climbed by wall jumping
+	room := roomChunk(
+		"#...................",
+		"#...................",
+		"#...................",
+		"#...................",
+		"#...................",
+		"#...######..........",
+		"#...#...............",
+		"#...#...............",
+		"#...#...............",
+		"#...#...............",
+		"#...#...............",
+		"#...#...............",
+		"#...#...............",
+		"#...#...............",
+		"#...#...............",
+		"#...#...............",
+		"#...#...............",
+		"#...#...............",
+		"#...#...............",
+		"####################",
+	)
+	if !reachableAt(t, validateRooms(room), 64, 80) {
+		t.Error("expected the ledge to be reached by wall jumping up the shaft")
+	}
+}
+
+func TestValidatorCeilingBlocksJump(t *testing.T) {
+	rows := []string{
+		"#..................#",
+		"#..................#",
+		"#..................#",
+		"#..................#",
+		"#..................#",
+		"#..................#",
+		"#..................#",
+		"#..................#",
+		"#..................#",
+		"#.......####.......#",
+		"#..................#",
+		"#..................#",
+		"#..................#",
+		"##################.#",
+		"#..................#",
+		"#..................#",
+		"#..................#",
+		"#..................#",
+		"#..................#",
+		"####################",
+	}
+	if !reachableAt(t, validateRooms(roomChunk(rows...)), 128, 144) {
+		t.Error("expected the ledge to be reached through the gap in the ceiling")
+	}
+
+	// Closing the gap leaves the ledge in jump range but out of reach
+	rows[13] = "####################"
+	if reachableAt(t, validateRooms(roomChunk(rows...)), 128, 144) {
+		t.Error("expected the ceiling to block the way up to the ledge")
+	}
+}
+
+func TestValidatorDeadZonePit(t *testing.T) {
+	// The pit is too wide to jump, so the way across is along its floor
+	room := roomChunk(
+		"..............................",
+		"..............................",
+		"..............................",
+		"..............................",
+		"..............................",
+		"..............................",
+		"..............................",
+		"..............................",
+		"..............................",
+		"..............................",
+		"..............................",
+		"..............................",
+		"..............................",
+		"..............................",
+		"..............................",
+		"..............................",
+		"###........................###",
+		"###........................###",
+		"###........................###",
+		"##############################",
+	)
+	if !reachableAt(t, validateRooms(room), 432, 256) {
+		t.Error("expected the far side to be reached by climbing through the pit")
+	}
+
+	room.HazardSlots = []procgen.HazardSlot{{X: 48, Y: 272, Width: 384, Height: 32, SlotType: "deadzone"}}
+	if reachableAt(t, validateRooms(room), 432, 256) {
+		t.Error("expected a dead zone over the pit to stop the player crossing it")
+	}
+}
+
+func TestValidatorCrouchTunnel(t *testing.T) {
+	tunnel := func(gap int) []string {
+		rows := make([]string, 20)
+		rows[0] = strings.Repeat("#", 20)
+		for y := 1; y < 19; y++ {
+			rows[y] = strings.Repeat(".", 19) + "#"
+			if y >= 19-gap {
+				rows[y] = strings.Repeat(".", 20)
+			}
+		}
+		rows[19] = strings.Repeat("#", 20)
+		return rows
+	}
+	// The second room is closed on the right so it can only be entered
+	// through the tunnel
+	end := roomChunk(tunnel(0)...)
+
+	// Crouching fits through two tiles of headroom, but not through one
+	if !reachableAt(t, validateRooms(roomChunk(tunnel(2)...), end), 320, 304) {
+		t.Error("expected the player to crouch through a two tile tunnel")
+	}
+	if reachableAt(t, validateRooms(roomChunk(tunnel(1)...), end), 320, 304) {
+		t.Error("expected a one tile tunnel to be too low to pass")
+	}
+}
@@ -0,0 +1,54 @@
+package scenes
+
+import (
+	"image/color"
+	"sync"
+
+	cfg "github.com/automoto/doomerang/config"
+	"github.com/automoto/doomerang/systems"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/yohamta/donburi"
+	"github.com/yohamta/donburi/ecs"
+)
+
+// CampaignCompleteScene is shown after the final campaign level is cleared.
+type CampaignCompleteScene struct {
+	ecs          *ecs.ECS
+	sceneChanger SceneChanger
+	once         sync.Once
+	levelCount   int
+}
+
+// NewCampaignCompleteScene creates a new campaign complete scene.
+func NewCampaignCompleteScene(sc SceneChanger, levelCount int) *CampaignCompleteScene {
+	return &CampaignCompleteScene{
+		sceneChanger: sc,
+		levelCount:   levelCount,
+	}
+}
+
+func (cs *CampaignCompleteScene) Update() {
+	cs.once.Do(cs.configure)
+	cs.ecs.Update()
+}
+
+func (cs *CampaignCompleteScene) Draw(screen *ebiten.Image) {
+	screen.Fill(color.Black)
+	if cs.ecs == nil {
+		return
+	}
+	cs.ecs.Draw(screen)
+}
+
+func (cs *CampaignCompleteScene) configure() {
+	cs.ecs = ecs.NewECS(donburi.NewWorld())
+
+	createMenuScene := NewMainMenuFactory(cs.sceneChanger)
+
+	cs.ecs.AddSystem(systems.UpdateAudio)
+	cs.ecs.AddSystem(systems.UpdateInput)
+	cs.ecs.AddSystem(systems.NewUpdateCampaignComplete(cs.sceneChanger, createMenuScene))
+	cs.ecs.AddRenderer(cfg.Default, systems.DrawCampaignComplete(cs.levelCount))
+
+	systems.PlayMusic(cs.ecs, cfg.Sound.MenuMusic)
+}
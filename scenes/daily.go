@@ -0,0 +1,65 @@
+package scenes
+
+import (
+	"image/color"
+	"log"
+	"sync"
+	"time"
+
+	cfg "github.com/automoto/doomerang/config"
+	"github.com/automoto/doomerang/procgen"
+	"github.com/automoto/doomerang/systems"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/yohamta/donburi"
+	"github.com/yohamta/donburi/ecs"
+)
+
+// DailyScene shows today's daily run and the results of past days.
+type DailyScene struct {
+	ecs          *ecs.ECS
+	sceneChanger SceneChanger
+	once         sync.Once
+}
+
+// NewDailyScene creates a new daily run scene.
+func NewDailyScene(sc SceneChanger) *DailyScene {
+	return &DailyScene{sceneChanger: sc}
+}
+
+func (ds *DailyScene) Update() {
+	ds.once.Do(ds.configure)
+	ds.ecs.Update()
+}
+
+func (ds *DailyScene) Draw(screen *ebiten.Image) {
+	screen.Fill(color.Black)
+	if ds.ecs == nil {
+		return
+	}
+	ds.ecs.Draw(screen)
+}
+
+func (ds *DailyScene) configure() {
+	ds.ecs = ecs.NewECS(donburi.NewWorld())
+
+	now := time.Now()
+	today := procgen.DailyKey(now)
+	seedCode, err := procgen.EncodeSeedCode(procgen.DailyRunOptions(now))
+	if err != nil {
+		log.Printf("Warning: Could not encode daily seed code: %v", err)
+	}
+	runs, _ := systems.LoadDailyRuns()
+	history := systems.DailyHistory(runs)
+
+	createDailyRunScene := func() interface{} {
+		return NewDailyRunScene(ds.sceneChanger)
+	}
+	createMenuScene := NewMainMenuFactory(ds.sceneChanger)
+
+	ds.ecs.AddSystem(systems.UpdateAudio)
+	ds.ecs.AddSystem(systems.UpdateInput)
+	ds.ecs.AddSystem(systems.NewUpdateDaily(ds.sceneChanger, history, createDailyRunScene, createMenuScene))
+	ds.ecs.AddRenderer(cfg.Default, systems.DrawDaily(today, seedCode, history))
+
+	systems.PlayMusic(ds.ecs, cfg.Sound.MenuMusic)
+}
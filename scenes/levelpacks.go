@@ -0,0 +1,53 @@
+package scenes
+
+import (
+	"image/color"
+	"sync"
+
+	"github.com/automoto/doomerang/assets"
+	cfg "github.com/automoto/doomerang/config"
+	"github.com/automoto/doomerang/systems"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/yohamta/donburi"
+	"github.com/yohamta/donburi/ecs"
+)
+
+// LevelPacksScene lists the installed level packs and switches between them.
+type LevelPacksScene struct {
+	ecs          *ecs.ECS
+	sceneChanger SceneChanger
+	once         sync.Once
+}
+
+// NewLevelPacksScene creates a new level packs scene.
+func NewLevelPacksScene(sc SceneChanger) *LevelPacksScene {
+	return &LevelPacksScene{sceneChanger: sc}
+}
+
+func (ps *LevelPacksScene) Update() {
+	ps.once.Do(ps.configure)
+	ps.ecs.Update()
+}
+
+func (ps *LevelPacksScene) Draw(screen *ebiten.Image) {
+	screen.Fill(color.Black)
+	if ps.ecs == nil {
+		return
+	}
+	ps.ecs.Draw(screen)
+}
+
+func (ps *LevelPacksScene) configure() {
+	ps.ecs = ecs.NewECS(donburi.NewWorld())
+
+	// Scanned each time the screen opens so newly installed packs show up
+	packs := assets.LoadLevelPacks(systems.LevelPacksDir())
+	createMenuScene := NewMainMenuFactory(ps.sceneChanger)
+
+	ps.ecs.AddSystem(systems.UpdateAudio)
+	ps.ecs.AddSystem(systems.UpdateInput)
+	ps.ecs.AddSystem(systems.NewUpdateLevelPacks(ps.sceneChanger, packs, createMenuScene))
+	ps.ecs.AddRenderer(cfg.Default, systems.DrawLevelPacks(packs))
+
+	systems.PlayMusic(ps.ecs, cfg.Sound.MenuMusic)
+}
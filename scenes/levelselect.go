@@ -0,0 +1,55 @@
+package scenes
+
+import (
+	"image/color"
+	"sync"
+
+	cfg "github.com/automoto/doomerang/config"
+	"github.com/automoto/doomerang/systems"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/yohamta/donburi"
+	"github.com/yohamta/donburi/ecs"
+)
+
+// LevelSelectScene lists campaign levels with their saved completion state.
+type LevelSelectScene struct {
+	ecs          *ecs.ECS
+	sceneChanger SceneChanger
+	once         sync.Once
+}
+
+// NewLevelSelectScene creates a new level select scene.
+func NewLevelSelectScene(sc SceneChanger) *LevelSelectScene {
+	return &LevelSelectScene{sceneChanger: sc}
+}
+
+func (ls *LevelSelectScene) Update() {
+	ls.once.Do(ls.configure)
+	ls.ecs.Update()
+}
+
+func (ls *LevelSelectScene) Draw(screen *ebiten.Image) {
+	screen.Fill(color.Black)
+	if ls.ecs == nil {
+		return
+	}
+	ls.ecs.Draw(screen)
+}
+
+func (ls *LevelSelectScene) configure() {
+	ls.ecs = ecs.NewECS(donburi.NewWorld())
+
+	entries := systems.LoadLevelSelectEntries()
+
+	createLevelScene := func(levelIndex int) interface{} {
+		return NewPlatformerSceneAtLevel(ls.sceneChanger, levelIndex, nil)
+	}
+	createMenuScene := NewMainMenuFactory(ls.sceneChanger)
+
+	ls.ecs.AddSystem(systems.UpdateAudio)
+	ls.ecs.AddSystem(systems.UpdateInput)
+	ls.ecs.AddSystem(systems.NewUpdateLevelSelect(ls.sceneChanger, entries, createLevelScene, createMenuScene))
+	ls.ecs.AddRenderer(cfg.Default, systems.DrawLevelSelect(entries))
+
+	systems.PlayMusic(ls.ecs, cfg.Sound.MenuMusic)
+}
@@ -50,8 +50,25 @@ func (ms *MenuScene) configure() {
 	createPlatformerScene := func() interface{} {
 		return NewPlatformerScene(ms.sceneChanger)
 	}
-	createRogueliteScene := func() interface{} {
-		return NewRogueliteScene(ms.sceneChanger)
+	scenes := systems.MenuScenes{
+		Roguelite: func() interface{} {
+			return NewRogueliteScene(ms.sceneChanger)
+		},
+		LevelSelect: func() interface{} {
+			return NewLevelSelectScene(ms.sceneChanger)
+		},
+		EnterSeed: func() interface{} {
+			return NewSeedEntryScene(ms.sceneChanger)
+		},
+		Daily: func() interface{} {
+			return NewDailyScene(ms.sceneChanger)
+		},
+		RunHistory: func() interface{} {
+			return NewRunHistoryScene(ms.sceneChanger)
+		},
+		LevelPacks: func() interface{} {
+			return NewLevelPacksScene(ms.sceneChanger)
+		},
 	}
 
 	// Audio system (runs first to initialize audio context)
@@ -59,7 +76,7 @@ func (ms *MenuScene) configure() {
 
 	// Minimal systems for menu
 	ms.ecs.AddSystem(systems.UpdateInput)
-	ms.ecs.AddSystem(systems.NewUpdateMenu(ms.sceneChanger, createPlatformerScene, createRogueliteScene))
+	ms.ecs.AddSystem(systems.NewUpdateMenu(ms.sceneChanger, createPlatformerScene, scenes))
 	ms.ecs.AddSystem(systems.UpdateSettingsMenu)
 
 	// Renderers (settings draws on top of menu)
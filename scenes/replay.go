@@ -0,0 +1,27 @@
+package scenes
+
+import (
+	"github.com/automoto/doomerang/components"
+	"github.com/automoto/doomerang/procgen"
+	"github.com/automoto/doomerang/systems"
+)
+
+// NewReplayScene recreates the scene a replay was recorded in and plays back its input.
+func NewReplayScene(sc SceneChanger, replay *components.ReplayData) interface{} {
+	if replay.Mode == components.ReplayRoguelite {
+		rs := NewRogueliteSceneWithOptions(sc, procgen.RunOptions{
+			Seed:   replay.Seed,
+			Length: replay.RunLength,
+			Biome:  replay.Biome,
+		})
+		rs.replay = replay
+		return rs
+	}
+
+	ps := NewPlatformerSceneAtLevel(sc, replay.LevelIndex, &systems.PlayerCarryover{
+		Lives:  replay.Lives,
+		Health: replay.Health,
+	})
+	ps.replay = replay
+	return ps
+}
@@ -25,14 +25,34 @@ type RogueliteScene struct {
 	ecs          *ecs.ECS
 	sceneChanger SceneChanger
 	once         sync.Once
-	seed         int64
+	opts         procgen.RunOptions
+	dailyDate    string                 // non-empty = today's daily run
+	replay       *components.ReplayData // non-nil = play back recorded input
 }
 
 // NewRogueliteScene creates a new roguelite scene with a random seed
 func NewRogueliteScene(sc SceneChanger) *RogueliteScene {
+	return NewRogueliteSceneWithSeed(sc, procgen.NewRunSeed())
+}
+
+// NewRogueliteSceneWithSeed creates a roguelite scene that generates the level for a specific seed
+func NewRogueliteSceneWithSeed(sc SceneChanger, seed int64) *RogueliteScene {
+	return NewRogueliteSceneWithOptions(sc, procgen.RunOptions{Seed: seed})
+}
+
+// NewDailyRunScene creates a roguelite scene for today's daily run
+func NewDailyRunScene(sc SceneChanger) *RogueliteScene {
+	now := time.Now()
+	rs := NewRogueliteSceneWithOptions(sc, procgen.DailyRunOptions(now))
+	rs.dailyDate = procgen.DailyKey(now)
+	return rs
+}
+
+// NewRogueliteSceneWithOptions creates a roguelite scene for an exact run, e.g. one decoded from a seed code
+func NewRogueliteSceneWithOptions(sc SceneChanger, opts procgen.RunOptions) *RogueliteScene {
 	return &RogueliteScene{
 		sceneChanger: sc,
-		seed:         time.Now().UnixNano(),
+		opts:         opts,
 	}
 }
 
@@ -41,7 +61,12 @@ func (rs *RogueliteScene) Update() {
 	rs.ecs.Update()
 
 	if rs.checkGameOver() {
+		rs.saveDailyResult(systems.RunDied)
+		systems.FinishRunHistory(rs.ecs, systems.RunDied)
 		retryFactory := func() interface{} {
+			if rs.dailyDate != "" {
+				return NewDailyRunScene(rs.sceneChanger)
+			}
 			return NewRogueliteScene(rs.sceneChanger)
 		}
 		rs.sceneChanger.ChangeScene(NewGameOverScene(rs.sceneChanger, retryFactory))
@@ -56,6 +81,20 @@ func (rs *RogueliteScene) checkGameOver() bool {
 	return !ok
 }
 
+// saveDailyResult records how the day's scored daily attempt ended
+func (rs *RogueliteScene) saveDailyResult(outcome systems.RunOutcome) {
+	if rs.dailyDate == "" {
+		return
+	}
+	snap := systems.SnapshotRunStats(rs.ecs)
+	if !snap.DailyScored {
+		return
+	}
+	if err := systems.SaveDailyResult(rs.dailyDate, outcome, snap); err != nil {
+		log.Printf("Warning: Could not save daily result: %v", err)
+	}
+}
+
 func (rs *RogueliteScene) Draw(screen *ebiten.Image) {
 	screen.Fill(color.Black)
 	if rs.ecs == nil {
@@ -69,47 +108,38 @@ func (rs *RogueliteScene) configure() {
 	assets.PreloadAllAnimations()
 
 	// Generate the procedural level
-	level, result, err := rs.generateLevel()
+	run, err := procgen.GenerateRun(rs.opts)
 	if err != nil {
 		log.Printf("Procgen failed: %v, falling back to campaign", err)
 		rs.sceneChanger.ChangeScene(NewPlatformerScene(rs.sceneChanger))
 		return
 	}
-	// Build room boundaries: right-edge X of each placed chunk
-	roomBoundaries := make([]float64, len(result.PlacedChunks))
-	for i, pc := range result.PlacedChunks {
-		roomBoundaries[i] = pc.OffsetX + float64(pc.Chunk.Width)
+	level, result := run.Level, run.Result
+
+	// The resolved length and biome keep the code valid if the defaults change
+	seedCode, err := procgen.EncodeSeedCode(run.Options)
+	if err != nil {
+		log.Printf("Warning: Could not encode seed code: %v", err)
 	}
 
 	e := ecs.NewECS(donburi.NewWorld())
 
 	// Closure that snapshots run stats and creates the summary scene
 	createSummaryScene := func() interface{} {
+		rs.saveDailyResult(systems.RunCleared)
+		systems.FinishRunHistory(e, systems.RunCleared)
 		snap := systems.SnapshotRunStats(e)
 		return NewRunSummaryScene(rs.sceneChanger, snap)
 	}
 
+	// Only the first daily attempt each day is scored; replays never are
+	dailyScored := rs.dailyDate != "" && rs.replay == nil && systems.StartDailyAttempt(rs.dailyDate, seedCode)
+
 	// Same systems as PlatformerScene
 	e.AddSystem(systems.UpdateAudio)
 	e.AddSystem(systems.UpdateInput)
 	e.AddSystem(systems.UpdatePause)
-	e.AddSystem(systems.WithGameplayChecks(systems.UpdatePlayer))
-	e.AddSystem(systems.WithGameplayChecks(systems.UpdateEnemies))
-	e.AddSystem(systems.WithGameplayChecks(systems.UpdateStates))
-	e.AddSystem(systems.WithGameplayChecks(systems.UpdatePhysics))
-	e.AddSystem(systems.WithGameplayChecks(systems.UpdateCollisions))
-	e.AddSystem(systems.WithGameplayChecks(systems.UpdateObjects))
-	e.AddSystem(systems.WithGameplayChecks(systems.UpdateBoomerang))
-	e.AddSystem(systems.WithGameplayChecks(systems.UpdateKnives))
-	e.AddSystem(systems.WithGameplayChecks(systems.UpdateCombat))
-	e.AddSystem(systems.WithGameplayChecks(systems.UpdateCombatHitboxes))
-	e.AddSystem(systems.WithGameplayChecks(systems.UpdateDeaths))
-	e.AddSystem(systems.WithGameplayChecks(systems.UpdateRunStats))
-	e.AddSystem(systems.WithGameplayChecks(systems.UpdateCheckpoints))
-	e.AddSystem(systems.WithGameplayChecks(systems.UpdateFire))
-	e.AddSystem(systems.WithGameplayChecks(systems.UpdateEffects))
-	e.AddSystem(systems.WithGameplayChecks(systems.UpdateMessage))
-	e.AddSystem(systems.WithGameplayChecks(systems.UpdateFinishLine))
+	systems.AddGameplaySystems(e)
 	e.AddSystem(systems.NewUpdateRogueliteFinish(rs.sceneChanger, createSummaryScene))
 	e.AddSystem(systems.UpdateSettings)
 	e.AddSystem(systems.UpdateSettingsMenu)
@@ -118,6 +148,7 @@ func (rs *RogueliteScene) configure() {
 	e.AddRenderer(cfg.Default, systems.DrawLevel)
 	e.AddRenderer(cfg.Default, systems.DrawAnimated)
 	e.AddRenderer(cfg.Default, systems.DrawSprites)
+	e.AddRenderer(cfg.Default, systems.DrawBoomerangAim)
 	e.AddRenderer(cfg.Default, systems.DrawHealthBars)
 	e.AddRenderer(cfg.Default, systems.DrawHitboxes)
 	e.AddRenderer(cfg.Default, systems.DrawHUD)
@@ -131,20 +162,20 @@ func (rs *RogueliteScene) configure() {
 
 	// Create RunStats entity for this run
 	runStatsEntry := e.World.Entry(e.Create(cfg.Default, components.RunStats))
-	components.RunStats.SetValue(runStatsEntry, components.RunStatsData{
-		Seed:           rs.seed,
-		TotalRooms:     len(result.PlacedChunks),
-		RoomBoundaries: roomBoundaries,
-	})
+	runStats := systems.NewRunStats(result)
+	runStats.Seed = rs.opts.Seed
+	runStats.SeedCode = seedCode
+	runStats.DailyDate = rs.dailyDate
+	runStats.DailyScored = dailyScored
+	components.RunStats.SetValue(runStatsEntry, runStats)
 
-	// Create level entity with procgen level
-	levelEntry := archetypeSpawnLevel(e)
-	levelData := &components.LevelData{
-		Levels:       []assets.Level{*level},
-		LevelIndex:   0,
-		CurrentLevel: level,
+	// Replays are playbacks of runs already in the history
+	if rs.replay == nil {
+		systems.StartRunHistory(e)
 	}
-	components.Level.Set(levelEntry, levelData)
+
+	// Create level entity with procgen level
+	factory2.CreateGeneratedLevel(e, level)
 
 	// Create space
 	spaceEntry := factory2.CreateSpace(e, level.Width, level.Height, 16, 16)
@@ -153,34 +184,8 @@ func (rs *RogueliteScene) configure() {
 	// Create camera
 	factory2.CreateCamera(e)
 
-	// Create collision objects from solid tiles
-	for _, tile := range level.SolidTiles {
-		if tile.SlopeType != "" {
-			factory2.CreateSlopeWall(e, tile.X, tile.Y, tile.Width, tile.Height, tile.SlopeType)
-		} else {
-			factory2.CreateWall(e, tile.X, tile.Y, tile.Width, tile.Height)
-		}
-	}
-
-	// Create dead zones
-	for _, dz := range level.DeadZones {
-		factory2.CreateDeadZone(e, dz.X, dz.Y, dz.Width, dz.Height)
-	}
-
-	// Create checkpoints
-	for _, ckp := range level.Checkpoints {
-		factory2.CreateCheckpoint(e, ckp.X, ckp.Y, ckp.Width, ckp.Height, ckp.CheckpointID)
-	}
-
-	// Create fires
-	for _, fire := range level.Fires {
-		factory2.CreateFire(e, fire.X, fire.Y, fire.FireType, fire.Direction)
-	}
-
-	// Create finish lines
-	for _, fl := range level.FinishLines {
-		factory2.CreateFinishLine(e, fl.X, fl.Y, fl.Width, fl.Height)
-	}
+	// Create collision geometry and level objects
+	factory2.CreateLevelObjects(e, level)
 
 	// Spawn player
 	spawn := level.PlayerSpawns[0]
@@ -196,107 +201,23 @@ func (rs *RogueliteScene) configure() {
 	}
 
 	// Spawn enemies
-	for _, es := range level.EnemySpawns {
-		enemyType := es.EnemyType
-		if enemyType == "" {
-			enemyType = "Guard"
-		}
-		enemy := factory2.CreateEnemy(e, es.X, es.Y, es.PatrolPath, enemyType)
-		enemyObj := components.Object.Get(enemy)
-		space.Add(enemyObj.Object)
+	factory2.CreateLevelEnemies(e, level)
+
+	systems.StartTelemetry(e, "roguelite "+seedCode)
+
+	// Replay playback or recording starts once the world is fully built
+	if rs.replay != nil {
+		systems.StartReplayPlayback(e, rs.replay)
+	} else if cfg.Debug.RecordReplayPath != "" {
+		recording := systems.StartReplayRecording(e, components.ReplayRoguelite, rs.opts.Seed)
+		// Resolved like the seed code, so the replay survives new defaults
+		recording.RunLength = run.Options.Length
+		recording.Biome = run.Options.Biome
 	}
 
 	if len(cfg.Sound.RogueliteMusic) > 0 {
-		musicRng := rand.New(rand.NewSource(rs.seed))
+		musicRng := rand.New(rand.NewSource(rs.opts.Seed))
 		track := cfg.Sound.RogueliteMusic[musicRng.Intn(len(cfg.Sound.RogueliteMusic))]
 		systems.PlayMusic(e, track)
 	}
 }
-
-func (rs *RogueliteScene) generateLevel() (*assets.Level, *procgen.GenerationResult, error) {
-	loader := procgen.NewChunkLoader()
-	chunks, err := loader.LoadAllChunks("chunks")
-	if err != nil {
-		return nil, nil, err
-	}
-
-	rng := rand.New(rand.NewSource(rs.seed))
-
-	// Pick a single biome for the entire run based on seed
-	biome := "cyberpunk"
-	if len(cfg.Procgen.Biomes) > 0 {
-		biome = cfg.Procgen.Biomes[rng.Intn(len(cfg.Procgen.Biomes))]
-	}
-
-	// Generate concept graph with pacing rules
-	graph := procgen.GenerateGraph(rng, cfg.Procgen.DefaultRunLength, []string{biome})
-	procgen.ValidateGraph(graph)
-
-	// Generate chunks with solvability validation (retries up to 5 times)
-	generator := procgen.NewChunkGenerator(rs.seed)
-	result, err := procgen.ValidateAndRemediate(generator, chunks, graph, 5)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	// Derive decorative variation (background image + color tint) from seed + biome
-	decoration := procgen.DeriveDecoration(rs.seed, biome)
-	defer func() {
-		if decoration.BackgroundImage != nil {
-			decoration.BackgroundImage.Deallocate()
-		}
-	}()
-
-	// Compile base level with decoration
-	compiler := procgen.NewCompiler()
-	level, err := compiler.Compile(result, &decoration)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	// Dynamic enemy placement
-	enemyPlacer := procgen.NewEnemyPlacer(rng)
-	for i, pc := range result.PlacedChunks {
-		if i < len(graph.Nodes) && (graph.Nodes[i].Type == procgen.NodeCombat || graph.Nodes[i].Type == procgen.NodeArena) {
-			spawns, patrolPaths := enemyPlacer.PlaceEnemies(pc, graph.Nodes[i].Difficulty)
-			level.EnemySpawns = append(level.EnemySpawns, spawns...)
-			for name, path := range patrolPaths {
-				level.PatrolPaths[name] = path
-			}
-		}
-	}
-
-	// Dynamic hazard placement
-	hazardPlacer := procgen.NewHazardPlacer(rng)
-	for i, pc := range result.PlacedChunks {
-		diff := 1
-		if i < len(graph.Nodes) {
-			diff = graph.Nodes[i].Difficulty
-		}
-		deadZones, fires := hazardPlacer.PlaceHazards(pc, diff)
-		level.DeadZones = append(level.DeadZones, deadZones...)
-		level.Fires = append(level.Fires, fires...)
-	}
-
-	// Auto-place checkpoints at break rooms
-	checkpointID := 1.0
-	for i, pc := range result.PlacedChunks {
-		if i < len(graph.Nodes) && graph.Nodes[i].Type == procgen.NodeBreakRoom {
-			level.Checkpoints = append(level.Checkpoints, assets.CheckpointSpawn{
-				X:            pc.OffsetX + float64(pc.Chunk.Width)/2,
-				Y:            pc.OffsetY + float64(pc.Chunk.Height) - 80,
-				Width:        32,
-				Height:       48,
-				CheckpointID: checkpointID,
-			})
-			checkpointID++
-		}
-	}
-
-	return level, result, nil
-}
-
-// archetypeSpawnLevel creates a level entity (avoids importing archetypes to prevent cycles)
-func archetypeSpawnLevel(e *ecs.ECS) *donburi.Entry {
-	return e.World.Entry(e.Create(cfg.Default, components.Level))
-}
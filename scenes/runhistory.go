@@ -0,0 +1,61 @@
+package scenes
+
+import (
+	"image/color"
+	"sync"
+
+	cfg "github.com/automoto/doomerang/config"
+	"github.com/automoto/doomerang/procgen"
+	"github.com/automoto/doomerang/systems"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/yohamta/donburi"
+	"github.com/yohamta/donburi/ecs"
+)
+
+// RunHistoryScene lists past roguelite runs and shows the details of each.
+type RunHistoryScene struct {
+	ecs          *ecs.ECS
+	sceneChanger SceneChanger
+	once         sync.Once
+}
+
+// NewRunHistoryScene creates a new run history scene.
+func NewRunHistoryScene(sc SceneChanger) *RunHistoryScene {
+	return &RunHistoryScene{sceneChanger: sc}
+}
+
+func (hs *RunHistoryScene) Update() {
+	hs.once.Do(hs.configure)
+	hs.ecs.Update()
+}
+
+func (hs *RunHistoryScene) Draw(screen *ebiten.Image) {
+	screen.Fill(color.Black)
+	if hs.ecs == nil {
+		return
+	}
+	hs.ecs.Draw(screen)
+}
+
+func (hs *RunHistoryScene) configure() {
+	hs.ecs = ecs.NewECS(donburi.NewWorld())
+
+	records, _ := systems.LoadRunHistory()
+
+	// Replaying a run plays its seed again; it is a new run, not a daily attempt
+	createRunScene := func(record systems.SavedRunRecord) interface{} {
+		opts, err := procgen.DecodeSeedCode(record.SeedCode)
+		if err != nil {
+			opts = procgen.RunOptions{Seed: record.Seed}
+		}
+		return NewRogueliteSceneWithOptions(hs.sceneChanger, opts)
+	}
+	createMenuScene := NewMainMenuFactory(hs.sceneChanger)
+
+	hs.ecs.AddSystem(systems.UpdateAudio)
+	hs.ecs.AddSystem(systems.UpdateInput)
+	hs.ecs.AddSystem(systems.NewUpdateRunHistory(hs.sceneChanger, records, createRunScene, createMenuScene))
+	hs.ecs.AddRenderer(cfg.Default, systems.DrawRunHistory(records))
+
+	systems.PlayMusic(hs.ecs, cfg.Sound.MenuMusic)
+}
@@ -41,8 +41,11 @@ func (rs *RunSummaryScene) Draw(screen *ebiten.Image) {
 }
 
 func (rs *RunSummaryScene) configure() {
-	// Persist lifetime stats before anything else
-	_ = systems.SaveRogueliteLifetimeStats(rs.stats)
+	// Persist lifetime stats before anything else. A replay is a playback
+	// of a run that already counted.
+	if !rs.stats.Replay {
+		_ = systems.SaveRogueliteLifetimeStats(rs.stats)
+	}
 
 	rs.ecs = ecs.NewECS(donburi.NewWorld())
 
@@ -0,0 +1,54 @@
+package scenes
+
+import (
+	"image/color"
+	"sync"
+
+	cfg "github.com/automoto/doomerang/config"
+	"github.com/automoto/doomerang/procgen"
+	"github.com/automoto/doomerang/systems"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/yohamta/donburi"
+	"github.com/yohamta/donburi/ecs"
+)
+
+// SeedEntryScene lets the player type a shared seed code and start that run.
+type SeedEntryScene struct {
+	ecs          *ecs.ECS
+	sceneChanger SceneChanger
+	once         sync.Once
+}
+
+// NewSeedEntryScene creates a new seed entry scene.
+func NewSeedEntryScene(sc SceneChanger) *SeedEntryScene {
+	return &SeedEntryScene{sceneChanger: sc}
+}
+
+func (ss *SeedEntryScene) Update() {
+	ss.once.Do(ss.configure)
+	ss.ecs.Update()
+}
+
+func (ss *SeedEntryScene) Draw(screen *ebiten.Image) {
+	screen.Fill(color.Black)
+	if ss.ecs == nil {
+		return
+	}
+	ss.ecs.Draw(screen)
+}
+
+func (ss *SeedEntryScene) configure() {
+	ss.ecs = ecs.NewECS(donburi.NewWorld())
+
+	createRogueliteScene := func(opts procgen.RunOptions) interface{} {
+		return NewRogueliteSceneWithOptions(ss.sceneChanger, opts)
+	}
+	createMenuScene := NewMainMenuFactory(ss.sceneChanger)
+
+	ss.ecs.AddSystem(systems.UpdateAudio)
+	ss.ecs.AddSystem(systems.UpdateInput)
+	ss.ecs.AddSystem(systems.NewUpdateSeedEntry(ss.sceneChanger, createRogueliteScene, createMenuScene))
+	ss.ecs.AddRenderer(cfg.Default, systems.DrawSeedEntry)
+
+	systems.PlayMusic(ss.ecs, cfg.Sound.MenuMusic)
+}
@@ -22,11 +22,26 @@ type PlatformerScene struct {
 	ecs          *ecs.ECS
 	sceneChanger SceneChanger
 	once         sync.Once
+	levelIndex   int                      // -1 = resume from saved progress
+	campaign     bool                     // Saves progress: resumed from the menu, or continued from a campaign level
+	carryover    *systems.PlayerCarryover // lives/health carried from the previous level
+	replay       *components.ReplayData   // non-nil = play back recorded input
 }
 
-// NewPlatformerScene creates a new platformer scene
+// NewPlatformerScene creates a new platformer scene that resumes the campaign
+// at the saved level, or starts at the first level when there is no save.
 func NewPlatformerScene(sc SceneChanger) *PlatformerScene {
-	return &PlatformerScene{sceneChanger: sc}
+	return &PlatformerScene{sceneChanger: sc, levelIndex: -1, campaign: true}
+}
+
+// NewPlatformerSceneAtLevel creates a platformer scene for a specific campaign level.
+// A non-nil carryover restores the player's lives and health from the previous level.
+func NewPlatformerSceneAtLevel(sc SceneChanger, levelIndex int, carryover *systems.PlayerCarryover) *PlatformerScene {
+	return &PlatformerScene{
+		sceneChanger: sc,
+		levelIndex:   levelIndex,
+		carryover:    carryover,
+	}
 }
 
 func (ps *PlatformerScene) Update() {
@@ -50,6 +65,23 @@ func (ps *PlatformerScene) checkGameOver() bool {
 	return !ok
 }
 
+// createLevelScene builds the scene for the next campaign level. Only a
+// campaign run keeps saving progress in the next level.
+func (ps *PlatformerScene) createLevelScene(levelIndex int, carryover *systems.PlayerCarryover) interface{} {
+	next := NewPlatformerSceneAtLevel(ps.sceneChanger, levelIndex, carryover)
+	next.campaign = ps.campaign
+	return next
+}
+
+// createCampaignCompleteScene builds the screen shown after the final level
+func (ps *PlatformerScene) createCampaignCompleteScene() interface{} {
+	levelCount := 0
+	if levelEntry, ok := components.Level.First(ps.ecs.World); ok {
+		levelCount = len(components.Level.Get(levelEntry).Levels)
+	}
+	return NewCampaignCompleteScene(ps.sceneChanger, levelCount)
+}
+
 func (ps *PlatformerScene) Draw(screen *ebiten.Image) {
 	// Always clear screen to prevent white flashes from OS window background
 	screen.Fill(color.Black)
@@ -75,23 +107,8 @@ func (ps *PlatformerScene) configure() {
 	ecs.AddSystem(systems.UpdatePause)
 
 	// Game systems wrapped with pause and level complete checks
-	ecs.AddSystem(systems.WithGameplayChecks(systems.UpdatePlayer))
-	ecs.AddSystem(systems.WithGameplayChecks(systems.UpdateEnemies))
-	ecs.AddSystem(systems.WithGameplayChecks(systems.UpdateStates))
-	ecs.AddSystem(systems.WithGameplayChecks(systems.UpdatePhysics))
-	ecs.AddSystem(systems.WithGameplayChecks(systems.UpdateCollisions))
-	ecs.AddSystem(systems.WithGameplayChecks(systems.UpdateObjects))
-	ecs.AddSystem(systems.WithGameplayChecks(systems.UpdateBoomerang))
-	ecs.AddSystem(systems.WithGameplayChecks(systems.UpdateKnives))
-	ecs.AddSystem(systems.WithGameplayChecks(systems.UpdateCombat))
-	ecs.AddSystem(systems.WithGameplayChecks(systems.UpdateCombatHitboxes))
-	ecs.AddSystem(systems.WithGameplayChecks(systems.UpdateDeaths))
-	ecs.AddSystem(systems.WithGameplayChecks(systems.UpdateCheckpoints))
-	ecs.AddSystem(systems.WithGameplayChecks(systems.UpdateFire))
-	ecs.AddSystem(systems.WithGameplayChecks(systems.UpdateEffects))
-	ecs.AddSystem(systems.WithGameplayChecks(systems.UpdateMessage))
-	ecs.AddSystem(systems.WithGameplayChecks(systems.UpdateFinishLine))
-	ecs.AddSystem(systems.UpdateLevelComplete)
+	systems.AddGameplaySystems(ecs)
+	ecs.AddSystem(systems.NewUpdateLevelComplete(ps.sceneChanger, ps.createLevelScene, ps.createCampaignCompleteScene))
 
 	// Systems that run even when paused
 	ecs.AddSystem(systems.UpdateSettings)
@@ -102,6 +119,7 @@ func (ps *PlatformerScene) configure() {
 	ecs.AddRenderer(cfg.Default, systems.DrawLevel)
 	ecs.AddRenderer(cfg.Default, systems.DrawAnimated)
 	ecs.AddRenderer(cfg.Default, systems.DrawSprites)
+	ecs.AddRenderer(cfg.Default, systems.DrawBoomerangAim)
 	ecs.AddRenderer(cfg.Default, systems.DrawHealthBars)
 	ecs.AddRenderer(cfg.Default, systems.DrawHitboxes)
 	ecs.AddRenderer(cfg.Default, systems.DrawHUD)
@@ -113,9 +131,20 @@ func (ps *PlatformerScene) configure() {
 
 	ps.ecs = ecs
 
+	// Resolve which campaign level to load
+	progress, _ := systems.LoadGameProgress()
+	levelIndex := ps.levelIndex
+	if levelIndex < 0 {
+		levelIndex = 0
+		if progress != nil {
+			levelIndex = progress.LevelIndex
+		}
+	}
+
 	// Create the level entity and load level data FIRST.
-	level := factory2.CreateLevel(ps.ecs)
+	level := factory2.CreateLevel(ps.ecs, levelIndex)
 	levelData := components.Level.Get(level)
+	levelData.Campaign = ps.campaign
 
 	// Now create the space for collision detection using the level's dimensions.
 	spaceEntry := factory2.CreateSpace(ps.ecs,
@@ -128,46 +157,15 @@ func (ps *PlatformerScene) configure() {
 	// Create camera
 	factory2.CreateCamera(ps.ecs)
 
-	// Create collision objects from solid tiles
-	for _, tile := range levelData.CurrentLevel.SolidTiles {
-		if tile.SlopeType != "" {
-			factory2.CreateSlopeWall(ps.ecs, tile.X, tile.Y, tile.Width, tile.Height, tile.SlopeType)
-		} else {
-			factory2.CreateWall(ps.ecs, tile.X, tile.Y, tile.Width, tile.Height)
-		}
-	}
-
-	// Create dead zones from the level
-	for _, dz := range levelData.CurrentLevel.DeadZones {
-		factory2.CreateDeadZone(ps.ecs, dz.X, dz.Y, dz.Width, dz.Height)
-	}
-
-	// Create checkpoints from the level
-	for _, ckp := range levelData.CurrentLevel.Checkpoints {
-		factory2.CreateCheckpoint(ps.ecs, ckp.X, ckp.Y, ckp.Width, ckp.Height, ckp.CheckpointID)
-	}
-
-	// Create fire obstacles from the level
-	for _, fire := range levelData.CurrentLevel.Fires {
-		factory2.CreateFire(ps.ecs, fire.X, fire.Y, fire.FireType, fire.Direction)
-	}
-
-	// Create message points from the level
-	for _, msg := range levelData.CurrentLevel.Messages {
-		factory2.CreateMessagePoint(ps.ecs, msg.X, msg.Y, msg.MessageID)
-	}
-
-	// Create finish lines from the level
-	for _, fl := range levelData.CurrentLevel.FinishLines {
-		factory2.CreateFinishLine(ps.ecs, fl.X, fl.Y, fl.Width, fl.Height)
-	}
+	// Create collision geometry and level objects
+	factory2.CreateLevelObjects(ps.ecs, levelData.CurrentLevel)
 
 	// Determine player spawn position
 	var playerSpawnX, playerSpawnY float64
 	var foundCheckpoint bool
 
-	// Load saved checkpoint progress
-	if progress, _ := systems.LoadGameProgress(); progress != nil && progress.LevelIndex == levelData.LevelIndex {
+	// Load saved checkpoint progress (only when resuming, not when a level was picked explicitly)
+	if ps.levelIndex < 0 && progress != nil && !progress.LevelStart && progress.LevelIndex == levelData.LevelIndex {
 		levelData.ActiveCheckpoint = &components.ActiveCheckpointData{
 			SpawnX:       progress.CheckpointSpawnX,
 			SpawnY:       progress.CheckpointSpawnY,
@@ -178,6 +176,18 @@ func (ps *PlatformerScene) configure() {
 		foundCheckpoint = true
 	}
 
+	// Replays start from the exact checkpoint they were recorded at
+	if ps.replay != nil && ps.replay.CheckpointID >= 0 {
+		levelData.ActiveCheckpoint = &components.ActiveCheckpointData{
+			SpawnX:       ps.replay.SpawnX,
+			SpawnY:       ps.replay.SpawnY,
+			CheckpointID: ps.replay.CheckpointID,
+		}
+		playerSpawnX = ps.replay.SpawnX
+		playerSpawnY = ps.replay.SpawnY
+		foundCheckpoint = true
+	}
+
 	// Check if we should spawn at a specific checkpoint (debug/testing) - overrides saved progress
 	if cfg.Debug.StartCheckpoint >= 0 {
 		for _, ckp := range levelData.CurrentLevel.Checkpoints {
@@ -213,6 +223,7 @@ func (ps *PlatformerScene) configure() {
 
 	// Create the player at the determined position
 	player := factory2.CreatePlayer(ps.ecs, playerSpawnX, playerSpawnY)
+	systems.ApplyPlayerCarryover(player, ps.carryover)
 	playerObj := components.Object.Get(player)
 	space.Add(playerObj.Object)
 
@@ -224,15 +235,15 @@ func (ps *PlatformerScene) configure() {
 	}
 
 	// Spawn enemies for the current level
-	for _, spawn := range levelData.CurrentLevel.EnemySpawns {
-		// Use the enemy type from the spawn data, default to "Guard" if not specified
-		enemyType := spawn.EnemyType
-		if enemyType == "" {
-			enemyType = "Guard"
-		}
-		enemy := factory2.CreateEnemy(ps.ecs, spawn.X, spawn.Y, spawn.PatrolPath, enemyType)
-		enemyObj := components.Object.Get(enemy)
-		space.Add(enemyObj.Object)
+	factory2.CreateLevelEnemies(ps.ecs, levelData.CurrentLevel)
+
+	systems.StartTelemetry(ps.ecs, levelData.CurrentLevel.Name)
+
+	// Replay playback or recording starts once the world is fully built
+	if ps.replay != nil {
+		systems.StartReplayPlayback(ps.ecs, ps.replay)
+	} else if cfg.Debug.RecordReplayPath != "" {
+		systems.StartReplayRecording(ps.ecs, components.ReplayCampaign, 0)
 	}
 
 	// Start level music from config
@@ -0,0 +1,189 @@
+// Package sim runs the gameplay ECS pipeline headlessly: no window, no audio and
+// no rendering. It builds the same world and system list as the campaign and
+// roguelite scenes and steps it tick by tick with scripted input, so gameplay
+// can be tested in CI on machines without a GPU.
+package sim
+
+import (
+	"errors"
+
+	"github.com/automoto/doomerang/assets"
+	"github.com/automoto/doomerang/components"
+	cfg "github.com/automoto/doomerang/config"
+	"github.com/automoto/doomerang/procgen"
+	"github.com/automoto/doomerang/systems"
+	"github.com/automoto/doomerang/systems/factory"
+	"github.com/automoto/doomerang/tags"
+	"github.com/yohamta/donburi"
+	"github.com/yohamta/donburi/ecs"
+)
+
+// Script returns the actions held down on a given tick (ticks start at 0).
+type Script func(tick int) []cfg.ActionID
+
+// Hold returns a script that holds the same actions on every tick.
+func Hold(actions ...cfg.ActionID) Script {
+	return func(int) []cfg.ActionID {
+		return actions
+	}
+}
+
+// Idle is a script that presses nothing.
+var Idle = Hold()
+
+// Sim is a headless gameplay world.
+type Sim struct {
+	ECS    *ecs.ECS
+	Tick   int
+	script Script
+}
+
+// NewCampaign builds the world for a campaign level, spawning the player at the level start.
+func NewCampaign(levelIndex int) (*Sim, error) {
+	if err := assets.LoadEnemyTypes(); err != nil {
+		return nil, err
+	}
+	s := newSim()
+
+	levelEntry := factory.CreateLevel(s.ECS, levelIndex)
+	if err := s.populate(components.Level.Get(levelEntry).CurrentLevel); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// NewRoguelite builds the world for the roguelite run generated from seed.
+func NewRoguelite(seed int64) (*Sim, error) {
+	if err := assets.LoadEnemyTypes(); err != nil {
+		return nil, err
+	}
+	level, result, err := procgen.GenerateRunLevel(seed)
+	if err != nil {
+		return nil, err
+	}
+
+	s := newSim()
+	runStatsEntry := s.ECS.World.Entry(s.ECS.Create(cfg.Default, components.RunStats))
+	runStats := systems.NewRunStats(result)
+	runStats.Seed = seed
+	components.RunStats.SetValue(runStatsEntry, runStats)
+
+	factory.CreateGeneratedLevel(s.ECS, level)
+	if err := s.populate(level); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// NewFromLevel builds the world for a hand-built level, e.g. a test arena.
+func NewFromLevel(level *assets.Level) (*Sim, error) {
+	if err := assets.LoadEnemyTypes(); err != nil {
+		return nil, err
+	}
+	s := newSim()
+	factory.CreateGeneratedLevel(s.ECS, level)
+	if err := s.populate(level); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func newSim() *Sim {
+	systems.DisableAudio()
+
+	e := ecs.NewECS(donburi.NewWorld())
+	s := &Sim{ECS: e, script: Idle}
+
+	// Same order as the gameplay scenes, with scripted input in place of
+	// device polling and without audio, pause menus or scene transitions.
+	e.AddSystem(s.updateInput)
+	systems.AddGameplaySystems(e)
+	e.AddSystem(systems.WithGameplayChecks(systems.UpdateCamera))
+
+	return s
+}
+
+// populate creates the space, level objects, player and enemies for level
+func (s *Sim) populate(level *assets.Level) error {
+	if len(level.PlayerSpawns) == 0 {
+		return errors.New("no player spawn points defined in level")
+	}
+
+	factory.CreateSpace(s.ECS, level.Width, level.Height, 16, 16)
+	factory.CreateCamera(s.ECS)
+	factory.CreateLevelObjects(s.ECS, level)
+
+	spawn := level.PlayerSpawns[0]
+	player := factory.CreatePlayer(s.ECS, spawn.X, spawn.Y)
+	spaceEntry, _ := components.Space.First(s.ECS.World)
+	components.Space.Get(spaceEntry).Add(components.Object.Get(player).Object)
+
+	factory.CreateLevelEnemies(s.ECS, level)
+	return nil
+}
+
+// SetScript replaces the input script. Script ticks continue from the current Tick.
+func (s *Sim) SetScript(script Script) {
+	s.script = script
+}
+
+// Step advances the simulation by n ticks.
+func (s *Sim) Step(n int) {
+	for i := 0; i < n; i++ {
+		s.ECS.Update()
+		s.Tick++
+	}
+}
+
+// RunUntil steps until done returns true or maxTicks have elapsed.
+// Returns whether done was satisfied.
+func (s *Sim) RunUntil(maxTicks int, done func(*Sim) bool) bool {
+	for i := 0; i < maxTicks; i++ {
+		if done(s) {
+			return true
+		}
+		s.Step(1)
+	}
+	return done(s)
+}
+
+// Player returns the player entity, or false once it has been removed (game over).
+func (s *Sim) Player() (*donburi.Entry, bool) {
+	return tags.Player.First(s.ECS.World)
+}
+
+// EnemyCount returns the number of enemies still in the world.
+func (s *Sim) EnemyCount() int {
+	count := 0
+	tags.Enemy.Each(s.ECS.World, func(*donburi.Entry) {
+		count++
+	})
+	return count
+}
+
+// LevelComplete reports whether the player has reached the finish line.
+func (s *Sim) LevelComplete() bool {
+	return systems.GetOrCreateLevelComplete(s.ECS).IsComplete
+}
+
+// updateInput feeds the script's actions into the input component,
+// the same way UpdateInput does for keyboard and gamepad. Replays started
+// with systems.StartReplayRecording or StartReplayPlayback work as in the scenes.
+func (s *Sim) updateInput(e *ecs.ECS) {
+	entry, ok := components.Input.First(e.World)
+	if !ok {
+		entry = e.World.Entry(e.World.Create(components.Input))
+	}
+	input := components.Input.Get(entry)
+
+	input.Previous = input.Current
+	input.Current = [cfg.ActionCount]bool{}
+	input.Aiming = false
+	if systems.ApplyReplayInput(e, input) {
+		return
+	}
+	for _, action := range s.script(s.Tick) {
+		input.Current[action] = true
+	}
+	systems.RecordReplayInput(e, input)
+}
@@ -0,0 +1,726 @@
+package sim_test
+
+import (
+	"bytes"
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/automoto/doomerang/assets"
+	"github.com/automoto/doomerang/components"
+	cfg "github.com/automoto/doomerang/config"
+	"github.com/automoto/doomerang/procgen"
+	"github.com/automoto/doomerang/sim"
+	"github.com/automoto/doomerang/systems"
+	"github.com/automoto/doomerang/tags"
+	"github.com/yohamta/donburi"
+)
+
+// newArena returns a flat-floored test level with the player on the left
+// and the given enemies spawned to the right.
+func newArena(enemies ...assets.EnemySpawn) *assets.Level {
+	const tile = 16.0
+	level := &assets.Level{
+		PatrolPaths:  make(map[string]assets.PatrolPath),
+		PlayerSpawns: []assets.PlayerSpawn{{X: 64, Y: 200}},
+		EnemySpawns:  enemies,
+		Width:        800,
+		Height:       320,
+	}
+	for x := 0.0; x < float64(level.Width); x += tile {
+		level.SolidTiles = append(level.SolidTiles, assets.SolidTile{X: x, Y: 256, Width: tile, Height: tile})
+	}
+	return level
+}
+
+// newWalledArena returns newArena with a wall from the floor to the top of
+// the level at x
+func newWalledArena(x float64) *assets.Level {
+	level := newArena()
+	for y := 0.0; y < 256; y += 16 {
+		level.SolidTiles = append(level.SolidTiles, assets.SolidTile{X: x, Y: y, Width: 16, Height: 16})
+	}
+	return level
+}
+
+// chargedThrow holds the boomerang button until the throw is fully charged,
+// then lets go
+func chargedThrow(tick int) []cfg.ActionID {
+	if tick < cfg.Boomerang.MaxChargeTime+10 {
+		return []cfg.ActionID{cfg.ActionBoomerang}
+	}
+	return nil
+}
+
+// throwEvery taps the boomerang button for a few ticks at a fixed interval.
+func throwEvery(interval int) sim.Script {
+	return func(tick int) []cfg.ActionID {
+		if tick%interval < 4 {
+			return []cfg.ActionID{cfg.ActionBoomerang}
+		}
+		return nil
+	}
+}
+
+func TestPlayerSettlesOnFloor(t *testing.T) {
+	s, err := sim.NewFromLevel(newArena())
+	if err != nil {
+		t.Fatalf("NewFromLevel failed: %v", err)
+	}
+
+	s.Step(60)
+
+	if _, ok := s.Player(); !ok {
+		t.Fatal("expected player to still exist after 60 idle ticks")
+	}
+}
+
+func TestBoomerangKillsGuard(t *testing.T) {
+	s, err := sim.NewFromLevel(newArena(assets.EnemySpawn{X: 220, Y: 200, EnemyType: "Guard"}))
+	if err != nil {
+		t.Fatalf("NewFromLevel failed: %v", err)
+	}
+	if s.EnemyCount() != 1 {
+		t.Fatalf("expected 1 enemy, got %d", s.EnemyCount())
+	}
+
+	s.SetScript(throwEvery(60))
+	killed := s.RunUntil(600, func(s *sim.Sim) bool { return s.EnemyCount() == 0 })
+
+	if !killed {
+		t.Errorf("expected guard to die within 600 ticks, %d enemies remain", s.EnemyCount())
+	}
+}
+
+func TestBoomerangRicochetsOffWall(t *testing.T) {
+	bounces := cfg.Boomerang.RicochetBounces
+	t.Cleanup(func() { cfg.Boomerang.RicochetBounces = bounces })
+	cfg.Boomerang.RicochetBounces = 1
+
+	// A wall in range of a quick throw
+	s, err := sim.NewFromLevel(newWalledArena(208))
+	if err != nil {
+		t.Fatalf("NewFromLevel failed: %v", err)
+	}
+
+	s.SetScript(throwEvery(600))
+	var b *components.BoomerangData
+	bounced := s.RunUntil(120, func(s *sim.Sim) bool {
+		entry, ok := components.Boomerang.First(s.ECS.World)
+		if ok {
+			b = components.Boomerang.Get(entry)
+		}
+		return ok && b.Bounces == 0
+	})
+	if !bounced {
+		t.Fatal("expected the boomerang to bounce off the wall")
+	}
+
+	entry, _ := components.Boomerang.First(s.ECS.World)
+	if components.Physics.Get(entry).SpeedX >= 0 || b.State != components.BoomerangOutbound {
+		t.Errorf("expected the boomerang to fly back out from the wall, got speed %v and state %v",
+			components.Physics.Get(entry).SpeedX, b.State)
+	}
+	if b.Damage >= cfg.Boomerang.BaseDamage {
+		t.Errorf("expected the bounce to cost damage, got %d", b.Damage)
+	}
+
+	// With no bounces left the next wall sends it back
+	s.RunUntil(120, func(s *sim.Sim) bool { return b.State == components.BoomerangInbound })
+	if b.State != components.BoomerangInbound {
+		t.Error("expected the boomerang to return after its last bounce")
+	}
+}
+
+func TestRogueliteSeedBuildsWorld(t *testing.T) {
+	s, err := sim.NewRoguelite(42)
+	if err != nil {
+		t.Fatalf("NewRoguelite failed: %v", err)
+	}
+
+	s.Step(30)
+
+	if _, ok := s.Player(); !ok {
+		t.Error("expected player to exist in generated run")
+	}
+}
+
+// The game, the sim and cmd/procgen must see the same enemy types, or a seed
+// places different enemies in each
+func TestRogueliteEnemiesMatchGeneratedRun(t *testing.T) {
+	const seed = 2 // Deep enough to place a HeavyKnifeThrower, which only the asset tree defines
+	s, err := sim.NewRoguelite(seed)
+	if err != nil {
+		t.Fatalf("NewRoguelite failed: %v", err)
+	}
+	// The options cmd/procgen generates from
+	run, err := procgen.GenerateRun(procgen.RunOptions{Seed: seed, Length: cfg.Procgen.DefaultRunLength, Headless: true})
+	if err != nil {
+		t.Fatalf("GenerateRun failed: %v", err)
+	}
+
+	level := components.Level.Get(components.Level.MustFirst(s.ECS.World)).CurrentLevel
+	if !slices.Equal(level.EnemySpawns, run.Level.EnemySpawns) {
+		t.Fatalf("the sim placed %d enemies and GenerateRun %d, or placed them differently", len(level.EnemySpawns), len(run.Level.EnemySpawns))
+	}
+	if !slices.ContainsFunc(level.EnemySpawns, func(e assets.EnemySpawn) bool { return e.EnemyType == "HeavyKnifeThrower" }) {
+		t.Error("expected the run to place a HeavyKnifeThrower")
+	}
+}
+
+// snapshot captures the parts of a run a replay has to reproduce
+func snapshot(t *testing.T, s *sim.Sim) []float64 {
+	t.Helper()
+	player, ok := s.Player()
+	if !ok {
+		t.Fatal("expected a player")
+	}
+	obj := components.Object.Get(player)
+	state := []float64{obj.X, obj.Y, float64(components.Health.Get(player).Current)}
+	tags.Enemy.Each(s.ECS.World, func(e *donburi.Entry) {
+		o := components.Object.Get(e)
+		state = append(state, o.X, o.Y)
+	})
+	return state
+}
+
+func TestReplayReproducesRun(t *testing.T) {
+	rec, err := sim.NewRoguelite(42)
+	if err != nil {
+		t.Fatalf("NewRoguelite failed: %v", err)
+	}
+	systems.StartReplayRecording(rec.ECS, components.ReplayRoguelite, 42)
+	rec.SetScript(func(tick int) []cfg.ActionID {
+		// Pace back and forth near the spawn so the run outlives the recording
+		actions := []cfg.ActionID{cfg.ActionMoveRight}
+		if tick%120 >= 60 {
+			actions[0] = cfg.ActionMoveLeft
+		}
+		if tick%90 < 10 {
+			actions = append(actions, cfg.ActionJump)
+		}
+		if tick%45 < 4 {
+			actions = append(actions, cfg.ActionBoomerang)
+		}
+		return actions
+	})
+	rec.Step(600)
+
+	replay := components.ReplayState.Get(components.ReplayState.MustFirst(rec.ECS.World)).Replay
+	var buf bytes.Buffer
+	if err := systems.EncodeReplay(&buf, replay); err != nil {
+		t.Fatalf("encode: %v", err)
+	}
+	decoded, err := systems.DecodeReplay(&buf)
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+
+	play, err := sim.NewRoguelite(decoded.Seed)
+	if err != nil {
+		t.Fatalf("NewRoguelite failed: %v", err)
+	}
+	systems.StartReplayPlayback(play.ECS, decoded)
+	play.Step(len(decoded.Frames))
+
+	want, got := snapshot(t, rec), snapshot(t, play)
+	if len(want) != len(got) {
+		t.Fatalf("expected %d values of state after playback, got %d", len(want), len(got))
+	}
+	for i := range want {
+		if want[i] != got[i] {
+			t.Errorf("state[%d]: recorded %v, replayed %v", i, want[i], got[i])
+		}
+	}
+}
+
+func TestChargedBoomerangCurvesHome(t *testing.T) {
+	s, err := sim.NewFromLevel(newArena())
+	if err != nil {
+		t.Fatalf("NewFromLevel failed: %v", err)
+	}
+
+	s.SetScript(chargedThrow)
+	var b *components.BoomerangData
+	var speedX float64
+	turned := s.RunUntil(300, func(s *sim.Sim) bool {
+		entry, ok := components.Boomerang.First(s.ECS.World)
+		if !ok {
+			return false
+		}
+		b = components.Boomerang.Get(entry)
+		speedX = components.Physics.Get(entry).SpeedX
+		return b.State == components.BoomerangInbound && b.ReturnFrames > 0
+	})
+	if !turned {
+		t.Fatal("expected the boomerang to start its return")
+	}
+	if b.ChargeRatio < 1 {
+		t.Errorf("expected a fully charged throw, got charge %v", b.ChargeRatio)
+	}
+	// A sharp turn would already be heading back toward the player
+	if speedX <= 0 {
+		t.Errorf("expected a charged throw to swing wide before coming back, got speed %v", speedX)
+	}
+
+	caught := s.RunUntil(300, func(s *sim.Sim) bool {
+		_, ok := components.Boomerang.First(s.ECS.World)
+		return !ok
+	})
+	if !caught {
+		t.Error("expected the curving boomerang to make it back to the player")
+	}
+}
+
+// boomerangGap returns how far the boomerang's center is from the player's,
+// or false if no boomerang is out
+func boomerangGap(s *sim.Sim) (float64, bool) {
+	entry, ok := components.Boomerang.First(s.ECS.World)
+	if !ok {
+		return 0, false
+	}
+	player, _ := s.Player()
+	b, p := components.Object.Get(entry), components.Object.Get(player)
+	return math.Hypot(b.X+b.W/2-(p.X+p.W/2), b.Y+b.H/2-(p.Y+p.H/2)), true
+}
+
+func TestPerfectCatch(t *testing.T) {
+	tests := []struct {
+		name string
+		// press reports whether to start holding throw this tick
+		press   func(s *sim.Sim, caughtAgo int) bool
+		perfect bool
+	}{
+		{"pressed as it arrives", func(s *sim.Sim, _ int) bool {
+			gap, ok := boomerangGap(s)
+			return ok && gap < 3*cfg.Boomerang.ReturnSpeed && components.Boomerang.Get(components.Boomerang.MustFirst(s.ECS.World)).State == components.BoomerangInbound
+		}, true},
+		{"pressed just after", func(_ *sim.Sim, caughtAgo int) bool { return caughtAgo == 1 }, true},
+		{"pressed too late", func(_ *sim.Sim, caughtAgo int) bool { return caughtAgo == cfg.Boomerang.PerfectCatchWindow+1 }, false},
+		{"pressed too early", func(s *sim.Sim, _ int) bool {
+			gap, ok := boomerangGap(s)
+			return ok && gap > 100
+		}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := sim.NewFromLevel(newArena())
+			if err != nil {
+				t.Fatalf("NewFromLevel failed: %v", err)
+			}
+			stats := systems.GetOrCreateRunStats(s.ECS)
+
+			s.SetScript(sim.Hold(cfg.ActionBoomerang))
+			s.Step(4)
+			s.SetScript(sim.Hold())
+			thrown := false
+			caughtAgo := 0
+			pressed := s.RunUntil(300, func(s *sim.Sim) bool {
+				_, out := boomerangGap(s)
+				thrown = thrown || out
+				if thrown && !out {
+					caughtAgo++
+				}
+				return tt.press(s, caughtAgo)
+			})
+			if !pressed {
+				t.Fatal("expected the boomerang to come back")
+			}
+
+			s.SetScript(sim.Hold(cfg.ActionBoomerang))
+			s.RunUntil(60, func(s *sim.Sim) bool {
+				_, out := boomerangGap(s)
+				return !out
+			})
+			s.Step(cfg.Boomerang.PerfectCatchWindow + 1)
+
+			if got := stats.PerfectCatches == 1; got != tt.perfect {
+				t.Fatalf("expected perfect catch %v, got %d perfect catches", tt.perfect, stats.PerfectCatches)
+			}
+			// Holding throw through the catch starts the next throw, part
+			// charged after a perfect catch
+			player, _ := s.Player()
+			charge := components.Player.Get(player).BoomerangChargeTime
+			if tt.perfect && charge < cfg.Boomerang.PerfectCatchCharge {
+				t.Errorf("expected the next throw to start charged, got %d charge frames", charge)
+			}
+		})
+	}
+}
+
+// newHeavySim builds the world for level with the player holding a heavy
+// boomerang, the only kind that embeds and drops
+func newHeavySim(t *testing.T, level *assets.Level) *sim.Sim {
+	t.Helper()
+	s, err := sim.NewFromLevel(level)
+	if err != nil {
+		t.Fatalf("NewFromLevel failed: %v", err)
+	}
+	player, _ := s.Player()
+	components.Player.Get(player).Modifiers = []components.BoomerangModifier{components.ModifierHeavy}
+	return s
+}
+
+func TestChargedBoomerangEmbedsInWall(t *testing.T) {
+	s := newHeavySim(t, newWalledArena(208))
+	s.SetScript(chargedThrow)
+	var entry *donburi.Entry
+	embedded := s.RunUntil(300, func(s *sim.Sim) bool {
+		var ok bool
+		entry, ok = components.Boomerang.First(s.ECS.World)
+		return ok && components.Boomerang.Get(entry).State == components.BoomerangEmbedded
+	})
+	if !embedded {
+		t.Fatal("expected a fully charged throw to stick in the wall")
+	}
+	obj := components.Object.Get(entry)
+	if !obj.HasTags(tags.ResolvSolid) || obj.X+obj.W != 208 {
+		t.Errorf("expected a solid ledge flush with the wall, got x %v tags %v", obj.X, obj.Tags())
+	}
+
+	// It stays put until its time is up, then comes back without a wall tag
+	s.Step(cfg.Boomerang.EmbedFrames - 1)
+	if b := components.Boomerang.Get(entry); b.State != components.BoomerangEmbedded {
+		t.Fatalf("expected the boomerang to stay embedded, got state %v", b.State)
+	}
+	s.Step(1)
+	if b := components.Boomerang.Get(entry); b.State != components.BoomerangInbound || obj.HasTags(tags.ResolvSolid) {
+		t.Errorf("expected the boomerang to come back, got state %v tags %v", b.State, obj.Tags())
+	}
+}
+
+func TestOnlyHeavyBoomerangEmbeds(t *testing.T) {
+	s, err := sim.NewFromLevel(newWalledArena(208))
+	if err != nil {
+		t.Fatalf("NewFromLevel failed: %v", err)
+	}
+	s.SetScript(chargedThrow)
+	embedded := s.RunUntil(300, func(s *sim.Sim) bool {
+		entry, ok := components.Boomerang.First(s.ECS.World)
+		return ok && components.Boomerang.Get(entry).State == components.BoomerangEmbedded
+	})
+	if embedded {
+		t.Error("expected a boomerang that isn't heavy to bounce back off the wall")
+	}
+}
+
+func TestPlayerCanStandOnEmbeddedBoomerang(t *testing.T) {
+	s := newHeavySim(t, newWalledArena(208))
+	s.SetScript(chargedThrow)
+	var entry *donburi.Entry
+	s.RunUntil(300, func(s *sim.Sim) bool {
+		var ok bool
+		entry, ok = components.Boomerang.First(s.ECS.World)
+		return ok && components.Boomerang.Get(entry).State == components.BoomerangEmbedded
+	})
+	ledge := components.Object.Get(entry)
+
+	// Drop the player onto the ledge
+	player, _ := s.Player()
+	playerObj := components.Object.Get(player)
+	playerObj.X = 208 - playerObj.W
+	playerObj.Y = ledge.Y - playerObj.H - 16
+	playerObj.Update()
+	s.SetScript(sim.Idle)
+	s.Step(20)
+
+	if ground := components.Physics.Get(player).OnGround; ground != ledge.Object {
+		t.Errorf("expected the player to stand on the boomerang at y %v, player is at y %v", ledge.Y, playerObj.Y+playerObj.H)
+	}
+}
+
+func TestBoomerangDropsWhenPlayerIsHit(t *testing.T) {
+	s := newHeavySim(t, newArena())
+	s.SetScript(throwEvery(600))
+	s.Step(12)
+	entry, ok := components.Boomerang.First(s.ECS.World)
+	if !ok {
+		t.Fatal("expected a boomerang in flight")
+	}
+	player, _ := s.Player()
+	donburi.Add(player, components.DamageEvent, &components.DamageEventData{Amount: 1, Source: "Test"})
+	s.SetScript(sim.Idle)
+	s.Step(1)
+
+	b := components.Boomerang.Get(entry)
+	if b.State != components.BoomerangDropped {
+		t.Fatalf("expected the hit to knock the boomerang down, got state %v", b.State)
+	}
+
+	// It lands and waits there
+	s.Step(90)
+	if b.State != components.BoomerangDropped || components.Physics.Get(entry).OnGround == nil {
+		t.Fatalf("expected the boomerang on the ground, got state %v", b.State)
+	}
+
+	// Walking over it picks it up
+	s.SetScript(sim.Hold(cfg.ActionMoveRight))
+	picked := s.RunUntil(240, func(s *sim.Sim) bool {
+		return components.Player.Get(player).ActiveBoomerang == nil
+	})
+	if !picked || entry.Valid() {
+		t.Error("expected the player to pick the boomerang up")
+	}
+}
+
+func TestRewardGivesBoomerangModifier(t *testing.T) {
+	level := newArena()
+	level.Rewards = []assets.RewardSpawn{
+		{X: 120, Y: 240, Width: 16, Height: 16, RewardType: "fire"},
+		{X: 180, Y: 240, Width: 16, Height: 16, RewardType: "fire"},
+	}
+	s, err := sim.NewFromLevel(level)
+	if err != nil {
+		t.Fatalf("NewFromLevel failed: %v", err)
+	}
+	player, _ := s.Player()
+	data := components.Player.Get(player)
+	health := components.Health.Get(player)
+
+	// The second pickup is one more than the most stacks, so it heals instead
+	maxStacks := cfg.BoomerangModifier.MaxStacks
+	t.Cleanup(func() { cfg.BoomerangModifier.MaxStacks = maxStacks })
+	cfg.BoomerangModifier.MaxStacks = 1
+	health.Current = health.Max - 10
+
+	s.SetScript(sim.Hold(cfg.ActionMoveRight))
+	s.RunUntil(300, func(s *sim.Sim) bool { return health.Current == health.Max })
+
+	if len(data.Modifiers) != 1 || data.Modifiers[0] != components.ModifierFire {
+		t.Errorf("expected one stack of fire, got %v", data.Modifiers)
+	}
+	if health.Current != health.Max {
+		t.Errorf("expected the pickup past the most stacks to heal, health is %d/%d", health.Current, health.Max)
+	}
+	if stats := systems.GetOrCreateRunStats(s.ECS); len(stats.Modifiers) != 1 {
+		t.Errorf("expected the run stats to record the modifier, got %v", stats.Modifiers)
+	}
+}
+
+// newModifierSim returns a sim of level where the player has the given
+// boomerang modifiers and throws once
+func newModifierSim(t *testing.T, level *assets.Level, mods ...components.BoomerangModifier) *sim.Sim {
+	t.Helper()
+	s, err := sim.NewFromLevel(level)
+	if err != nil {
+		t.Fatalf("NewFromLevel failed: %v", err)
+	}
+	player, _ := s.Player()
+	components.Player.Get(player).Modifiers = mods
+	s.SetScript(throwEvery(600))
+	return s
+}
+
+// runUntilEnemyHit steps until the boomerang hits the enemy, returning the
+// enemy and the boomerang that hit it
+func runUntilEnemyHit(t *testing.T, s *sim.Sim) (*donburi.Entry, *components.BoomerangData) {
+	t.Helper()
+	var b *components.BoomerangData
+	hit := s.RunUntil(120, func(s *sim.Sim) bool {
+		entry, ok := components.Boomerang.First(s.ECS.World)
+		if ok {
+			b = components.Boomerang.Get(entry)
+		}
+		return ok && len(b.HitEnemies) > 0
+	})
+	if !hit {
+		t.Fatal("expected the boomerang to hit the guard")
+	}
+	enemy, _ := tags.Enemy.First(s.ECS.World)
+	return enemy, b
+}
+
+// turnBack steps until the boomerang turns back and returns it
+func turnBack(t *testing.T, s *sim.Sim) *components.BoomerangData {
+	t.Helper()
+	var b *components.BoomerangData
+	turned := s.RunUntil(120, func(s *sim.Sim) bool {
+		entry, ok := components.Boomerang.First(s.ECS.World)
+		if ok {
+			b = components.Boomerang.Get(entry)
+		}
+		return ok && b.State == components.BoomerangInbound
+	})
+	if !turned {
+		t.Fatal("expected the boomerang to turn back")
+	}
+	return b
+}
+
+func TestBoomerangModifiers(t *testing.T) {
+	guardArena := newArena(assets.EnemySpawn{X: 160, Y: 200, EnemyType: "Guard"})
+
+	t.Run("range and return speed stack", func(t *testing.T) {
+		plain := turnBack(t, newModifierSim(t, newArena()))
+		b := turnBack(t, newModifierSim(t, newArena(), components.ModifierRange, components.ModifierRange, components.ModifierReturn))
+		if b.DistanceTraveled <= plain.DistanceTraveled+cfg.BoomerangModifier.RangeBonus {
+			t.Errorf("expected the boomerang to fly more than %v past the %v of a plain throw, it flew %v",
+				cfg.BoomerangModifier.RangeBonus, plain.DistanceTraveled, b.DistanceTraveled)
+		}
+		if b.ReturnSpeed <= cfg.Boomerang.ReturnSpeed {
+			t.Errorf("expected a faster return than %v, got %v", cfg.Boomerang.ReturnSpeed, b.ReturnSpeed)
+		}
+	})
+
+	t.Run("pierce keeps going past an enemy", func(t *testing.T) {
+		s := newModifierSim(t, guardArena, components.ModifierPierce)
+		_, b := runUntilEnemyHit(t, s)
+		if b.Pierces != 0 || b.MaxRange < cfg.Boomerang.BaseRange {
+			t.Errorf("expected the hit to use up the pierce without cutting the range, got %d pierces and range %v", b.Pierces, b.MaxRange)
+		}
+	})
+
+	t.Run("ricochet bounces off another wall", func(t *testing.T) {
+		bounces := cfg.Boomerang.RicochetBounces
+		t.Cleanup(func() { cfg.Boomerang.RicochetBounces = bounces })
+		cfg.Boomerang.RicochetBounces = 0
+
+		s := newModifierSim(t, newWalledArena(208), components.ModifierRicochet)
+		var b *components.BoomerangData
+		bounced := s.RunUntil(120, func(s *sim.Sim) bool {
+			entry, ok := components.Boomerang.First(s.ECS.World)
+			if ok {
+				b = components.Boomerang.Get(entry)
+			}
+			return ok && b.Bounces == 0
+		})
+		if !bounced || b.State != components.BoomerangOutbound {
+			t.Error("expected the boomerang to bounce off the wall instead of turning back")
+		}
+	})
+
+	t.Run("fire burns the enemy after the hit", func(t *testing.T) {
+		s := newModifierSim(t, guardArena, components.ModifierFire)
+		enemy, _ := runUntilEnemyHit(t, s)
+		health := components.Health.Get(enemy)
+		before := health.Current
+		s.Step(cfg.BoomerangModifier.BurnInterval)
+		if want := before - cfg.BoomerangModifier.BurnDamage; health.Current != want {
+			t.Errorf("expected the burn to take the guard to %d health, got %d", want, health.Current)
+		}
+	})
+
+	t.Run("lifesteal heals the owner", func(t *testing.T) {
+		s := newModifierSim(t, guardArena, components.ModifierLifesteal)
+		player, _ := s.Player()
+		health := components.Health.Get(player)
+		health.Current = health.Max - 10
+		runUntilEnemyHit(t, s)
+		if want := health.Max - 10 + cfg.BoomerangModifier.LifestealHeal; health.Current != want {
+			t.Errorf("expected the hit to heal the player to %d, got %d", want, health.Current)
+		}
+	})
+
+	t.Run("twin throws a second boomerang", func(t *testing.T) {
+		s := newModifierSim(t, guardArena, components.ModifierTwin)
+		s.Step(12)
+		count, twins := 0, 0
+		for entry := range components.Boomerang.Iter(s.ECS.World) {
+			count++
+			if components.Boomerang.Get(entry).Twin {
+				twins++
+			}
+		}
+		if count != 2 || twins != 1 {
+			t.Fatalf("expected the throw and one twin, got %d boomerangs with %d twins", count, twins)
+		}
+
+		// Both come back to be caught
+		s.SetScript(sim.Idle)
+		caught := s.RunUntil(600, func(s *sim.Sim) bool {
+			_, ok := components.Boomerang.First(s.ECS.World)
+			return !ok
+		})
+		player, _ := s.Player()
+		if !caught || components.Player.Get(player).ActiveBoomerang != nil {
+			t.Error("expected both boomerangs to return to the player")
+		}
+	})
+}
+
+// moveScript is the input for one tick of TestValidatorMovesMatchGame
+func moveScript(tick int) procgen.MoveInput {
+	var in procgen.MoveInput
+	switch {
+	case tick < 30: // Land on the floor
+	case tick < 130: // Run right over the ledge and jump at the wall
+		in.Move = 1
+		in.Jump = tick == 60 || tick == 105
+	case tick < 180: // Wall jump back off it
+		in.Move = -1
+		in.Jump = tick == 130
+	case tick < 260: // Bump the ledge from below, then jump at the wall and wall kick off it
+		in.Move = 1
+		in.Jump = tick == 225 || tick == 238
+		in.Kick = tick == 255
+	case tick < 300: // Land on the ledge
+		in.Move = -1
+	}
+	return in
+}
+
+func TestValidatorMovesMatchGame(t *testing.T) {
+	// The validator mirrors the player's movement so it can play levels
+	// without an ECS world; check its moves land where the game's do
+	level := newWalledArena(480)
+	for x := 256.0; x < 352; x += 16 {
+		level.SolidTiles = append(level.SolidTiles, assets.SolidTile{X: x, Y: 192, Width: 16, Height: 16})
+	}
+	chunk := &procgen.Chunk{
+		ID:         "arena",
+		Width:      level.Width,
+		Height:     level.Height,
+		TileWidth:  level.Width / 16,
+		TileHeight: level.Height / 16,
+		SolidTiles: level.SolidTiles,
+	}
+	result := &procgen.GenerationResult{
+		PlacedChunks: []procgen.PlacedChunk{{Chunk: chunk}},
+		TotalWidth:   level.Width,
+		TotalHeight:  level.Height,
+	}
+
+	const ticks = 320
+	inputs := make([]procgen.MoveInput, ticks)
+	for i := range inputs {
+		inputs[i] = moveScript(i)
+	}
+	spawn := level.PlayerSpawns[0]
+	path := procgen.TraceMove(result, spawn.X, spawn.Y, inputs)
+	if len(path) != ticks {
+		t.Fatalf("validator lost the player after %d ticks", len(path))
+	}
+
+	s, err := sim.NewFromLevel(level)
+	if err != nil {
+		t.Fatalf("NewFromLevel failed: %v", err)
+	}
+	s.SetScript(func(tick int) []cfg.ActionID {
+		in := moveScript(tick)
+		var actions []cfg.ActionID
+		switch in.Move {
+		case -1:
+			actions = append(actions, cfg.ActionMoveLeft)
+		case 1:
+			actions = append(actions, cfg.ActionMoveRight)
+		}
+		if in.Jump {
+			actions = append(actions, cfg.ActionJump)
+		}
+		if in.Kick {
+			actions = append(actions, cfg.ActionAttack)
+		}
+		return actions
+	})
+	for tick, want := range path {
+		s.Step(1)
+		player, ok := s.Player()
+		if !ok {
+			t.Fatalf("game lost the player at tick %d", tick)
+		}
+		obj := components.Object.Get(player)
+		if math.Abs(obj.X-want[0]) > 0.01 || math.Abs(obj.Y-want[1]) > 0.01 {
+			t.Fatalf("tick %d: game has the player at (%.2f, %.2f), validator at (%.2f, %.2f)", tick, obj.X, obj.Y, want[0], want[1])
+		}
+	}
+}
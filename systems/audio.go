@@ -23,10 +23,20 @@ var (
 	globalFadeDuration int
 	globalFadeStart    float64
 	audioInitOnce      sync.Once
+	audioDisabled      bool
 )
 
+// DisableAudio turns all audio into no-ops. Used by headless simulation,
+// which has no audio device. Must be called before any audio is used.
+func DisableAudio() {
+	audioDisabled = true
+}
+
 // initGlobalAudio initializes the global audio context (called once)
 func initGlobalAudio() {
+	if audioDisabled {
+		return
+	}
 	audioInitOnce.Do(func() {
 		globalAudioContext = audio.NewContext(cfg.Audio.SampleRate)
 		globalAudioLoader = assets.NewAudioLoader(globalAudioContext)
@@ -37,6 +47,9 @@ func initGlobalAudio() {
 // This is especially important for WASM where decoding is slower.
 func PreloadAllSFX() {
 	initGlobalAudio()
+	if globalAudioLoader == nil {
+		return
+	}
 
 	for _, path := range cfg.Sound.SFXPaths {
 		_ = globalAudioLoader.PreloadSFX(path)
@@ -46,6 +59,9 @@ func PreloadAllSFX() {
 // UpdateAudio processes pending SFX and manages music transitions
 func UpdateAudio(e *ecs.ECS) {
 	initGlobalAudio()
+	if globalAudioLoader == nil {
+		return
+	}
 
 	// Handle music fade out
 	if globalFadeTimer > 0 {
@@ -102,8 +118,8 @@ func playSFX(soundID cfg.SoundID) {
 func PlayMusic(e *ecs.ECS, musicPath string) {
 	initGlobalAudio()
 
-	// Already playing this music
-	if globalMusicKey == musicPath {
+	// Already playing this music, or audio is disabled
+	if globalMusicKey == musicPath || globalAudioLoader == nil {
 		return
 	}
 
@@ -131,7 +147,7 @@ func PlayLevelMusic(e *ecs.ECS, levelPath string) {
 
 	// Extract level name from path (e.g., "levels/level01.tmx" -> "level01")
 	levelName := extractLevelName(levelPath)
-	if levelName == "" {
+	if levelName == "" || globalAudioLoader == nil {
 		return
 	}
 
@@ -204,6 +220,9 @@ func ResumeMusic(e *ecs.ECS) {
 
 // PlaySFX queues a sound effect to be played
 func PlaySFX(e *ecs.ECS, sound cfg.SoundID) {
+	if audioDisabled {
+		return
+	}
 	initGlobalAudio()
 
 	// Get or create audio data for this ECS to queue SFX
@@ -19,6 +19,16 @@ func UpdateBoomerang(ecs *ecs.ECS) {
 		obj := components.Object.Get(e)
 		sprite := components.Sprite.Get(e)
 
+		// A boomerang out of flight neither spins nor hits anything
+		switch b.State {
+		case components.BoomerangEmbedded:
+			updateEmbedded(b, physics, obj)
+			return
+		case components.BoomerangDropped:
+			updateDropped(ecs, e, b, physics, obj)
+			return
+		}
+
 		// 1. Update Rotation
 		sprite.Rotation += 0.3 // Constant spin
 
@@ -83,10 +93,26 @@ func updateInbound(ecs *ecs.ECS, e *donburi.Entry, b *components.BoomerangData,
 		dirX := dx / dist
 		dirY := dy / dist
 
+		// Curve toward the owner instead of turning on the spot. Charged
+		// throws turn slower for a wider swing, and every throw tightens
+		// its turn over time so it can't circle the owner forever.
+		turn := cfg.Boomerang.ReturnTurnRate + (cfg.Boomerang.ReturnTurnRateCharged-cfg.Boomerang.ReturnTurnRate)*b.ChargeRatio
+		turn = math.Min(1, turn+cfg.Boomerang.ReturnTurnRamp*float64(b.ReturnFrames))
+		b.ReturnFrames++
+		if speed := math.Hypot(physics.SpeedX, physics.SpeedY); speed > 0 {
+			curX := physics.SpeedX / speed
+			curY := physics.SpeedY / speed
+			newX := curX + (dirX-curX)*turn
+			newY := curY + (dirY-curY)*turn
+			// Heading straight away from the owner the blend can cancel out
+			if length := math.Hypot(newX, newY); length > 0.01 {
+				dirX, dirY = newX/length, newY/length
+			}
+		}
+
 		// Apply Return Speed
-		returnSpeed := cfg.Boomerang.ReturnSpeed
-		physics.SpeedX = dirX * returnSpeed
-		physics.SpeedY = dirY * returnSpeed
+		physics.SpeedX = dirX * b.ReturnSpeed
+		physics.SpeedY = dirY * b.ReturnSpeed
 	} else {
 		// Stop moving if we are exactly at the player center 
 		// (collision check will catch it)
@@ -101,15 +127,26 @@ func SwitchToInbound(b *components.BoomerangData, physics *components.PhysicsDat
 	}
 	b.State = components.BoomerangInbound
 	physics.Gravity = 0 // Disable gravity for homing return
+	physics.Friction = 0
 	// Keep HitEnemies - each enemy should only be hit once per throw
 }
 
 func checkCollisions(ecs *ecs.ECS, e *donburi.Entry, b *components.BoomerangData, physics *components.PhysicsData, obj *components.ObjectData) {
+	// Ricochet off walls while there are bounces left
+	ricocheting := b.State == components.BoomerangOutbound && b.Bounces > 0
+	if ricocheting && !ricochet(ecs, b, physics, obj) {
+		SwitchToInbound(b, physics)
+	}
+	// A fully charged throw sticks in the wall instead of coming back
+	if !ricocheting && b.State == components.BoomerangOutbound && embed(ecs, b, physics, obj) {
+		return
+	}
+
 	// Check for collision with anything
 	if check := obj.Check(0, 0, tags.ResolvSolid, tags.ResolvEnemy, tags.ResolvPlayer); check != nil {
 
 		// Wall Collision
-		if solids := check.ObjectsByTags(tags.ResolvSolid); len(solids) > 0 {
+		if solids := check.ObjectsByTags(tags.ResolvSolid); len(solids) > 0 && !ricocheting {
 			SwitchToInbound(b, physics)
 		}
 
@@ -121,18 +158,260 @@ func checkCollisions(ecs *ecs.ECS, e *donburi.Entry, b *components.BoomerangData
 		}
 
 		// Player Collision (Catch)
-		if b.State == components.BoomerangInbound {
-			if players := check.ObjectsByTags(tags.ResolvPlayer); len(players) > 0 {
-				ownerObj := components.Object.Get(b.Owner)
-				for _, pObj := range players {
-					if pObj == ownerObj.Object {
-						catchBoomerang(ecs, e, b)
-						return
-					}
-				}
+		if b.State == components.BoomerangInbound && touchingOwner(b, check) {
+			catchBoomerang(ecs, e, b)
+			return
+		}
+	}
+}
+
+// touchingOwner reports whether the boomerang's owner is among the objects
+// of a collision check
+func touchingOwner(b *components.BoomerangData, check *resolv.Collision) bool {
+	if b.Owner == nil || !b.Owner.Valid() {
+		return false
+	}
+	ownerObj := components.Object.Get(b.Owner)
+	for _, pObj := range check.ObjectsByTags(tags.ResolvPlayer) {
+		if pObj == ownerObj.Object {
+			return true
+		}
+	}
+	return false
+}
+
+// embed sticks a fully charged heavy boomerang into the side of the wall it flew
+// into this frame, where it becomes a solid the player can stand on or
+// wall-kick from. Returns false if it didn't hit a wall side on.
+func embed(ecs *ecs.ECS, b *components.BoomerangData, physics *components.PhysicsData, obj *components.ObjectData) bool {
+	if !b.Heavy || cfg.Boomerang.EmbedFrames <= 0 || b.Twin || b.ChargeRatio < 1 || physics.SpeedX == 0 {
+		return false
+	}
+	wallX, ok := embedFace(obj.Object, obj.X, obj.Y, physics.SpeedX, physics.SpeedY)
+	if !ok {
+		return false
+	}
+
+	// Sit flush against the face so the whole boomerang is a ledge
+	obj.X = wallX
+	obj.Y -= physics.SpeedY
+	obj.AddTags(tags.ResolvSolid)
+	obj.Update()
+
+	physics.SpeedX, physics.SpeedY, physics.Gravity = 0, 0, 0
+	b.State = components.BoomerangEmbedded
+	b.StateFrames = cfg.Boomerang.EmbedFrames
+
+	PlaySFX(ecs, cfg.SoundBoomerangImpact)
+	TriggerScreenShake(ecs, cfg.ScreenShake.BoomerangIntensity, cfg.ScreenShake.BoomerangDuration)
+	factory.SpawnExplosion(ecs, obj.X+obj.W/2, obj.Y+obj.H/2, 0.5)
+	return true
+}
+
+// embedFace returns the x a boomerang that moved by (vx, vy) to (x, y) sits
+// at, flush against the side of the wall it flew into. ok is false if it
+// didn't hit a wall side on.
+func embedFace(obj *resolv.Object, x, y, vx, vy float64) (float64, bool) {
+	// Only moving across alone may reach the wall: floors, ceilings and
+	// slopes don't hold it
+	prevX, prevY := x-vx, y-vy
+	if len(touchingWalls(obj, prevX, prevY)) > 0 || len(touchingWalls(obj, prevX, y)) > 0 {
+		return 0, false
+	}
+	var wall *resolv.Object
+	for _, o := range touchingWalls(obj, x, prevY) {
+		if !o.HasTags(tags.ResolvSolid) {
+			continue
+		}
+		// The face nearest the boomerang
+		if wall == nil || (vx > 0 && o.X < wall.X) || (vx < 0 && o.X+o.W > wall.X+wall.W) {
+			wall = o
+		}
+	}
+	if wall == nil {
+		return 0, false
+	}
+	if vx > 0 {
+		return wall.X - obj.W, true
+	}
+	return wall.X + wall.W, true
+}
+
+func updateEmbedded(b *components.BoomerangData, physics *components.PhysicsData, obj *components.ObjectData) {
+	b.StateFrames--
+	if b.StateFrames <= 0 {
+		releaseBoomerang(b, physics, obj)
+	}
+}
+
+// releaseBoomerang pulls an embedded or dropped boomerang loose and sends it
+// back to its owner
+func releaseBoomerang(b *components.BoomerangData, physics *components.PhysicsData, obj *components.ObjectData) {
+	obj.RemoveTags(tags.ResolvSolid)
+	physics.SpeedX, physics.SpeedY = 0, 0
+	SwitchToInbound(b, physics)
+}
+
+// recallBoomerang sends the player's boomerang back if it is stuck in a wall or
+// lying on the ground, e.g. when they respawn away from it
+func recallBoomerang(player *components.PlayerData) {
+	if player.ActiveBoomerang == nil || !player.ActiveBoomerang.Valid() {
+		return
+	}
+	b := components.Boomerang.Get(player.ActiveBoomerang)
+	if b.State == components.BoomerangEmbedded || b.State == components.BoomerangDropped {
+		releaseBoomerang(b, components.Physics.Get(player.ActiveBoomerang), components.Object.Get(player.ActiveBoomerang))
+	}
+}
+
+// dropBoomerang knocks the player's heavy boomerang out of the air when they
+// are hit. It falls and lies where it lands until they walk over it.
+func dropBoomerang(playerEntry *donburi.Entry) {
+	player := components.Player.Get(playerEntry)
+	if cfg.Boomerang.DropFrames <= 0 || player.ActiveBoomerang == nil || !player.ActiveBoomerang.Valid() {
+		return
+	}
+	b := components.Boomerang.Get(player.ActiveBoomerang)
+	if !b.Heavy || (b.State != components.BoomerangOutbound && b.State != components.BoomerangInbound) {
+		return
+	}
+	// On its way back it can be inside a wall, where it couldn't be reached
+	obj := components.Object.Get(player.ActiveBoomerang)
+	if len(touchingWalls(obj.Object, obj.X, obj.Y)) > 0 {
+		return
+	}
+
+	physics := components.Physics.Get(player.ActiveBoomerang)
+	b.State = components.BoomerangDropped
+	b.StateFrames = cfg.Boomerang.DropFrames
+	physics.Gravity = cfg.Boomerang.DropGravity
+	physics.Friction = cfg.Boomerang.DropFriction
+	physics.SpeedX *= 0.5
+	physics.SpeedY = -2 // A little pop before it falls
+}
+
+// updateDropped moves a dropped boomerang like a body in the level, and hands
+// it back when the owner walks over it. One that can't be reached, because it
+// fell out of the level or the owner left it too long, flies back.
+func updateDropped(ecs *ecs.ECS, e *donburi.Entry, b *components.BoomerangData, physics *components.PhysicsData, obj *components.ObjectData) {
+	if b.Owner == nil || !b.Owner.Valid() {
+		destroyBoomerang(ecs, e, obj)
+		return
+	}
+
+	resolveObjectHorizontalCollision(physics, obj.Object, false)
+	resolveObjectVerticalCollision(physics, obj.Object)
+	obj.Update()
+
+	if check := obj.Check(0, 0, tags.ResolvPlayer); check != nil && touchingOwner(b, check) {
+		pickUpBoomerang(ecs, e, b)
+		return
+	}
+
+	b.StateFrames--
+	fellOut := checkDeadZone(obj.Object)
+	if levelEntry, ok := components.Level.First(ecs.World); ok {
+		if level := components.Level.Get(levelEntry).CurrentLevel; level != nil && obj.Y > float64(level.Height) {
+			fellOut = true
+		}
+	}
+	if fellOut || b.StateFrames <= 0 {
+		releaseBoomerang(b, physics, obj)
+	}
+}
+
+// pickUpBoomerang gives a dropped boomerang back to its owner
+func pickUpBoomerang(ecs *ecs.ECS, e *donburi.Entry, b *components.BoomerangData) {
+	defer destroyBoomerang(ecs, e, components.Object.Get(e))
+	publishGameplayEvent(ecs, b.Owner, components.GameplayEventData{Kind: components.EventBoomerangCatch})
+	components.Player.Get(b.Owner).ActiveBoomerang = nil
+	PlaySFX(ecs, cfg.SoundBoomerangCatch)
+}
+
+// ricochet bounces the boomerang off the walls and slopes it flew into this
+// frame. Each bounce starts a new hit set, so enemies can be hit again, and
+// loses some damage. Returns false if the boomerang is wedged in a wall with
+// no side to bounce off, and should return instead.
+func ricochet(ecs *ecs.ECS, b *components.BoomerangData, physics *components.PhysicsData, obj *components.ObjectData) bool {
+	if len(touchingWalls(obj.Object, obj.X, obj.Y)) == 0 {
+		return true
+	}
+	vx, vy, ok := wallBounce(obj.Object, obj.X, obj.Y, physics.SpeedX, physics.SpeedY)
+	if !ok {
+		return false
+	}
+	obj.X, obj.Y = obj.X-physics.SpeedX, obj.Y-physics.SpeedY
+	physics.SpeedX, physics.SpeedY = vx, vy
+	obj.Update()
+
+	b.Bounces--
+	b.HitEnemies = make(map[*donburi.Entry]struct{})
+	b.Damage = max(1, int(math.Round(float64(b.Damage)*cfg.Boomerang.RicochetDamageFalloff)))
+
+	PlaySFX(ecs, cfg.SoundBoomerangImpact)
+	factory.SpawnExplosion(ecs, obj.X+obj.W/2, obj.Y+obj.H/2, 0.3+b.ChargeRatio*0.2)
+	return true
+}
+
+// wallBounce returns the speed a boomerang that moved by (vx, vy) to (x, y),
+// into a wall or slope, leaves it with. ok is false if it was already in a
+// wall before the move, with no side to bounce off.
+func wallBounce(obj *resolv.Object, x, y, vx, vy float64) (float64, float64, bool) {
+	prevX, prevY := x-vx, y-vy
+	hits := touchingWalls(obj, x, y)
+	if len(hits) == 0 {
+		return vx, vy, true
+	}
+	if len(touchingWalls(obj, prevX, prevY)) > 0 {
+		return vx, vy, false
+	}
+
+	// Reflect off a slope's surface, or off the side of a wall the boomerang
+	// crossed: moving along only one axis tells which side that was
+	switch ramp := hits[0]; {
+	case len(hits) == 1 && ramp.HasTags(tags.Slope45UpRight) && vx+vy > 0:
+		return -vy, -vx, true
+	case len(hits) == 1 && ramp.HasTags(tags.Slope45UpLeft) && vy > vx:
+		return vy, vx, true
+	}
+	hitX := len(touchingWalls(obj, x, prevY)) > 0
+	hitY := len(touchingWalls(obj, prevX, y)) > 0
+	if hitX || !hitY {
+		vx = -vx
+	}
+	if hitY || !hitX {
+		vy = -vy
+	}
+	return vx, vy, true
+}
+
+// touchingWalls returns the solids, and the slopes whose surface, the
+// boomerang would overlap at (x, y)
+func touchingWalls(obj *resolv.Object, x, y float64) []*resolv.Object {
+	check := obj.Check(x-obj.X, y-obj.Y, tags.ResolvSolid, tags.ResolvRamp)
+	if check == nil {
+		return nil
+	}
+	right, bottom := x+obj.W, y+obj.H
+	var hits []*resolv.Object
+	for _, o := range check.Objects {
+		if right <= o.X || x >= o.X+o.W || bottom <= o.Y || y >= o.Y+o.H {
+			continue
+		}
+		// Only the corner nearest a slope's surface can be under it
+		switch {
+		case o.HasTags(tags.Slope45UpRight):
+			if bottom <= getSlopeSurfaceAt(o, right) {
+				continue
+			}
+		case o.HasTags(tags.Slope45UpLeft):
+			if bottom <= getSlopeSurfaceAt(o, x) {
+				continue
 			}
 		}
+		hits = append(hits, o)
 	}
+	return hits
 }
 
 func handleEnemyCollision(ecs *ecs.ECS, boomerangEntry *donburi.Entry, b *components.BoomerangData, physics *components.PhysicsData, enemyObj *resolv.Object) {
@@ -161,8 +440,14 @@ func handleEnemyCollision(ecs *ecs.ECS, boomerangEntry *donburi.Entry, b *compon
 	TriggerScreenShake(ecs, cfg.ScreenShake.BoomerangIntensity, cfg.ScreenShake.BoomerangDuration)
 
 	// Apply Damage
+	publishGameplayEvent(ecs, enemyEntry, components.GameplayEventData{Kind: components.EventBoomerangHit, Amount: b.Damage})
 	if health := components.Health.Get(enemyEntry); health != nil {
 		health.Current -= b.Damage
+		publishGameplayEvent(ecs, enemyEntry, components.GameplayEventData{
+			Kind:   components.EventDamageDealt,
+			Amount: b.Damage,
+			Detail: "boomerang",
+		})
 
 		// Show health bar on hit
 		if !enemyEntry.HasComponent(components.HealthBar) {
@@ -205,11 +490,30 @@ func handleEnemyCollision(ecs *ecs.ECS, boomerangEntry *donburi.Entry, b *compon
 		enemyComp.InvulnFrames = cfg.Combat.EnemyInvulnFrames / 2
 	}
 
+	// Fire sets the enemy burning, restarting any burn already going
+	if b.BurnDamage > 0 {
+		if enemyComp := components.Enemy.Get(enemyEntry); enemyComp != nil {
+			enemyComp.BurnFrames = cfg.BoomerangModifier.BurnFrames
+			enemyComp.BurnDamage = b.BurnDamage
+		}
+	}
+
+	// Lifesteal heals the owner, up to their max health
+	if b.Lifesteal > 0 && b.Owner != nil && b.Owner.Valid() && !b.Owner.HasComponent(components.Death) {
+		if health := components.Health.Get(b.Owner); health != nil {
+			health.Current = min(health.Max, health.Current+b.Lifesteal)
+		}
+	}
+
 	// Add to hit map
 	b.HitEnemies[enemyEntry] = struct{}{}
 
-	// Short Return Rule
+	// Short Return Rule, once it has pierced all the enemies it can
 	if b.State == components.BoomerangOutbound {
+		if b.Pierces > 0 {
+			b.Pierces--
+			return
+		}
 		newMax := b.DistanceTraveled + b.PierceDistance
 		if newMax < b.MaxRange {
 			b.MaxRange = newMax
@@ -218,17 +522,74 @@ func handleEnemyCollision(ecs *ecs.ECS, boomerangEntry *donburi.Entry, b *compon
 }
 
 func catchBoomerang(ecs *ecs.ECS, e *donburi.Entry, b *components.BoomerangData) {
+	defer destroyBoomerang(ecs, e, components.Object.Get(e))
+	if b.Twin {
+		return // Only the active boomerang's catch counts
+	}
+	publishGameplayEvent(ecs, b.Owner, components.GameplayEventData{Kind: components.EventBoomerangCatch})
+
+	if b.Owner != nil && b.Owner.Valid() && b.Owner.HasComponent(components.Player) {
+		player := components.Player.Get(b.Owner)
+		player.ActiveBoomerang = nil
+
+		// A press just before the catch makes it perfect; without one, a
+		// press just after still can
+		if player.PerfectCatchWindow > 0 {
+			perfectCatch(ecs, b.Owner, player)
+			return
+		}
+		player.PerfectCatchWindow = cfg.Boomerang.PerfectCatchWindow
+	}
+
 	// Play catch sound
 	PlaySFX(ecs, cfg.SoundBoomerangCatch)
+}
+
+// updateBoomerangButton handles presses of throw while the boomerang is out
+// or just caught. A press pulls an embedded boomerang out of its wall. It also
+// runs the perfect catch window: the first press while the boomerang is out
+// opens it for a catch, so mashing the button doesn't pay, and a press in the
+// window a plain catch opens completes a late perfect catch.
+func updateBoomerangButton(ecs *ecs.ECS, input *components.InputData, playerEntry *donburi.Entry, player *components.PlayerData) {
+	if player.PerfectCatchWindow > 0 {
+		player.PerfectCatchWindow--
+	}
+	if player.ChargeBonusFrames > 0 {
+		player.ChargeBonusFrames--
+	}
+	if !GetAction(input, cfg.ActionBoomerang).JustPressed {
+		return
+	}
 
-	if b.Owner != nil && b.Owner.Valid() {
-		if b.Owner.HasComponent(components.Player) {
-			player := components.Player.Get(b.Owner)
-			player.ActiveBoomerang = nil
+	active := player.ActiveBoomerang != nil && player.ActiveBoomerang.Valid()
+	switch {
+	case active && components.Boomerang.Get(player.ActiveBoomerang).State == components.BoomerangEmbedded:
+		recallBoomerang(player)
+	case active:
+		if b := components.Boomerang.Get(player.ActiveBoomerang); !b.CatchPressed {
+			b.CatchPressed = true
+			player.PerfectCatchWindow = cfg.Boomerang.PerfectCatchWindow
 		}
+	case player.ActiveBoomerang == nil && player.PerfectCatchWindow > 0:
+		perfectCatch(ecs, playerEntry, player)
+	}
+}
+
+// perfectCatch rewards a well timed catch: the next throw, if started soon,
+// begins part charged
+func perfectCatch(ecs *ecs.ECS, playerEntry *donburi.Entry, player *components.PlayerData) {
+	player.PerfectCatchWindow = 0
+	player.ChargeBonusFrames = cfg.Boomerang.PerfectCatchBonus
+
+	if entry, ok := components.RunStats.First(ecs.World); ok {
+		components.RunStats.Get(entry).PerfectCatches++
 	}
 
-	destroyBoomerang(ecs, e, components.Object.Get(e))
+	PlaySFX(ecs, cfg.SoundPerfectCatch)
+	TriggerCatchFlash(playerEntry)
+	obj := components.Object.Get(playerEntry)
+	factory.SpawnPlasma(ecs, obj.X+obj.W/2, obj.Y+obj.H/2)
+	publishGameplayEvent(ecs, playerEntry, components.GameplayEventData{Kind: components.EventPerfectCatch})
 }
 
 func destroyBoomerang(ecs *ecs.ECS, e *donburi.Entry, obj *components.ObjectData) {
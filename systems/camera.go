@@ -57,6 +57,17 @@ func UpdateCamera(e *ecs.ECS) {
 	minCameraY := screenHeight / 2
 	maxCameraY := levelHeight - screenHeight/2
 
+	// In generated levels with vertical sections the level's bounding box extends
+	// below most rooms; keep the bottom of the current room at the bottom of the
+	// screen so the empty space under it stays hidden.
+	for _, room := range levelData.CurrentLevel.Rooms {
+		if room.Contains(playerObject.X, playerObject.Y) {
+			maxCameraY = math.Min(maxCameraY, room.Y+room.Height-screenHeight/2)
+			break
+		}
+	}
+	maxCameraY = math.Max(minCameraY, maxCameraY)
+
 	// Constrain target position to camera bounds
 	targetX = math.Max(minCameraX, math.Min(maxCameraX, targetX))
 	targetY = math.Max(minCameraY, math.Min(maxCameraY, targetY))
@@ -0,0 +1,113 @@
+package systems
+
+import (
+	"fmt"
+
+	"github.com/automoto/doomerang/components"
+	cfg "github.com/automoto/doomerang/config"
+	"github.com/automoto/doomerang/fonts"
+	"github.com/automoto/doomerang/tags"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/yohamta/donburi"
+	"github.com/yohamta/donburi/ecs"
+)
+
+// PlayerCarryover holds the player state carried from one campaign level to the next.
+type PlayerCarryover struct {
+	Lives  int
+	Health int
+}
+
+// SnapshotPlayerCarryover captures the player's lives and health.
+// Returns nil if there is no player in the world.
+func SnapshotPlayerCarryover(e *ecs.ECS) *PlayerCarryover {
+	playerEntry, ok := tags.Player.First(e.World)
+	if !ok {
+		return nil
+	}
+	return &PlayerCarryover{
+		Lives:  components.Lives.Get(playerEntry).Lives,
+		Health: components.Health.Get(playerEntry).Current,
+	}
+}
+
+// ApplyPlayerCarryover restores carried lives and health onto a freshly created player.
+// Values outside 1 to the player's maximums are ignored; a nil carryover is a no-op.
+func ApplyPlayerCarryover(playerEntry *donburi.Entry, carry *PlayerCarryover) {
+	if carry == nil {
+		return
+	}
+
+	lives := components.Lives.Get(playerEntry)
+	if carry.Lives > 0 && carry.Lives <= lives.MaxLives {
+		lives.Lives = carry.Lives
+	}
+
+	health := components.Health.Get(playerEntry)
+	if carry.Health > 0 && carry.Health <= health.Max {
+		health.Current = carry.Health
+	}
+}
+
+// NewUpdateCampaignComplete creates the update system for the campaign complete screen.
+func NewUpdateCampaignComplete(sceneChanger SceneChanger, createMenuScene func() interface{}) ecs.System {
+	// Skip the first frame so the confirm press that closed the level complete
+	// overlay doesn't immediately dismiss this screen too.
+	firstFrame := true
+	return func(e *ecs.ECS) {
+		if firstFrame {
+			firstFrame = false
+			return
+		}
+
+		input := getOrCreateInput(e)
+		if GetAction(input, cfg.ActionMenuSelect).JustPressed {
+			PlaySFX(e, cfg.SoundMenuSelect)
+			sceneChanger.ChangeScene(createMenuScene())
+		}
+	}
+}
+
+// DrawCampaignComplete returns a renderer for the campaign complete screen.
+func DrawCampaignComplete(levelCount int) func(*ecs.ECS, *ebiten.Image) {
+	return func(e *ecs.ECS, screen *ebiten.Image) {
+		width := float64(screen.Bounds().Dx())
+		height := float64(screen.Bounds().Dy())
+
+		vector.FillRect(
+			screen,
+			0, 0,
+			float32(width), float32(height),
+			cfg.CampaignComplete.BackgroundColor,
+			false,
+		)
+
+		titleFont := fonts.ExcelTitle.GetV2()
+		title := cfg.CampaignComplete.Title
+		titleX := centerTextX(title, titleFont, width)
+		drawText(screen, title, titleFont, titleX, int(cfg.CampaignComplete.TitleY), cfg.CampaignComplete.TitleColor)
+
+		msgFont := fonts.ExcelBold.GetV2()
+		msg := fmt.Sprintf(cfg.CampaignComplete.MessageFormat, levelCount)
+		msgX := centerTextX(msg, msgFont, width)
+		drawText(screen, msg, msgFont, msgX, int(cfg.CampaignComplete.MessageY), cfg.CampaignComplete.TextColor)
+
+		hintFont := fonts.ExcelSmall.GetV2()
+		input := getOrCreateInput(e)
+		hint := getCampaignCompleteHint(input.LastInputMethod)
+		hintX := centerTextX(hint, hintFont, width)
+		drawText(screen, hint, hintFont, hintX, int(cfg.CampaignComplete.HintY), cfg.CampaignComplete.HintColor)
+	}
+}
+
+// getCampaignCompleteHint returns the appropriate hint for the campaign complete screen
+func getCampaignCompleteHint(method components.InputMethod) string {
+	switch method {
+	case components.InputPlayStation:
+		return "Press Cross to return to menu"
+	case components.InputXbox:
+		return "Press A to return to menu"
+	}
+	return cfg.CampaignComplete.ContinueHint
+}
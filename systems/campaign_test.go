@@ -0,0 +1,143 @@
+package systems_test
+
+import (
+	"testing"
+
+	"github.com/automoto/doomerang/assets"
+	"github.com/automoto/doomerang/components"
+	cfg "github.com/automoto/doomerang/config"
+	"github.com/automoto/doomerang/systems"
+	"github.com/automoto/doomerang/tags"
+	"github.com/yohamta/donburi"
+	"github.com/yohamta/donburi/ecs"
+)
+
+func addCarryoverPlayer(e *ecs.ECS, lives, health int) *donburi.Entry {
+	entry := e.World.Entry(e.Create(cfg.Default, tags.Player, components.Lives, components.Health))
+	components.Lives.SetValue(entry, components.LivesData{Lives: lives, MaxLives: 3})
+	components.Health.SetValue(entry, components.HealthData{Current: health, Max: 100})
+	return entry
+}
+
+func TestSnapshotPlayerCarryover(t *testing.T) {
+	e := newTestECS()
+	addCarryoverPlayer(e, 2, 40)
+
+	carry := systems.SnapshotPlayerCarryover(e)
+	if carry == nil {
+		t.Fatal("expected carryover, got nil")
+	}
+	if carry.Lives != 2 || carry.Health != 40 {
+		t.Errorf("expected lives=2 health=40, got lives=%d health=%d", carry.Lives, carry.Health)
+	}
+}
+
+func TestSnapshotPlayerCarryover_NoPlayer(t *testing.T) {
+	e := newTestECS()
+	if carry := systems.SnapshotPlayerCarryover(e); carry != nil {
+		t.Errorf("expected nil carryover without a player, got %+v", carry)
+	}
+}
+
+func TestApplyPlayerCarryover(t *testing.T) {
+	e := newTestECS()
+	player := addCarryoverPlayer(e, 3, 100)
+
+	systems.ApplyPlayerCarryover(player, &systems.PlayerCarryover{Lives: 1, Health: 25})
+
+	if got := components.Lives.Get(player).Lives; got != 1 {
+		t.Errorf("expected lives=1, got %d", got)
+	}
+	if got := components.Health.Get(player).Current; got != 25 {
+		t.Errorf("expected health=25, got %d", got)
+	}
+}
+
+func TestApplyPlayerCarryover_IgnoresOutOfRange(t *testing.T) {
+	e := newTestECS()
+	player := addCarryoverPlayer(e, 3, 100)
+
+	systems.ApplyPlayerCarryover(player, &systems.PlayerCarryover{Lives: 9, Health: 0})
+
+	if got := components.Lives.Get(player).Lives; got != 3 {
+		t.Errorf("expected lives unchanged at 3, got %d", got)
+	}
+	if got := components.Health.Get(player).Current; got != 100 {
+		t.Errorf("expected health unchanged at 100, got %d", got)
+	}
+}
+
+type recordingSceneChanger struct{ scene interface{} }
+
+func (r *recordingSceneChanger) ChangeScene(scene interface{}) { r.scene = scene }
+
+// completeLevel confirms the level complete overlay for level index of a
+// three level campaign
+func completeLevel(t *testing.T, index int, campaign bool) {
+	t.Helper()
+	e := newTestECS()
+	levelEntry := e.World.Entry(e.Create(cfg.Default, components.Level))
+	components.Level.SetValue(levelEntry, components.LevelData{
+		CurrentLevel: &assets.Level{Name: "levels/test_level.tmx"},
+		LevelIndex:   index,
+		Levels:       make([]assets.Level, 3),
+		Campaign:     campaign,
+	})
+	systems.GetOrCreateLevelComplete(e).IsComplete = true
+	inputEntry := e.World.Entry(e.Create(cfg.Default, components.Input))
+	components.Input.Get(inputEntry).Current[cfg.ActionMenuSelect] = true
+
+	changer := &recordingSceneChanger{}
+	update := systems.NewUpdateLevelComplete(changer,
+		func(int, *systems.PlayerCarryover) interface{} { return "next" },
+		func() interface{} { return "complete" })
+	update(e)
+	if changer.scene == nil {
+		t.Fatal("expected confirming the overlay to change scene")
+	}
+}
+
+func TestLevelComplete_LevelSelectReplayKeepsProgress(t *testing.T) {
+	setupPersistence(t)
+	t.Cleanup(func() { _ = systems.ClearGameProgress() })
+	if err := systems.ClearGameProgress(); err != nil {
+		t.Fatalf("ClearGameProgress failed: %v", err)
+	}
+	if err := systems.SaveLevelProgress(2); err != nil {
+		t.Fatalf("SaveLevelProgress failed: %v", err)
+	}
+
+	// Replaying the first and the last level from level select
+	completeLevel(t, 0, false)
+	completeLevel(t, 2, false)
+
+	progress, err := systems.LoadGameProgress()
+	if err != nil || progress == nil {
+		t.Fatalf("expected the save to survive, got %+v, %v", progress, err)
+	}
+	if progress.LevelIndex != 2 {
+		t.Errorf("expected saved level 2, got %d", progress.LevelIndex)
+	}
+}
+
+func TestLevelComplete_CampaignNeverLowersProgress(t *testing.T) {
+	setupPersistence(t)
+	t.Cleanup(func() { _ = systems.ClearGameProgress() })
+	if err := systems.ClearGameProgress(); err != nil {
+		t.Fatalf("ClearGameProgress failed: %v", err)
+	}
+	if err := systems.SaveLevelProgress(2); err != nil {
+		t.Fatalf("SaveLevelProgress failed: %v", err)
+	}
+
+	completeLevel(t, 0, true)
+	if progress, _ := systems.LoadGameProgress(); progress == nil || progress.LevelIndex != 2 {
+		t.Errorf("expected saved level 2 to be kept, got %+v", progress)
+	}
+
+	// Finishing the campaign clears the save
+	completeLevel(t, 2, true)
+	if progress, _ := systems.LoadGameProgress(); progress != nil {
+		t.Errorf("expected the save to be cleared, got %+v", progress)
+	}
+}
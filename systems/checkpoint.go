@@ -1,6 +1,7 @@
 package systems
 
 import (
+	"fmt"
 	"log"
 
 	"github.com/automoto/doomerang/components"
@@ -45,6 +46,10 @@ func UpdateCheckpoints(ecs *ecs.ECS) {
 
 	// Activate checkpoint
 	checkpoint.Activated = true
+	publishGameplayEvent(ecs, playerEntry, components.GameplayEventData{
+		Kind:   components.EventCheckpoint,
+		Detail: fmt.Sprintf("%g", checkpoint.CheckpointID),
+	})
 
 	// Update level's active checkpoint
 	levelEntry, ok := components.Level.First(ecs.World)
@@ -59,6 +64,9 @@ func UpdateCheckpoints(ecs *ecs.ECS) {
 		CheckpointID: checkpoint.CheckpointID,
 	}
 
+	if !levelData.Campaign {
+		return
+	}
 	if err := SaveGameProgress(levelData.LevelIndex, levelData.ActiveCheckpoint); err != nil {
 		log.Printf("Warning: Could not save game progress: %v", err)
 	}
@@ -0,0 +1,45 @@
+//go:build !js
+
+package systems
+
+import (
+	"log"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+// clipboardCommands returns the commands that print the clipboard on this OS,
+// in the order they are tried
+func clipboardCommands() [][]string {
+	switch runtime.GOOS {
+	case "darwin":
+		return [][]string{{"pbpaste"}}
+	case "windows":
+		return [][]string{{"powershell", "-NoProfile", "-Command", "Get-Clipboard"}}
+	}
+	return [][]string{
+		{"wl-paste", "--no-newline"},
+		{"xclip", "-selection", "clipboard", "-o"},
+		{"xsel", "--clipboard", "--output"},
+	}
+}
+
+// pastedText returns the clipboard text when the paste shortcut (Ctrl+V, or
+// Cmd+V on macOS) was just pressed, and "" otherwise. Ebiten has no clipboard
+// API, so the text is read through the platform's clipboard tool.
+func pastedText() string {
+	if !inpututil.IsKeyJustPressed(ebiten.KeyV) || !(ebiten.IsKeyPressed(ebiten.KeyControl) || ebiten.IsKeyPressed(ebiten.KeyMeta)) {
+		return ""
+	}
+	for _, args := range clipboardCommands() {
+		if out, err := exec.Command(args[0], args[1:]...).Output(); err == nil {
+			return strings.TrimSpace(string(out))
+		}
+	}
+	log.Printf("Warning: Could not read the clipboard")
+	return ""
+}
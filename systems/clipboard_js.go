@@ -0,0 +1,39 @@
+//go:build js
+
+package systems
+
+import (
+	"sync"
+	"syscall/js"
+)
+
+var (
+	pasteListener sync.Once
+	pasteMu       sync.Mutex
+	pendingPaste  string
+)
+
+// pastedText returns the text pasted into the page since the last call.
+// Browsers only hand over the clipboard in a paste event, which Ctrl+V raises.
+func pastedText() string {
+	pasteListener.Do(func() {
+		doc := js.Global().Get("document")
+		if !doc.Truthy() {
+			return
+		}
+		doc.Call("addEventListener", "paste", js.FuncOf(func(this js.Value, args []js.Value) any {
+			if data := args[0].Get("clipboardData"); data.Truthy() {
+				pasteMu.Lock()
+				pendingPaste += data.Call("getData", "text").String()
+				pasteMu.Unlock()
+			}
+			return nil
+		}))
+	})
+
+	pasteMu.Lock()
+	defer pasteMu.Unlock()
+	text := pendingPaste
+	pendingPaste = ""
+	return text
+}
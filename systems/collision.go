@@ -268,8 +268,12 @@ func snapToSlopeSurface(physics *components.PhysicsData, object *resolv.Object,
 
 // getSlopeSurfaceY calculates the slope surface Y at the object's center X position
 func getSlopeSurfaceY(object *resolv.Object, ramp *resolv.Object) float64 {
-	playerCenterX := object.X + object.W/2
-	relativeX := clampFloat(playerCenterX-ramp.X, 0, ramp.W)
+	return getSlopeSurfaceAt(ramp, object.X+object.W/2)
+}
+
+// getSlopeSurfaceAt calculates the slope surface Y at world position x
+func getSlopeSurfaceAt(ramp *resolv.Object, x float64) float64 {
+	relativeX := clampFloat(x-ramp.X, 0, ramp.W)
 	slope := relativeX / ramp.W
 
 	switch {
@@ -366,6 +370,10 @@ func handleDeadZoneHit(ecs *ecs.ECS, e *donburi.Entry) {
 	lives := components.Lives.Get(e)
 	lives.Lives--
 
+	if stats, ok := components.RunStats.First(ecs.World); ok {
+		components.RunStats.Get(stats).LastDamageSource = "Fall"
+	}
+
 	obj := components.Object.Get(e)
 	centerX := obj.X + obj.W/2
 	centerY := obj.Y + obj.H/2
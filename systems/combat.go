@@ -27,6 +27,29 @@ func UpdateCombat(ecs *ecs.ECS) {
 		hp := components.Health.Get(e)
 		hp.Current -= dmg.Amount
 
+		damageKind := components.EventDamageDealt
+		if e.HasComponent(components.Player) {
+			damageKind = components.EventDamageTaken
+		}
+		publishGameplayEvent(ecs, e, components.GameplayEventData{
+			Kind:   damageKind,
+			Amount: dmg.Amount,
+			Detail: dmg.Source,
+		})
+
+		if e.HasComponent(components.Player) {
+			if stats, ok := components.RunStats.First(ecs.World); ok {
+				runStats := components.RunStats.Get(stats)
+				runStats.LastDamageSource = dmg.Source
+				if obj := components.Object.Get(e); obj != nil {
+					if room := runStats.CurrentRoom(obj.X, obj.Y); room != nil {
+						room.DamageTaken += dmg.Amount
+					}
+				}
+			}
+			dropBoomerang(e)
+		}
+
 		// If the entity is an enemy, show the health bar.
 		if e.HasComponent(tags.Enemy) {
 			donburi.Add(e, components.HealthBar, &components.HealthBarData{
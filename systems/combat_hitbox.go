@@ -376,6 +376,7 @@ func applyHitToEnemy(ecs *ecs.ECS, enemyEntry *donburi.Entry, hitbox *components
 	// Apply damage
 	donburi.Add(enemyEntry, components.DamageEvent, &components.DamageEventData{
 		Amount: hitbox.Damage,
+		Source: hitbox.AttackType,
 	})
 
 	// Apply knockback
@@ -399,8 +400,13 @@ func applyHitToPlayer(ecs *ecs.ECS, playerEntry *donburi.Entry, hitbox *componen
 	TriggerScreenShake(ecs, cfg.ScreenShake.PlayerDamageIntensity, cfg.ScreenShake.PlayerDamageDuration)
 
 	// Apply damage
+	source := ""
+	if hitbox.OwnerEntity != nil && hitbox.OwnerEntity.Valid() && hitbox.OwnerEntity.HasComponent(components.Enemy) {
+		source = components.Enemy.Get(hitbox.OwnerEntity).TypeName
+	}
 	donburi.Add(playerEntry, components.DamageEvent, &components.DamageEventData{
 		Amount: hitbox.Damage,
+		Source: source,
 	})
 
 	// Apply knockback
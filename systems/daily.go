@@ -0,0 +1,166 @@
+package systems
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/automoto/doomerang/components"
+	cfg "github.com/automoto/doomerang/config"
+	"github.com/automoto/doomerang/fonts"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/yohamta/donburi/ecs"
+)
+
+// DailyHistory returns saved daily results newest first.
+func DailyHistory(runs map[string]SavedDailyRun) []SavedDailyRun {
+	history := make([]SavedDailyRun, 0, len(runs))
+	for _, run := range runs {
+		history = append(history, run)
+	}
+	// Date keys are ISO dates, so string order is calendar order
+	sort.Slice(history, func(i, j int) bool {
+		return history[i].Date > history[j].Date
+	})
+	return history
+}
+
+// GetOrCreateDaily returns the singleton Daily component, creating if needed
+func GetOrCreateDaily(e *ecs.ECS) *components.DailyData {
+	if _, ok := components.Daily.First(e.World); !ok {
+		ent := e.World.Entry(e.World.Create(components.Daily))
+		components.Daily.SetValue(ent, components.DailyData{})
+	}
+
+	ent, _ := components.Daily.First(e.World)
+	return components.Daily.Get(ent)
+}
+
+// NewUpdateDaily creates the update system for the daily run screen.
+// Select starts today's run, up/down scroll the history and back returns to the menu.
+func NewUpdateDaily(
+	sceneChanger SceneChanger,
+	history []SavedDailyRun,
+	createDailyRunScene func() interface{},
+	createMenuScene func() interface{},
+) ecs.System {
+	// Skip the first frame so the confirm press that opened this screen
+	// from the main menu doesn't immediately start the run.
+	firstFrame := true
+	return func(e *ecs.ECS) {
+		if firstFrame {
+			firstFrame = false
+			return
+		}
+
+		daily := GetOrCreateDaily(e)
+		input := getOrCreateInput(e)
+
+		if GetAction(input, cfg.ActionMenuBack).JustPressed {
+			PlaySFX(e, cfg.SoundMenuNavigate)
+			sceneChanger.ChangeScene(createMenuScene())
+			return
+		}
+
+		maxScroll := len(history) - cfg.Daily.VisibleRows
+		if GetAction(input, cfg.ActionMenuUp).JustPressed && daily.ScrollOffset > 0 {
+			PlaySFX(e, cfg.SoundMenuNavigate)
+			daily.ScrollOffset--
+		}
+		if GetAction(input, cfg.ActionMenuDown).JustPressed && daily.ScrollOffset < maxScroll {
+			PlaySFX(e, cfg.SoundMenuNavigate)
+			daily.ScrollOffset++
+		}
+
+		if GetAction(input, cfg.ActionMenuSelect).JustPressed {
+			PlaySFX(e, cfg.SoundMenuSelect)
+			FadeOutMusic(e)
+			sceneChanger.ChangeScene(createDailyRunScene())
+		}
+	}
+}
+
+// DrawDaily returns a renderer for the daily run screen. today is the current
+// day's key and seedCode its run; history includes today's result once played.
+func DrawDaily(today, seedCode string, history []SavedDailyRun) func(*ecs.ECS, *ebiten.Image) {
+	status := "Not played yet - your first attempt today is scored"
+	statusColor := cfg.Daily.HighlightColor
+	if len(history) > 0 && history[0].Date == today {
+		status = "Today's attempt: " + dailyResultText(history[0]) + " - replays are practice"
+		statusColor = cfg.Daily.TextColor
+	}
+
+	return func(e *ecs.ECS, screen *ebiten.Image) {
+		daily := GetOrCreateDaily(e)
+		width := float64(screen.Bounds().Dx())
+		height := float64(screen.Bounds().Dy())
+
+		vector.FillRect(
+			screen,
+			0, 0,
+			float32(width), float32(height),
+			cfg.Daily.BackgroundColor,
+			false,
+		)
+
+		titleFont := fonts.ExcelTitle.GetV2()
+		titleX := centerTextX(cfg.Daily.Title, titleFont, width)
+		drawText(screen, cfg.Daily.Title, titleFont, titleX, int(cfg.Daily.TitleY), cfg.Daily.TitleColor)
+
+		rowFont := fonts.ExcelBold.GetV2()
+		todayText := fmt.Sprintf("%s   Seed %s", today, seedCode)
+		drawText(screen, todayText, rowFont, centerTextX(todayText, rowFont, width), int(cfg.Daily.TodayY), cfg.Daily.TextColor)
+
+		smallFont := fonts.ExcelSmall.GetV2()
+		drawText(screen, status, smallFont, centerTextX(status, smallFont, width), int(cfg.Daily.StatusY), statusColor)
+
+		historyTitle := "Past results"
+		if len(history) == 0 {
+			historyTitle = "No daily runs played yet"
+		}
+		drawText(screen, historyTitle, rowFont, int(cfg.Daily.DateX), int(cfg.Daily.HistoryTitleY), cfg.Daily.TitleColor)
+
+		for i := daily.ScrollOffset; i < len(history) && i < daily.ScrollOffset+cfg.Daily.VisibleRows; i++ {
+			run := history[i]
+			y := int(cfg.Daily.HistoryStartY) + (i-daily.ScrollOffset)*int(cfg.Daily.RowHeight)
+
+			outcomeColor := cfg.Daily.FailedColor
+			if run.Outcome == RunCleared {
+				outcomeColor = cfg.Daily.ClearedColor
+			}
+			drawText(screen, run.Date, rowFont, int(cfg.Daily.DateX), y, cfg.Daily.TextColor)
+			drawText(screen, getRunOutcomeLabel(run.Outcome), rowFont, int(cfg.Daily.OutcomeX), y, outcomeColor)
+			if run.Outcome == RunAbandoned {
+				continue
+			}
+			drawText(screen, fmt.Sprintf("%d/%d rooms", run.RoomsCleared, run.TotalRooms), rowFont, int(cfg.Daily.RoomsX), y, cfg.Daily.TextColor)
+			drawText(screen, fmt.Sprintf("%d kills", run.KillCount), rowFont, int(cfg.Daily.KillsX), y, cfg.Daily.TextColor)
+			drawText(screen, fmt.Sprintf("%dm %02ds", run.ElapsedSecs/60, run.ElapsedSecs%60), rowFont, int(cfg.Daily.TimeX), y, cfg.Daily.TextColor)
+		}
+
+		input := getOrCreateInput(e)
+		hint := getDailyHint(input.LastInputMethod)
+		hintX := centerTextX(hint, smallFont, width)
+		drawText(screen, hint, smallFont, hintX, int(height)-12, cfg.Daily.TextColor)
+	}
+}
+
+// dailyResultText summarizes a daily result in one line
+func dailyResultText(run SavedDailyRun) string {
+	if run.Outcome == RunAbandoned {
+		return getRunOutcomeLabel(run.Outcome)
+	}
+	return fmt.Sprintf("%s, %d/%d rooms, %d kills, %dm %02ds", getRunOutcomeLabel(run.Outcome),
+		run.RoomsCleared, run.TotalRooms, run.KillCount, run.ElapsedSecs/60, run.ElapsedSecs%60)
+}
+
+// getDailyHint returns the appropriate hint for daily screen navigation
+func getDailyHint(method components.InputMethod) string {
+	switch method {
+	case components.InputPlayStation:
+		return "D-Pad: Scroll   Cross: Play   Circle: Back"
+	case components.InputXbox:
+		return "D-Pad: Scroll   A: Play   B: Back"
+	}
+	return "Arrows: Scroll   Enter: Play   Esc: Back"
+}
@@ -0,0 +1,112 @@
+package systems_test
+
+import (
+	"testing"
+
+	"github.com/automoto/doomerang/assets"
+	"github.com/automoto/doomerang/systems"
+)
+
+func setupDailyPersistence(t *testing.T) {
+	t.Helper()
+	if err := systems.InitPersistence(); err != nil {
+		t.Skipf("persistence not available in this environment: %v", err)
+	}
+	if err := systems.ClearDailyRuns(); err != nil {
+		t.Fatalf("failed to clear daily runs: %v", err)
+	}
+}
+
+func TestDailyFirstAttemptScored(t *testing.T) {
+	setupDailyPersistence(t)
+
+	if !systems.StartDailyAttempt("2026-10-17", "ABCD-EF") {
+		t.Fatal("expected the first attempt of the day to be scored")
+	}
+	if systems.StartDailyAttempt("2026-10-17", "ABCD-EF") {
+		t.Error("expected a second attempt on the same day to be practice")
+	}
+	if !systems.StartDailyAttempt("2026-10-18", "GHJK-MN") {
+		t.Error("expected the next day's first attempt to be scored")
+	}
+
+	runs, _ := systems.LoadDailyRuns()
+	if runs["2026-10-17"].Outcome != systems.RunAbandoned {
+		t.Errorf("expected an unfinished attempt to be saved as abandoned, got %q", runs["2026-10-17"].Outcome)
+	}
+}
+
+func TestDailyAttemptKeepsUnreadableRecords(t *testing.T) {
+	setupDailyPersistence(t)
+	defer systems.ClearDailyRuns()
+	if err := systems.SaveRawItem("daily_runs", []byte("{not json")); err != nil {
+		t.Fatalf("SaveRawItem failed: %v", err)
+	}
+
+	if systems.StartDailyAttempt("2026-10-17", "ABCD-EF") {
+		t.Error("expected the attempt not to be scored when the records can't be read")
+	}
+	if _, err := systems.LoadDailyRuns(); err == nil {
+		t.Error("expected the unreadable records to be left alone")
+	}
+}
+
+func TestDailyRunsKeptPerChunkPack(t *testing.T) {
+	setupDailyPersistence(t)
+	systems.StartDailyAttempt("2026-10-17", "ABCD-EF")
+
+	// The pack's chunks make a different daily run, scored on its own
+	assets.UseLevelPack(&assets.LevelPack{ID: "neon-city", Chunks: 4})
+	defer assets.UseLevelPack(nil)
+	if err := systems.ClearDailyRuns(); err != nil {
+		t.Fatalf("failed to clear daily runs: %v", err)
+	}
+	if !systems.StartDailyAttempt("2026-10-17", "GHJK-MN") {
+		t.Error("expected the pack's daily run to be scored separately")
+	}
+
+	assets.UseLevelPack(nil)
+	runs, _ := systems.LoadDailyRuns()
+	if runs["2026-10-17"].SeedCode != "ABCD-EF" {
+		t.Errorf("expected the built-in daily result to be kept, got %+v", runs["2026-10-17"])
+	}
+}
+
+func TestDailyResultSavedOnce(t *testing.T) {
+	setupDailyPersistence(t)
+	systems.StartDailyAttempt("2026-10-17", "ABCD-EF")
+
+	first := systems.FinalRunStats{RoomsCleared: 4, TotalRooms: 10, KillCount: 7, ElapsedSecs: 95}
+	if err := systems.SaveDailyResult("2026-10-17", systems.RunDied, first); err != nil {
+		t.Fatalf("SaveDailyResult failed: %v", err)
+	}
+	// A later result for the same day doesn't overwrite the scored one
+	better := systems.FinalRunStats{RoomsCleared: 10, TotalRooms: 10, KillCount: 20, ElapsedSecs: 60}
+	_ = systems.SaveDailyResult("2026-10-17", systems.RunCleared, better)
+	// Days without a started attempt are ignored
+	_ = systems.SaveDailyResult("2026-10-19", systems.RunCleared, better)
+
+	runs, _ := systems.LoadDailyRuns()
+	got := runs["2026-10-17"]
+	if got.Outcome != systems.RunDied || got.RoomsCleared != 4 || got.KillCount != 7 || got.ElapsedSecs != 95 {
+		t.Errorf("expected the first result to be kept, got %+v", got)
+	}
+	if _, ok := runs["2026-10-19"]; ok {
+		t.Error("expected no record for a day that was never started")
+	}
+}
+
+func TestDailyHistoryNewestFirst(t *testing.T) {
+	history := systems.DailyHistory(map[string]systems.SavedDailyRun{
+		"2026-09-30": {Date: "2026-09-30"},
+		"2026-10-17": {Date: "2026-10-17"},
+		"2026-10-02": {Date: "2026-10-02"},
+	})
+
+	want := []string{"2026-10-17", "2026-10-02", "2026-09-30"}
+	for i, run := range history {
+		if run.Date != want[i] {
+			t.Errorf("row %d: expected %s, got %s", i, want[i], run.Date)
+		}
+	}
+}
@@ -21,6 +21,7 @@ func UpdateDeaths(ecs *ecs.ECS) {
 			}
 
 			// Non-player entity: remove from world
+			publishGameplayEvent(ecs, e, components.GameplayEventData{Kind: components.EventDeath})
 			spaceEntry, _ := components.Space.First(e.World)
 			space := components.Space.Get(spaceEntry)
 			if obj := components.Object.Get(e); obj != nil {
@@ -49,6 +50,27 @@ func handlePlayerDeath(ecs *ecs.ECS, e *donburi.Entry) {
 		return
 	}
 
+	// Count the death once, when its timer first expires
+	if entry, ok := components.RunStats.First(ecs.World); ok {
+		stats := components.RunStats.Get(entry)
+		stats.Deaths++
+		stats.CauseOfDeath = stats.LastDamageSource
+		stats.DeathRoom = stats.LastRoomIndex
+		if obj := components.Object.Get(e); obj != nil {
+			if i := stats.RoomAt(obj.X, obj.Y); i >= 0 {
+				stats.DeathRoom = i
+			}
+			if room := stats.CurrentRoom(obj.X, obj.Y); room != nil {
+				room.Deaths++
+			}
+		}
+	}
+	cause := ""
+	if entry, ok := components.RunStats.First(ecs.World); ok {
+		cause = components.RunStats.Get(entry).CauseOfDeath
+	}
+	publishGameplayEvent(ecs, e, components.GameplayEventData{Kind: components.EventDeath, Detail: cause})
+
 	// Death zone already decremented lives at collision time
 	if !death.IsDeathZone {
 		lives.Lives--
@@ -62,6 +84,7 @@ func handlePlayerDeath(ecs *ecs.ECS, e *donburi.Entry) {
 
 	donburi.Remove[components.DeathData](e, components.Death)
 	RespawnPlayerNearDeath(ecs, e)
+	publishGameplayEvent(ecs, e, components.GameplayEventData{Kind: components.EventRespawn})
 }
 
 // RespawnPlayer resets the player to checkpoint with full health and lives.
@@ -139,6 +162,7 @@ func resetPlayerAtPosition(e *donburi.Entry, spawnX, spawnY float64) {
 
 	player := components.Player.Get(e)
 	player.InvulnFrames = cfg.Player.RespawnInvulnFrames
+	recallBoomerang(player)
 
 	state := components.State.Get(e)
 	state.CurrentState = cfg.Idle
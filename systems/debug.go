@@ -25,6 +25,8 @@ func DrawDebug(ecs *ecs.ECS, screen *ebiten.Image) {
 	camX := float64(width)/2 - camera.Position.X
 	camY := float64(height)/2 - camera.Position.Y
 
+	drawHeatmap(ecs, screen, camX, camY)
+
 	// Draw collision grid (Disabled, showing objects only)
 	spaceEntry, ok := components.Space.First(ecs.World)
 	if ok {
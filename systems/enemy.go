@@ -40,6 +40,7 @@ func UpdateEnemies(e *ecs.ECS) {
 		if enemy.InvulnFrames > 0 {
 			enemy.InvulnFrames--
 		}
+		updateBurn(e, entry, enemy)
 
 		if entry.HasComponent(components.HealthBar) {
 			healthBar := components.HealthBar.Get(entry)
@@ -54,6 +55,28 @@ func UpdateEnemies(e *ecs.ECS) {
 	})
 }
 
+// updateBurn deals an enemy set on fire by a boomerang its burn damage every
+// BurnInterval frames until the burn runs out
+func updateBurn(e *ecs.ECS, enemyEntry *donburi.Entry, enemy *components.EnemyData) {
+	if enemy.BurnFrames <= 0 {
+		return
+	}
+	enemy.BurnFrames--
+	if interval := cfg.BoomerangModifier.BurnInterval; interval > 0 && enemy.BurnFrames%interval != 0 {
+		return
+	}
+
+	components.Health.Get(enemyEntry).Current -= enemy.BurnDamage
+	publishGameplayEvent(e, enemyEntry, components.GameplayEventData{
+		Kind:   components.EventDamageDealt,
+		Amount: enemy.BurnDamage,
+		Detail: "burn",
+	})
+	TriggerBurnFlash(enemyEntry)
+	obj := components.Object.Get(enemyEntry)
+	factory.SpawnExplosion(e, obj.X+obj.W/2, obj.Y+obj.H/2, 0.3)
+}
+
 func updateEnemyAI(e *ecs.ECS, enemyEntry *donburi.Entry, playerObject *resolv.Object, enemyPositions []enemyPos) {
 	enemy := components.Enemy.Get(enemyEntry)
 	physics := components.Physics.Get(enemyEntry)
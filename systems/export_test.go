@@ -0,0 +1,10 @@
+package systems
+
+// SaveRawItem stores data under a run item's key as is, so tests can plant
+// records the game can't read
+func SaveRawItem(item string, data []byte) error {
+	return gdataManager.SaveItem(runItemKey(item), data)
+}
+
+// TraceBoomerangAim exposes the flight the aim preview draws
+var TraceBoomerangAim = traceBoomerangAim
@@ -13,14 +13,28 @@ import (
 	"github.com/yohamta/donburi/ecs"
 )
 
-// CreateBoomerang spawns a new boomerang entity with the given aim direction.
-func CreateBoomerang(ecs *ecs.ECS, owner *donburi.Entry, chargeFrames float64, aimX, aimY float64) *donburi.Entry {
-	b := archetypes.Boomerang.Spawn(ecs)
+// BoomerangSize is the width and height of the boomerang's hitbox
+const BoomerangSize = 12.0
+
+// BoomerangLaunch is how a throw leaves the owner's hand
+type BoomerangLaunch struct {
+	X, Y           float64 // Top left of the hitbox
+	AimX, AimY     float64 // Unit aim direction
+	Speed          float64 // Throw speed along the aim, before the lift
+	SpeedX, SpeedY float64
+	MaxRange       float64
+	ChargeRatio    float64 // 0.0 = quick throw, 1.0 = fully charged
+}
 
+// NewBoomerangLaunch works out the start position, velocity and range of a
+// throw by owner with the given charge and aim direction.
+func NewBoomerangLaunch(owner *donburi.Entry, chargeFrames float64, aimX, aimY float64) BoomerangLaunch {
 	// Get owner position and facing
 	ownerObj := components.Object.Get(owner).Object
-	ownerPlayer := components.Player.Get(owner)
-	facingX := ownerPlayer.Direction.X
+	facingX := components.Player.Get(owner).Direction.X
+	if math.Abs(aimX) > 0.01 {
+		facingX = aimX // A throw behind the owner leaves from that side
+	}
 
 	// Determine start position (offset from player)
 	startX := ownerObj.X + ownerObj.W/2
@@ -31,19 +45,6 @@ func CreateBoomerang(ecs *ecs.ECS, owner *donburi.Entry, chargeFrames float64, a
 	}
 	startY := ownerObj.Y + ownerObj.H/2
 
-	// Create Physics Object (Hitbox)
-	// Using a smaller hitbox for the boomerang
-	width, height := 12.0, 12.0
-	obj := resolv.NewObject(startX, startY, width, height, tags.ResolvBoomerang)
-	obj.Data = b
-	components.Object.Set(b, &components.ObjectData{
-		Object: obj,
-	})
-
-	// Add to space
-	components.Space.Get(components.Space.MustFirst(ecs.World)).Add(obj)
-
-	// Physics
 	// Calculate initial velocity based on charge
 	chargeRatio := chargeFrames / float64(config.Boomerang.MaxChargeTime)
 	if chargeRatio > 1.0 {
@@ -59,36 +60,105 @@ func CreateBoomerang(ecs *ecs.ECS, owner *donburi.Entry, chargeFrames float64, a
 		aimX /= length
 		aimY /= length
 	}
-	velocityX := speed * aimX
-	velocityY := speed * aimY
 
-	// Add upward lift to all throws for a nice arc
-	velocityY -= 3.0
+	return BoomerangLaunch{
+		X:      startX,
+		Y:      startY,
+		AimX:   aimX,
+		AimY:   aimY,
+		Speed:  speed,
+		SpeedX: speed * aimX,
+		// Add upward lift to all throws for a nice arc
+		SpeedY:      speed*aimY - 3.0,
+		MaxRange:    config.Boomerang.BaseRange + (config.Boomerang.MaxChargeRange-config.Boomerang.BaseRange)*chargeRatio,
+		ChargeRatio: chargeRatio,
+	}
+}
+
+// CreateBoomerang spawns a new boomerang entity with the given aim direction,
+// and a twin alongside it for each stack of the twin modifier.
+func CreateBoomerang(ecs *ecs.ECS, owner *donburi.Entry, chargeFrames float64, aimX, aimY float64) *donburi.Entry {
+	ownerObj := components.Object.Get(owner).Object
+	ownerPlayer := components.Player.Get(owner)
+	launch := NewBoomerangLaunch(owner, chargeFrames, aimX, aimY)
+
+	b := spawnBoomerang(ecs, owner, launch, ownerPlayer.Modifiers, false)
+
+	// Twins fan out from the aim, alternating sides
+	for i := 1; i <= components.ModifierStacks(ownerPlayer.Modifiers)[components.ModifierTwin]; i++ {
+		angle := config.BoomerangModifier.TwinSpread * float64((i+1)/2)
+		if i%2 == 0 {
+			angle = -angle
+		}
+		sin, cos := math.Sincos(angle)
+		twin := NewBoomerangLaunch(owner, chargeFrames, aimX*cos-aimY*sin, aimX*sin+aimY*cos)
+		spawnBoomerang(ecs, owner, twin, ownerPlayer.Modifiers, true)
+	}
+
+	// Track active boomerang on player
+	if owner.HasComponent(components.Player) {
+		ownerPlayer.ActiveBoomerang = b
+	}
+
+	// Spawn gunshot muzzle flash effect in the throw direction
+	gunshotOffset := 45.0
+	gunshotX := ownerObj.X + ownerObj.W/2 + gunshotOffset*launch.AimX
+	gunshotY := ownerObj.Y + ownerObj.H/2 + gunshotOffset*launch.AimY
+	SpawnGunshot(ecs, gunshotX, gunshotY, launch.AimX)
+
+	return b
+}
+
+// spawnBoomerang creates one boomerang of a throw, with the owner's run
+// modifiers applied
+func spawnBoomerang(ecs *ecs.ECS, owner *donburi.Entry, launch BoomerangLaunch, mods []components.BoomerangModifier, twin bool) *donburi.Entry {
+	b := archetypes.Boomerang.Spawn(ecs)
+	chargeRatio := launch.ChargeRatio
+
+	// Create Physics Object (Hitbox)
+	// Using a smaller hitbox for the boomerang
+	obj := resolv.NewObject(launch.X, launch.Y, BoomerangSize, BoomerangSize, tags.ResolvBoomerang)
+	obj.Data = b
+	components.Object.Set(b, &components.ObjectData{
+		Object: obj,
+	})
+
+	// Add to space
+	components.Space.Get(components.Space.MustFirst(ecs.World)).Add(obj)
 
 	components.Physics.Set(b, &components.PhysicsData{
-		SpeedX:   velocityX,
-		SpeedY:   velocityY,
+		SpeedX:   launch.SpeedX,
+		SpeedY:   launch.SpeedY,
 		Gravity:  config.Boomerang.Gravity,
 		Friction: 0,
-		MaxSpeed: speed * 2, // Allow high speed
+		MaxSpeed: launch.Speed * 2, // Allow high speed
 	})
 
 	// Boomerang Logic
-	maxRange := config.Boomerang.BaseRange + (config.Boomerang.MaxChargeRange-config.Boomerang.BaseRange)*chargeRatio
-
-	components.Boomerang.Set(b, &components.BoomerangData{
+	data := &components.BoomerangData{
 		Owner:            owner,
 		State:            components.BoomerangOutbound,
 		DistanceTraveled: 0,
-		MaxRange:         maxRange,
+		MaxRange:         launch.MaxRange,
 		PierceDistance:   config.Boomerang.PierceDistance,
 		HitEnemies:       make(map[*donburi.Entry]struct{}),
 		Damage:           config.Boomerang.BaseDamage + int(float64(config.Boomerang.MaxChargeDamageBonus)*chargeRatio),
 		ChargeRatio:      chargeRatio, // Store for scaled effects
-	})
+		Bounces:          config.Boomerang.RicochetBounces,
+		ReturnSpeed:      config.Boomerang.ReturnSpeed,
+		Twin:             twin,
+	}
+	ApplyBoomerangModifiers(data, mods)
+	components.Boomerang.Set(b, data)
 
 	// Sprite
 	img := assets.GetObjectImage("boom_green.png")
+	switch {
+	case data.BurnDamage > 0:
+		img = assets.GetObjectImage("boom_red.png")
+	case twin:
+		img = assets.GetObjectImage("boom_blue.png")
+	}
 	components.Sprite.Set(b, &components.SpriteData{
 		Image:    img,
 		Rotation: 0,
@@ -96,16 +166,29 @@ func CreateBoomerang(ecs *ecs.ECS, owner *donburi.Entry, chargeFrames float64, a
 		PivotY:   float64(img.Bounds().Dy()) / 2,
 	})
 
-	// Track active boomerang on player
-	if owner.HasComponent(components.Player) {
-		ownerPlayer.ActiveBoomerang = b
-	}
-
-	// Spawn gunshot muzzle flash effect in the throw direction
-	gunshotOffset := 45.0
-	gunshotX := ownerObj.X + ownerObj.W/2 + gunshotOffset*aimX
-	gunshotY := ownerObj.Y + ownerObj.H/2 + gunshotOffset*aimY
-	SpawnGunshot(ecs, gunshotX, gunshotY, aimX)
-
 	return b
 }
+
+// ApplyBoomerangModifiers is the run modifier pipeline: it applies each
+// modifier in mods to a new boomerang, once per stack. Twin is applied by
+// CreateBoomerang throwing more boomerangs.
+func ApplyBoomerangModifiers(b *components.BoomerangData, mods []components.BoomerangModifier) {
+	for mod, stacks := range components.ModifierStacks(mods) {
+		switch mod {
+		case components.ModifierRange:
+			b.MaxRange += config.BoomerangModifier.RangeBonus * float64(stacks)
+		case components.ModifierPierce:
+			b.Pierces += config.BoomerangModifier.ExtraPierces * stacks
+		case components.ModifierFire:
+			b.BurnDamage += config.BoomerangModifier.BurnDamage * stacks
+		case components.ModifierReturn:
+			b.ReturnSpeed += config.Boomerang.ReturnSpeed * config.BoomerangModifier.ReturnSpeedBonus * float64(stacks)
+		case components.ModifierLifesteal:
+			b.Lifesteal += config.BoomerangModifier.LifestealHeal * stacks
+		case components.ModifierRicochet:
+			b.Bounces += config.BoomerangModifier.ExtraBounces * stacks
+		case components.ModifierHeavy:
+			b.Heavy = true
+		}
+	}
+}
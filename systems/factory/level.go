@@ -8,7 +8,9 @@ import (
 	"github.com/yohamta/donburi/ecs"
 )
 
-func CreateLevel(ecs *ecs.ECS) *donburi.Entry {
+// CreateLevel loads all campaign levels and makes levelIndex the current one.
+// Out-of-range indices fall back to the first level.
+func CreateLevel(ecs *ecs.ECS, levelIndex int) *donburi.Entry {
 	level := archetypes.Level.Spawn(ecs)
 
 	// Load all levels
@@ -19,14 +21,88 @@ func CreateLevel(ecs *ecs.ECS) *donburi.Entry {
 		panic("No levels found in assets/levels directory")
 	}
 
+	if levelIndex < 0 || levelIndex >= len(levels) {
+		levelIndex = 0
+	}
+
 	// Set up level data
 	levelData := &components.LevelData{
 		Levels:       levels,
-		LevelIndex:   0,
-		CurrentLevel: &levels[0],
+		LevelIndex:   levelIndex,
+		CurrentLevel: &levels[levelIndex],
 	}
 
 	components.Level.Set(level, levelData)
 
 	return level
 }
+
+// CreateGeneratedLevel creates a level entity for a single level that was not
+// loaded from the campaign (e.g. a procedurally generated roguelite level).
+func CreateGeneratedLevel(ecs *ecs.ECS, level *assets.Level) *donburi.Entry {
+	entry := archetypes.Level.Spawn(ecs)
+	components.Level.Set(entry, &components.LevelData{
+		Levels:       []assets.Level{*level},
+		LevelIndex:   0,
+		CurrentLevel: level,
+	})
+	return entry
+}
+
+// CreateLevelObjects creates collision geometry and interactive objects
+// (dead zones, checkpoints, fires, messages, finish lines) for a level.
+// The space must already exist.
+func CreateLevelObjects(ecs *ecs.ECS, level *assets.Level) {
+	// Create collision objects from solid tiles
+	for _, tile := range level.SolidTiles {
+		if tile.SlopeType != "" {
+			CreateSlopeWall(ecs, tile.X, tile.Y, tile.Width, tile.Height, tile.SlopeType)
+		} else {
+			CreateWall(ecs, tile.X, tile.Y, tile.Width, tile.Height)
+		}
+	}
+
+	for _, dz := range level.DeadZones {
+		CreateDeadZone(ecs, dz.X, dz.Y, dz.Width, dz.Height)
+	}
+
+	for _, ckp := range level.Checkpoints {
+		CreateCheckpoint(ecs, ckp.X, ckp.Y, ckp.Width, ckp.Height, ckp.CheckpointID)
+	}
+
+	for _, fire := range level.Fires {
+		CreateFire(ecs, fire.X, fire.Y, fire.FireType, fire.Direction)
+	}
+
+	for _, msg := range level.Messages {
+		CreateMessagePoint(ecs, msg.X, msg.Y, msg.MessageID)
+	}
+
+	for _, fl := range level.FinishLines {
+		CreateFinishLine(ecs, fl.X, fl.Y, fl.Width, fl.Height)
+	}
+
+	for _, r := range level.Rewards {
+		CreateReward(ecs, r.X, r.Y, r.Width, r.Height, r.RewardType)
+	}
+}
+
+// CreateLevelEnemies spawns every enemy in the level and adds it to the space.
+func CreateLevelEnemies(ecs *ecs.ECS, level *assets.Level) {
+	spaceEntry, ok := components.Space.First(ecs.World)
+	if !ok {
+		return
+	}
+	space := components.Space.Get(spaceEntry)
+
+	for _, spawn := range level.EnemySpawns {
+		// Use the enemy type from the spawn data, default to "Guard" if not specified
+		enemyType := spawn.EnemyType
+		if enemyType == "" {
+			enemyType = "Guard"
+		}
+		enemy := CreateEnemy(ecs, spawn.X, spawn.Y, spawn.PatrolPath, enemyType)
+		enemyObj := components.Object.Get(enemy)
+		space.Add(enemyObj.Object)
+	}
+}
@@ -0,0 +1,52 @@
+package factory
+
+import (
+	"github.com/automoto/doomerang/archetypes"
+	"github.com/automoto/doomerang/assets"
+	"github.com/automoto/doomerang/components"
+	"github.com/automoto/doomerang/tags"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/solarlune/resolv"
+	"github.com/yohamta/donburi"
+	"github.com/yohamta/donburi/ecs"
+)
+
+// CreateReward creates a reward pickup the player collects by touching it
+func CreateReward(ecs *ecs.ECS, x, y, w, h float64, rewardType string) *donburi.Entry {
+	reward := archetypes.Reward.Spawn(ecs)
+
+	obj := resolv.NewObject(x, y, w, h, tags.ResolvReward)
+	obj.SetShape(resolv.NewRectangle(0, 0, w, h))
+	obj.Data = reward
+
+	components.Object.SetValue(reward, components.ObjectData{Object: obj})
+	components.Reward.SetValue(reward, components.RewardData{
+		RewardType: rewardType,
+	})
+
+	img := rewardImage(rewardType)
+	components.Sprite.SetValue(reward, components.SpriteData{
+		Image:  img,
+		PivotX: float64(img.Bounds().Dx()) / 2,
+		PivotY: float64(img.Bounds().Dy()) / 2,
+	})
+
+	// Add to physics space
+	if spaceEntry, ok := components.Space.First(ecs.World); ok {
+		components.Space.Get(spaceEntry).Add(obj)
+	}
+
+	return reward
+}
+
+// rewardImage returns the pickup sprite for a reward type: a heart for health,
+// and a boomerang for a boomerang modifier
+func rewardImage(rewardType string) *ebiten.Image {
+	switch mod := components.BoomerangModifier(rewardType); {
+	case mod == components.ModifierFire:
+		return assets.GetObjectImage("boom_red.png")
+	case mod.Label() != "":
+		return assets.GetObjectImage("boom_blue.png")
+	}
+	return assets.GetIconImage("icon_heart.png")
+}
@@ -50,6 +50,7 @@ func UpdateFinishLine(ecs *ecs.ECS) {
 	// Activate finish line and trigger level complete
 	finishLine.Activated = true
 	levelComplete.IsComplete = true
+	publishGameplayEvent(ecs, playerEntry, components.GameplayEventData{Kind: components.EventFinish})
 
 	// Pause music when level completes
 	PauseMusic(ecs)
@@ -82,6 +82,7 @@ func UpdateFire(ecs *ecs.ECS) {
 
 			donburi.Add(playerEntry, components.DamageEvent, &components.DamageEventData{
 				Amount: fire.Damage,
+				Source: "Fire",
 			})
 			TriggerDamageFlash(playerEntry)
 			PlaySFX(ecs, cfg.SoundHit)
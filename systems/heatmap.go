@@ -0,0 +1,115 @@
+package systems
+
+import (
+	"image/color"
+	"log"
+
+	"github.com/automoto/doomerang/assets"
+	"github.com/automoto/doomerang/components"
+	cfg "github.com/automoto/doomerang/config"
+	"github.com/automoto/doomerang/telemetry"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/yohamta/donburi/ecs"
+)
+
+// HeatmapCells places the deaths and damage in events over level. Campaign
+// levels use every play of the level; generated levels place each chunk's
+// heat, gathered across all runs, at the room it was placed in this run.
+func HeatmapCells(level *assets.Level, events []components.GameplayEventData) []components.HeatmapCell {
+	type placedGrid struct {
+		grid *telemetry.Grid
+		x, y float64
+	}
+	var grids []placedGrid
+	if len(level.Rooms) == 0 {
+		grids = append(grids, placedGrid{grid: telemetry.LevelHeat(events, level.Name, telemetry.DefaultKinds, cfg.Heatmap.CellSize)})
+	}
+	for _, room := range level.Rooms {
+		if room.ChunkID == "" {
+			continue
+		}
+		grid := telemetry.ChunkHeat(events, room.ChunkID, telemetry.DefaultKinds, cfg.Heatmap.CellSize)
+		grids = append(grids, placedGrid{grid: grid, x: room.X, y: room.Y})
+	}
+
+	// Colors are relative to the hottest cell anywhere on the level
+	hottest := 0
+	for _, g := range grids {
+		hottest = max(hottest, g.grid.Max)
+	}
+	if hottest == 0 {
+		return nil
+	}
+
+	var cells []components.HeatmapCell
+	for _, g := range grids {
+		for cell, n := range g.grid.Counts {
+			c := telemetry.HeatColor(float64(n) / float64(hottest))
+			cells = append(cells, components.HeatmapCell{
+				X:     g.x + float64(cell.X)*g.grid.CellSize,
+				Y:     g.y + float64(cell.Y)*g.grid.CellSize,
+				Size:  g.grid.CellSize,
+				Color: color.NRGBA{R: c.R, G: c.G, B: c.B, A: cfg.Heatmap.Alpha},
+			})
+		}
+	}
+	return cells
+}
+
+// drawHeatmap draws the logged deaths and damage of the current level under
+// the debug collision outlines. The log is read the first time a level is shown.
+func drawHeatmap(e *ecs.ECS, screen *ebiten.Image, camX, camY float64) {
+	levelEntry, ok := components.Level.First(e.World)
+	if !ok {
+		return
+	}
+	level := components.Level.Get(levelEntry).CurrentLevel
+	if level == nil {
+		return
+	}
+
+	heatmap := getOrCreateHeatmap(e)
+	if heatmap.Level != level {
+		heatmap.Level = level
+		heatmap.Cells = loadHeatmapCells(level)
+	}
+
+	width, height := float64(screen.Bounds().Dx()), float64(screen.Bounds().Dy())
+	for _, cell := range heatmap.Cells {
+		x, y := cell.X+camX, cell.Y+camY
+		if x+cell.Size < 0 || x > width || y+cell.Size < 0 || y > height {
+			continue
+		}
+		vector.FillRect(screen, float32(x), float32(y), float32(cell.Size), float32(cell.Size), cell.Color, false)
+	}
+}
+
+// loadHeatmapCells reads the heatmap log, or returns nil if there isn't one
+func loadHeatmapCells(level *assets.Level) []components.HeatmapCell {
+	path := cfg.Debug.HeatmapPath
+	if path == "" {
+		path = cfg.Debug.TelemetryPath
+	}
+	if path == "" {
+		return nil
+	}
+	// Include what has been logged so far this session
+	FlushTelemetry()
+	events, err := telemetry.LoadEvents(path)
+	if err != nil {
+		log.Printf("Warning: Could not load heatmap from %s: %v", path, err)
+		return nil
+	}
+	return HeatmapCells(level, events)
+}
+
+func getOrCreateHeatmap(e *ecs.ECS) *components.HeatmapData {
+	if _, ok := components.Heatmap.First(e.World); !ok {
+		ent := e.World.Entry(e.World.Create(components.Heatmap))
+		components.Heatmap.SetValue(ent, components.HeatmapData{})
+	}
+
+	ent, _ := components.Heatmap.First(e.World)
+	return components.Heatmap.Get(ent)
+}
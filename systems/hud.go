@@ -1,10 +1,15 @@
 package systems
 
 import (
+	"fmt"
 	"image/color"
+	"math"
+	"strings"
 
 	"github.com/automoto/doomerang/assets"
 	"github.com/automoto/doomerang/components"
+	cfg "github.com/automoto/doomerang/config"
+	"github.com/automoto/doomerang/fonts"
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/vector"
 	"github.com/yohamta/donburi"
@@ -16,6 +21,8 @@ const (
 	hudBarHeight = 13
 	hudMargin    = 10
 	livesMargin  = 5
+
+	boomerangArrowSize = 8
 )
 
 var heartIcon *ebiten.Image
@@ -44,6 +51,84 @@ func DrawHUD(ecs *ecs.ECS, screen *ebiten.Image) {
 
 	// Draw lives counter
 	drawLives(playerEntry, screen)
+
+	drawModifiers(playerEntry, screen)
+
+	drawBoomerangMarker(ecs, playerEntry, screen)
+}
+
+// drawBoomerangMarker shows where a boomerang stuck in a wall or lying on the
+// ground is: a ring around it, or an arrow at the screen edge pointing to it
+// when it is off screen.
+func drawBoomerangMarker(ecs *ecs.ECS, playerEntry *donburi.Entry, screen *ebiten.Image) {
+	active := components.Player.Get(playerEntry).ActiveBoomerang
+	if active == nil || !active.Valid() {
+		return
+	}
+	var clr color.RGBA
+	switch components.Boomerang.Get(active).State {
+	case components.BoomerangEmbedded:
+		clr = cfg.BrightYellow
+	case components.BoomerangDropped:
+		clr = cfg.BrightOrange
+	default:
+		return
+	}
+	cameraEntry, ok := components.Camera.First(ecs.World)
+	if !ok {
+		return
+	}
+	camera := components.Camera.Get(cameraEntry)
+	width, height := float64(screen.Bounds().Dx()), float64(screen.Bounds().Dy())
+
+	obj := components.Object.Get(active)
+	x := obj.X + obj.W/2 + width/2 - camera.Position.X
+	y := obj.Y + obj.H/2 + height/2 - camera.Position.Y
+
+	const edge = hudMargin + boomerangArrowSize
+	if x >= 0 && x <= width && y >= 0 && y <= height {
+		vector.StrokeCircle(screen, float32(x), float32(y), float32(obj.W), 1.5, clr, true)
+		return
+	}
+
+	// Arrow on the screen edge, pointing from the screen centre toward it
+	ax, ay := clampFloat(x, edge, width-edge), clampFloat(y, edge, height-edge)
+	angle := math.Atan2(y-height/2, x-width/2)
+	var path vector.Path
+	path.MoveTo(float32(ax+math.Cos(angle)*boomerangArrowSize), float32(ay+math.Sin(angle)*boomerangArrowSize))
+	path.LineTo(float32(ax+math.Cos(angle+2.5)*boomerangArrowSize), float32(ay+math.Sin(angle+2.5)*boomerangArrowSize))
+	path.LineTo(float32(ax+math.Cos(angle-2.5)*boomerangArrowSize), float32(ay+math.Sin(angle-2.5)*boomerangArrowSize))
+	path.Close()
+	op := &vector.DrawPathOptions{AntiAlias: true}
+	op.ColorScale.ScaleWithColor(clr)
+	vector.FillPath(screen, &path, nil, op)
+}
+
+// drawModifiers lists the boomerang modifiers picked up this run below the lives
+func drawModifiers(playerEntry *donburi.Entry, screen *ebiten.Image) {
+	text := formatModifiers(components.Player.Get(playerEntry).Modifiers, "  ")
+	if text == "" || heartIcon == nil {
+		return
+	}
+	face := fonts.ExcelSmall.GetV2()
+	y := hudMargin + hudBarHeight + livesMargin + heartIcon.Bounds().Dy() + livesMargin + int(face.Metrics().HAscent)
+	drawText(screen, text, face, hudMargin, y, cfg.BrightYellow)
+}
+
+// formatModifiers describes the stacks of each modifier in mods, e.g.
+// "Range x2, Fire", or "" for none
+func formatModifiers(mods []components.BoomerangModifier, sep string) string {
+	stacks := components.ModifierStacks(mods)
+	var parts []string
+	for _, mod := range components.BoomerangModifiers {
+		switch n := stacks[mod]; {
+		case n == 1:
+			parts = append(parts, mod.Label())
+		case n > 1:
+			parts = append(parts, fmt.Sprintf("%s x%d", mod.Label(), n))
+		}
+	}
+	return strings.Join(parts, sep)
 }
 
 func drawLives(playerEntry *donburi.Entry, screen *ebiten.Image) {
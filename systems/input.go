@@ -1,10 +1,12 @@
 package systems
 
 import (
+	"math"
 	"strings"
 
 	"github.com/automoto/doomerang/components"
 	cfg "github.com/automoto/doomerang/config"
+	"github.com/automoto/doomerang/tags"
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/yohamta/donburi/ecs"
 )
@@ -15,6 +17,13 @@ var gamepadIDs []ebiten.GamepadID
 // Cache controller types to avoid string allocation every frame
 var controllerTypeCache = make(map[ebiten.GamepadID]components.InputMethod)
 
+// The mouse aims once it has moved, until a gamepad is used, so a cursor left
+// sitting in a corner doesn't steer keyboard throws
+var (
+	lastCursorX, lastCursorY int
+	cursorSeen, mouseAiming  bool
+)
+
 // UpdateInput polls raw input and updates the InputComponent.
 // Must run BEFORE UpdatePlayer in the system order.
 func UpdateInput(ecs *ecs.ECS) {
@@ -23,6 +32,12 @@ func UpdateInput(ecs *ecs.ECS) {
 	// Swap buffers: current becomes previous, then zero out current
 	input.Previous = input.Current
 	input.Current = [cfg.ActionCount]bool{}
+	input.Aiming = false
+
+	// Replay playback replaces device polling entirely
+	if ApplyReplayInput(ecs, input) {
+		return
+	}
 
 	// Get connected gamepads
 	gamepadIDs = ebiten.AppendGamepadIDs(gamepadIDs[:0])
@@ -85,12 +100,26 @@ func UpdateInput(ecs *ecs.ECS) {
 		activeGamepadID = analogGpID
 	}
 
+	// Free aim: a stick takes priority over the mouse
+	if x, y, gpID, ok := getAimStickState(gamepadIDs); ok {
+		setAim(input, x, y)
+		gamepadUsed = true
+		activeGamepadID = gpID
+	}
+	if gamepadUsed {
+		mouseAiming = false
+	} else if x, y, ok := getMouseAim(ecs, input); ok {
+		setAim(input, x, y)
+	}
+
 	// Update last input method - gamepad takes priority if both used
 	if gamepadUsed {
 		input.LastInputMethod = getControllerType(activeGamepadID)
 	} else if keyboardUsed {
 		input.LastInputMethod = components.InputKeyboard
 	}
+
+	RecordReplayInput(ecs, input)
 }
 
 // getControllerType returns cached controller type, detecting on first access
@@ -151,6 +180,73 @@ func getAnalogStickState(gamepads []ebiten.GamepadID) (left, right, up, down boo
 	return
 }
 
+// getAimStickState reads the direction a stick is pushed for free aim: the
+// right stick, or the left stick if the right one is centred
+func getAimStickState(gamepads []ebiten.GamepadID) (x, y float64, gpID ebiten.GamepadID, ok bool) {
+	deadzone := cfg.Input.AnalogDeadzone
+	sticks := [][2]ebiten.StandardGamepadAxis{
+		{ebiten.StandardGamepadAxisRightStickHorizontal, ebiten.StandardGamepadAxisRightStickVertical},
+		{ebiten.StandardGamepadAxisLeftStickHorizontal, ebiten.StandardGamepadAxisLeftStickVertical},
+	}
+	for _, stick := range sticks {
+		for _, id := range gamepads {
+			if !ebiten.IsStandardGamepadLayoutAvailable(id) {
+				continue
+			}
+			x = ebiten.StandardGamepadAxisValue(id, stick[0])
+			y = ebiten.StandardGamepadAxisValue(id, stick[1])
+			if math.Hypot(x, y) > deadzone {
+				return x, y, id, true
+			}
+		}
+	}
+	return 0, 0, 0, false
+}
+
+// getMouseAim returns the direction from the player to the mouse cursor.
+// Holding up or down aims with the keys instead.
+func getMouseAim(e *ecs.ECS, input *components.InputData) (x, y float64, ok bool) {
+	cx, cy := ebiten.CursorPosition()
+	if cursorSeen && (cx != lastCursorX || cy != lastCursorY) {
+		mouseAiming = true
+	}
+	lastCursorX, lastCursorY, cursorSeen = cx, cy, true
+	if !mouseAiming || input.Current[cfg.ActionMoveUp] || input.Current[cfg.ActionCrouch] {
+		return 0, 0, false
+	}
+
+	playerEntry, ok := tags.Player.First(e.World)
+	if !ok {
+		return 0, 0, false
+	}
+	cameraEntry, ok := components.Camera.First(e.World)
+	if !ok {
+		return 0, 0, false
+	}
+	camera := components.Camera.Get(cameraEntry)
+	playerObj := components.Object.Get(playerEntry)
+
+	// Screen to world, the inverse of the camera translation used when drawing
+	worldX := float64(cx) - float64(cfg.C.Width)/2 + camera.Position.X
+	worldY := float64(cy) - float64(cfg.C.Height)/2 + camera.Position.Y
+	x = worldX - (playerObj.X + playerObj.W/2)
+	y = worldY - (playerObj.Y + playerObj.H/2)
+	return x, y, x != 0 || y != 0
+}
+
+// setAim sets the input's free aim to the step nearest the direction (x, y)
+func setAim(input *components.InputData, x, y float64) {
+	step := int(math.Round(math.Atan2(y, x) / (2 * math.Pi) * cfg.AimSteps))
+	input.Aiming = true
+	input.AimStep = (step%cfg.AimSteps + cfg.AimSteps) % cfg.AimSteps
+}
+
+// aimDirection returns the input's free aim as a unit vector
+func aimDirection(input *components.InputData) (x, y float64) {
+	angle := float64(input.AimStep) / cfg.AimSteps * 2 * math.Pi
+	return math.Cos(angle), math.Sin(angle)
+}
+
 // getOrCreateInput returns the singleton Input component, creating if needed
 func getOrCreateInput(ecs *ecs.ECS) *components.InputData {
 	entry, ok := components.Input.First(ecs.World)
@@ -96,6 +96,7 @@ func handleKnifePlayerHit(ecs *ecs.ECS, knifeEntry *donburi.Entry, knife *compon
 		Amount:     knife.Damage,
 		KnockbackX: knockbackX,
 		KnockbackY: cfg.Combat.KnockbackUpwardForce,
+		Source:     "Knife",
 	})
 
 	// Visual feedback
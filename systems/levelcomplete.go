@@ -1,7 +1,8 @@
 package systems
 
 import (
-	"os"
+	"fmt"
+	"log"
 
 	"github.com/automoto/doomerang/components"
 	cfg "github.com/automoto/doomerang/config"
@@ -11,19 +12,60 @@ import (
 	"github.com/yohamta/donburi/ecs"
 )
 
-// UpdateLevelComplete handles input when level complete overlay is shown
-func UpdateLevelComplete(e *ecs.ECS) {
-	levelComplete := GetOrCreateLevelComplete(e)
-	if !levelComplete.IsComplete {
-		return
-	}
-
-	input := getOrCreateInput(e)
+// NewUpdateLevelComplete creates the level complete system with campaign progression.
+// The cleared level's time and death count are recorded for level select.
+// Confirming the overlay advances to the next level (carrying lives and health over)
+// or, after the final level, shows the campaign complete screen. Only a campaign
+// run saves its progress, or clears it at the end; a level picked from level
+// select leaves the save alone, and a replay saves nothing at all.
+func NewUpdateLevelComplete(
+	sceneChanger SceneChanger,
+	createLevelScene func(levelIndex int, carry *PlayerCarryover) interface{},
+	createCampaignCompleteScene func() interface{},
+) ecs.System {
+	return func(e *ecs.ECS) {
+		levelComplete := GetOrCreateLevelComplete(e)
+		if !levelComplete.IsComplete {
+			return
+		}
 
-	// Handle selection - Enter or Start exits the game
-	if GetAction(input, cfg.ActionMenuSelect).JustPressed {
+		input := getOrCreateInput(e)
+		if !GetAction(input, cfg.ActionMenuSelect).JustPressed {
+			return
+		}
 		PlaySFX(e, cfg.SoundMenuSelect)
-		os.Exit(0)
+
+		levelEntry, ok := components.Level.First(e.World)
+		if !ok {
+			return
+		}
+		levelData := components.Level.Get(levelEntry)
+
+		// A replay is a playback of a run that was already recorded
+		if !isReplayPlayback(e) {
+			stats := SnapshotRunStats(e)
+			if err := SaveLevelCompletion(levelData.CurrentLevel.Name, stats.ElapsedSecs, stats.Deaths); err != nil {
+				log.Printf("Warning: Could not save level record: %v", err)
+			}
+		}
+
+		nextIndex := levelData.LevelIndex + 1
+		if nextIndex >= len(levelData.Levels) {
+			if levelData.Campaign {
+				if err := ClearGameProgress(); err != nil {
+					log.Printf("Warning: Could not clear game progress: %v", err)
+				}
+			}
+			sceneChanger.ChangeScene(createCampaignCompleteScene())
+			return
+		}
+
+		if levelData.Campaign {
+			if err := SaveLevelProgress(nextIndex); err != nil {
+				log.Printf("Warning: Could not save level progress: %v", err)
+			}
+		}
+		sceneChanger.ChangeScene(createLevelScene(nextIndex, SnapshotPlayerCarryover(e)))
 	}
 }
 
@@ -54,7 +96,7 @@ func DrawLevelComplete(e *ecs.ECS, screen *ebiten.Image) {
 
 	// Draw message
 	msgFont := fonts.ExcelBold.GetV2()
-	msg := cfg.LevelComplete.Message
+	msg := getLevelCompleteMessage(e)
 	msgX := centerTextX(msg, msgFont, width)
 	drawText(screen, msg, msgFont, msgX, int(cfg.LevelComplete.MessageY), cfg.LevelComplete.TextColor)
 
@@ -66,13 +108,23 @@ func DrawLevelComplete(e *ecs.ECS, screen *ebiten.Image) {
 	drawText(screen, hint, hintFont, hintX, int(cfg.LevelComplete.HintY), cfg.LevelComplete.HintColor)
 }
 
+// getLevelCompleteMessage describes campaign progress for the overlay
+func getLevelCompleteMessage(e *ecs.ECS) string {
+	levelEntry, ok := components.Level.First(e.World)
+	if !ok {
+		return ""
+	}
+	levelData := components.Level.Get(levelEntry)
+	return fmt.Sprintf(cfg.LevelComplete.MessageFormat, levelData.LevelIndex+1, len(levelData.Levels))
+}
+
 // getLevelCompleteHint returns the appropriate hint for level complete screen
 func getLevelCompleteHint(method components.InputMethod) string {
 	switch method {
 	case components.InputPlayStation:
-		return "Press Cross to exit"
+		return "Press Cross to continue"
 	case components.InputXbox:
-		return "Press A to exit"
+		return "Press A to continue"
 	}
 	return cfg.LevelComplete.ContinueHint
 }
@@ -0,0 +1,278 @@
+package systems
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/automoto/doomerang/assets"
+	"github.com/automoto/doomerang/components"
+	cfg "github.com/automoto/doomerang/config"
+	"github.com/automoto/doomerang/fonts"
+	"github.com/automoto/doomerang/procgen"
+	"github.com/hajimehoshi/ebiten/v2"
+	textv2 "github.com/hajimehoshi/ebiten/v2/text/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/yohamta/donburi/ecs"
+)
+
+// LevelPacksDir returns the directory level packs are installed in, next to
+// the save data. Empty when persistence is unavailable.
+func LevelPacksDir() string {
+	if !gdataInitialized || gdataManager == nil {
+		return ""
+	}
+	return gdataManager.ItemPath("packs")
+}
+
+// RestoreLevelPack activates the level pack chosen in an earlier session.
+// Call this after InitPersistence. A pack that has since been removed or
+// broken falls back to the built-in content.
+func RestoreLevelPack() {
+	id := LoadLevelPackID()
+	if id == "" {
+		return
+	}
+	for _, pack := range assets.LoadLevelPacks(LevelPacksDir()) {
+		if pack.ID == id {
+			if err := SelectLevelPack(pack); err != nil {
+				log.Printf("Warning: Could not restore level pack %s: %v", id, err)
+			}
+			return
+		}
+	}
+	log.Printf("Warning: Level pack %s is no longer installed, using the built-in levels", id)
+	_ = SaveLevelPackID("")
+}
+
+// SelectLevelPack makes pack the content used by new campaigns and runs and
+// remembers the choice. nil selects the built-in content, which never fails. A
+// pack whose chunks can't generate a run is rejected and the previous content kept.
+func SelectLevelPack(pack *assets.LevelPack) error {
+	previous := assets.ActiveLevelPack()
+	assets.UseLevelPack(pack)
+	if pack != nil && pack.Chunks > 0 {
+		if _, err := procgen.GenerateRun(procgen.RunOptions{Seed: 1, Headless: true}); err != nil {
+			assets.UseLevelPack(previous)
+			return fmt.Errorf("its chunks can't generate a run: %w", err)
+		}
+	}
+
+	id := ""
+	if pack != nil {
+		id = pack.ID
+	}
+	// SaveLevelPackID logs a failed save; the pack still applies this session
+	_ = SaveLevelPackID(id)
+	return nil
+}
+
+// GetOrCreateLevelPacks returns the singleton LevelPacks component, creating if needed
+func GetOrCreateLevelPacks(e *ecs.ECS) *components.LevelPacksData {
+	if _, ok := components.LevelPacks.First(e.World); !ok {
+		ent := e.World.Entry(e.World.Create(components.LevelPacks))
+		components.LevelPacks.SetValue(ent, components.LevelPacksData{})
+	}
+
+	ent, _ := components.LevelPacks.First(e.World)
+	return components.LevelPacks.Get(ent)
+}
+
+// activeLevelPackIndex returns the screen entry of the active pack: 0 for the
+// built-in content, i+1 for packs[i]
+func activeLevelPackIndex(packs []*assets.LevelPack) int {
+	active := assets.ActiveLevelPack()
+	for i, pack := range packs {
+		if active != nil && pack.ID == active.ID {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// NewUpdateLevelPacks creates the update system for the level packs screen.
+// Up/down pick an entry, select activates it and back returns to the menu.
+func NewUpdateLevelPacks(
+	sceneChanger SceneChanger,
+	packs []*assets.LevelPack,
+	createMenuScene func() interface{},
+) ecs.System {
+	// Skip the first frame so the confirm press that opened this screen
+	// from the main menu doesn't immediately activate the first entry.
+	firstFrame := true
+	return func(e *ecs.ECS) {
+		screen := GetOrCreateLevelPacks(e)
+		if firstFrame {
+			firstFrame = false
+			screen.SelectedIndex = activeLevelPackIndex(packs)
+			return
+		}
+
+		input := getOrCreateInput(e)
+
+		if GetAction(input, cfg.ActionMenuBack).JustPressed {
+			PlaySFX(e, cfg.SoundMenuNavigate)
+			sceneChanger.ChangeScene(createMenuScene())
+			return
+		}
+
+		numEntries := len(packs) + 1
+		if GetAction(input, cfg.ActionMenuUp).JustPressed {
+			PlaySFX(e, cfg.SoundMenuNavigate)
+			screen.SelectedIndex = (screen.SelectedIndex - 1 + numEntries) % numEntries
+			screen.Message = ""
+		}
+		if GetAction(input, cfg.ActionMenuDown).JustPressed {
+			PlaySFX(e, cfg.SoundMenuNavigate)
+			screen.SelectedIndex = (screen.SelectedIndex + 1) % numEntries
+			screen.Message = ""
+		}
+
+		if GetAction(input, cfg.ActionMenuSelect).JustPressed {
+			PlaySFX(e, cfg.SoundMenuSelect)
+			var pack *assets.LevelPack
+			if screen.SelectedIndex > 0 {
+				pack = packs[screen.SelectedIndex-1]
+			}
+			if err := SelectLevelPack(pack); err != nil {
+				log.Printf("Warning: Could not use level pack %s: %v", pack.ID, err)
+				screen.Message = "Can't use this pack: " + err.Error()
+			}
+		}
+	}
+}
+
+// DrawLevelPacks returns a renderer for the level packs screen.
+func DrawLevelPacks(packs []*assets.LevelPack) func(*ecs.ECS, *ebiten.Image) {
+	return func(e *ecs.ECS, screen *ebiten.Image) {
+		state := GetOrCreateLevelPacks(e)
+		width := float64(screen.Bounds().Dx())
+		height := float64(screen.Bounds().Dy())
+
+		vector.FillRect(
+			screen,
+			0, 0,
+			float32(width), float32(height),
+			cfg.LevelPacks.BackgroundColor,
+			false,
+		)
+
+		titleFont := fonts.ExcelTitle.GetV2()
+		titleX := centerTextX(cfg.LevelPacks.Title, titleFont, width)
+		drawText(screen, cfg.LevelPacks.Title, titleFont, titleX, int(cfg.LevelPacks.TitleY), cfg.LevelPacks.TitleColor)
+
+		rowFont := fonts.ExcelBold.GetV2()
+		smallFont := fonts.ExcelSmall.GetV2()
+		active := activeLevelPackIndex(packs)
+
+		// Scroll so the selected row stays within the visible window
+		first := 0
+		if visible := cfg.LevelPacks.VisibleRows; state.SelectedIndex >= visible {
+			first = state.SelectedIndex - visible + 1
+		}
+
+		for i := first; i <= len(packs) && i < first+cfg.LevelPacks.VisibleRows; i++ {
+			y := int(cfg.LevelPacks.ListStartY) + (i-first)*int(cfg.LevelPacks.RowHeight)
+
+			textColor := cfg.LevelPacks.TextColorNormal
+			if i == state.SelectedIndex {
+				textColor = cfg.LevelPacks.TextColorSelected
+			}
+
+			name, contents := "Built-in", "Campaign and chunks"
+			if i > 0 {
+				name, contents = packs[i-1].Manifest.Name, getLevelPackContents(packs[i-1])
+			}
+			drawText(screen, name, rowFont, int(cfg.LevelPacks.NameX), y, textColor)
+			drawText(screen, contents, smallFont, int(cfg.LevelPacks.ContentsX), y, textColor)
+			if i == active {
+				drawText(screen, "Active", rowFont, int(cfg.LevelPacks.ActiveX), y, cfg.LevelPacks.ActiveColor)
+			}
+		}
+
+		y := int(cfg.LevelPacks.DetailY)
+		lineHeight := int(cfg.LevelPacks.DetailLineHeight)
+		switch {
+		case state.Message != "":
+			for _, line := range wrapText(state.Message, smallFont, cfg.LevelPacks.DetailWidth) {
+				drawText(screen, line, smallFont, int(cfg.LevelPacks.NameX), y, cfg.LevelPacks.ErrorColor)
+				y += lineHeight
+			}
+		case len(packs) == 0:
+			empty := "No level packs installed. Add them to " + LevelPacksDir()
+			if LevelPacksDir() == "" {
+				empty = "Level packs need save data, which is unavailable"
+			}
+			for _, line := range wrapText(empty, smallFont, cfg.LevelPacks.DetailWidth) {
+				drawText(screen, line, smallFont, int(cfg.LevelPacks.NameX), y, cfg.LevelPacks.TextColorNormal)
+				y += lineHeight
+			}
+		case state.SelectedIndex > 0:
+			manifest := packs[state.SelectedIndex-1].Manifest
+			byline := "by " + manifest.Author
+			if manifest.Author == "" {
+				byline = "Unknown author"
+			}
+			if manifest.Version != "" {
+				byline += "   v" + manifest.Version
+			}
+			drawText(screen, byline, smallFont, int(cfg.LevelPacks.NameX), y, cfg.LevelPacks.TitleColor)
+			y += lineHeight
+			for _, line := range wrapText(manifest.Description, smallFont, cfg.LevelPacks.DetailWidth) {
+				drawText(screen, line, smallFont, int(cfg.LevelPacks.NameX), y, cfg.LevelPacks.TextColorNormal)
+				y += lineHeight
+			}
+		}
+
+		input := getOrCreateInput(e)
+		hint := getLevelPacksHint(input.LastInputMethod)
+		drawText(screen, hint, smallFont, centerTextX(hint, smallFont, width), int(height)-12, cfg.LevelPacks.TextColorNormal)
+	}
+}
+
+// getLevelPackContents summarizes what a pack adds or replaces
+func getLevelPackContents(pack *assets.LevelPack) string {
+	var parts []string
+	if pack.Levels > 0 {
+		parts = append(parts, fmt.Sprintf("%d levels", pack.Levels))
+	}
+	if pack.Chunks > 0 {
+		chunks := fmt.Sprintf("%d chunks", pack.Chunks)
+		if !pack.Manifest.ReplaceChunks {
+			chunks = "+" + chunks
+		}
+		parts = append(parts, chunks)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// wrapText splits s at spaces into lines no wider than maxWidth
+func wrapText(s string, font *textv2.GoXFace, maxWidth float64) []string {
+	var lines []string
+	var line strings.Builder
+	for _, word := range strings.Fields(s) {
+		if line.Len() > 0 && float64(measureTextWidth(line.String()+" "+word, font)) > maxWidth {
+			lines = append(lines, line.String())
+			line.Reset()
+		}
+		if line.Len() > 0 {
+			line.WriteString(" ")
+		}
+		line.WriteString(word)
+	}
+	if line.Len() > 0 {
+		lines = append(lines, line.String())
+	}
+	return lines
+}
+
+// getLevelPacksHint returns the appropriate hint for level packs navigation
+func getLevelPacksHint(method components.InputMethod) string {
+	switch method {
+	case components.InputPlayStation:
+		return "D-Pad: Navigate   Cross: Use Pack   Circle: Back"
+	case components.InputXbox:
+		return "D-Pad: Navigate   A: Use Pack   B: Back"
+	}
+	return "Up/Down: Navigate   Enter: Use Pack   Esc: Back"
+}
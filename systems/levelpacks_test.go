@@ -0,0 +1,65 @@
+package systems_test
+
+import (
+	"io/fs"
+	"testing"
+	"testing/fstest"
+
+	"github.com/automoto/doomerang/assets"
+	"github.com/automoto/doomerang/systems"
+)
+
+func loadTestPack(t *testing.T, manifest, file, from string) *assets.LevelPack {
+	t.Helper()
+	data, err := fs.ReadFile(assets.GetAssetFS(), from)
+	if err != nil {
+		t.Fatalf("failed to read %s: %v", from, err)
+	}
+	pack, err := assets.LoadLevelPack(fstest.MapFS{
+		"pack.json": {Data: []byte(manifest)},
+		file:        {Data: data},
+	}, "test-pack")
+	if err != nil {
+		t.Fatalf("LoadLevelPack failed: %v", err)
+	}
+	return pack
+}
+
+func TestSelectLevelPackKeepsCampaignSavesApart(t *testing.T) {
+	setupPersistence(t)
+	defer systems.SelectLevelPack(nil)
+
+	if err := systems.SaveLevelProgress(0); err != nil {
+		t.Fatalf("SaveLevelProgress failed: %v", err)
+	}
+	defer systems.ClearGameProgress()
+
+	pack := loadTestPack(t, `{"name": "Test"}`, "levels/a.tmx", "levels/level1.tmx")
+	if err := systems.SelectLevelPack(pack); err != nil {
+		t.Fatalf("SelectLevelPack failed: %v", err)
+	}
+	if systems.LoadLevelPackID() != "test-pack" {
+		t.Errorf("expected the pack choice to be saved, got %q", systems.LoadLevelPackID())
+	}
+	if systems.HasSaveGame() {
+		t.Error("expected the pack's campaign to start without the built-in save")
+	}
+
+	if err := systems.SelectLevelPack(nil); err != nil {
+		t.Fatalf("SelectLevelPack(nil) failed: %v", err)
+	}
+	if !systems.HasSaveGame() {
+		t.Error("expected the built-in save to be back after leaving the pack")
+	}
+}
+
+func TestSelectLevelPackRejectsUnusableChunks(t *testing.T) {
+	// A lone combat chunk has no start or end room to build a run from
+	pack := loadTestPack(t, `{"name": "Broken", "replaceChunks": true}`, "chunks/combat_01.tmx", "chunks/combat_01.tmx")
+	if err := systems.SelectLevelPack(pack); err == nil {
+		t.Fatal("expected a pack that can't generate a run to be rejected")
+	}
+	if assets.ActiveLevelPack() != nil {
+		t.Error("expected the built-in content to stay active")
+	}
+}
@@ -0,0 +1,224 @@
+package systems
+
+import (
+	"fmt"
+
+	"github.com/automoto/doomerang/assets"
+	"github.com/automoto/doomerang/components"
+	cfg "github.com/automoto/doomerang/config"
+	"github.com/automoto/doomerang/fonts"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/yohamta/donburi/ecs"
+)
+
+// LevelStatus describes whether a campaign level can be played from level select.
+type LevelStatus int
+
+const (
+	LevelLocked LevelStatus = iota
+	LevelUnlocked
+	LevelCompleted
+)
+
+// LevelSelectEntry is one row on the level select screen.
+type LevelSelectEntry struct {
+	Index  int
+	Name   string // display name, e.g. "level1"
+	Status LevelStatus
+	Record SavedLevelRecord
+}
+
+// BuildLevelSelectEntries combines the discovered level paths with saved records.
+// A level is unlocked if it is the first level, the previous level has been completed,
+// or campaign progress has already reached it (furthestIndex).
+func BuildLevelSelectEntries(levelPaths []string, records map[string]SavedLevelRecord, furthestIndex int) []LevelSelectEntry {
+	entries := make([]LevelSelectEntry, len(levelPaths))
+	for i, path := range levelPaths {
+		record := records[path]
+
+		status := LevelLocked
+		switch {
+		case record.Completed:
+			status = LevelCompleted
+		case i == 0, i <= furthestIndex, records[levelPaths[i-1]].Completed:
+			status = LevelUnlocked
+		}
+
+		entries[i] = LevelSelectEntry{
+			Index:  i,
+			Name:   assets.LevelDisplayName(path),
+			Status: status,
+			Record: record,
+		}
+	}
+	return entries
+}
+
+// LoadLevelSelectEntries discovers campaign levels and reads their saved status.
+func LoadLevelSelectEntries() []LevelSelectEntry {
+	paths, err := assets.NewLevelLoader().LevelPaths()
+	if err != nil {
+		return nil
+	}
+
+	records, _ := LoadLevelRecords()
+
+	furthest := 0
+	if progress, _ := LoadGameProgress(); progress != nil {
+		furthest = progress.LevelIndex
+	}
+
+	return BuildLevelSelectEntries(paths, records, furthest)
+}
+
+// GetOrCreateLevelSelect returns the singleton LevelSelect component, creating if needed
+func GetOrCreateLevelSelect(e *ecs.ECS) *components.LevelSelectData {
+	if _, ok := components.LevelSelect.First(e.World); !ok {
+		ent := e.World.Entry(e.World.Create(components.LevelSelect))
+		components.LevelSelect.SetValue(ent, components.LevelSelectData{})
+	}
+
+	ent, _ := components.LevelSelect.First(e.World)
+	return components.LevelSelect.Get(ent)
+}
+
+// NewUpdateLevelSelect creates the update system for the level select screen.
+// Selecting an unlocked level starts the campaign at that level; back returns to the menu.
+func NewUpdateLevelSelect(
+	sceneChanger SceneChanger,
+	entries []LevelSelectEntry,
+	createLevelScene func(levelIndex int) interface{},
+	createMenuScene func() interface{},
+) ecs.System {
+	// Skip the first frame so the confirm press that opened this screen
+	// from the main menu doesn't immediately start the first level.
+	firstFrame := true
+	return func(e *ecs.ECS) {
+		if firstFrame {
+			firstFrame = false
+			return
+		}
+
+		levelSelect := GetOrCreateLevelSelect(e)
+		input := getOrCreateInput(e)
+
+		if GetAction(input, cfg.ActionMenuBack).JustPressed {
+			PlaySFX(e, cfg.SoundMenuNavigate)
+			sceneChanger.ChangeScene(createMenuScene())
+			return
+		}
+
+		numEntries := len(entries)
+		if numEntries == 0 {
+			return
+		}
+
+		if GetAction(input, cfg.ActionMenuUp).JustPressed {
+			PlaySFX(e, cfg.SoundMenuNavigate)
+			levelSelect.SelectedIndex = (levelSelect.SelectedIndex - 1 + numEntries) % numEntries
+		}
+		if GetAction(input, cfg.ActionMenuDown).JustPressed {
+			PlaySFX(e, cfg.SoundMenuNavigate)
+			levelSelect.SelectedIndex = (levelSelect.SelectedIndex + 1) % numEntries
+		}
+
+		if GetAction(input, cfg.ActionMenuSelect).JustPressed {
+			entry := entries[levelSelect.SelectedIndex]
+			if entry.Status == LevelLocked {
+				return
+			}
+			PlaySFX(e, cfg.SoundMenuSelect)
+			FadeOutMusic(e)
+			sceneChanger.ChangeScene(createLevelScene(entry.Index))
+		}
+	}
+}
+
+// DrawLevelSelect returns a renderer for the level select screen.
+func DrawLevelSelect(entries []LevelSelectEntry) func(*ecs.ECS, *ebiten.Image) {
+	return func(e *ecs.ECS, screen *ebiten.Image) {
+		levelSelect := GetOrCreateLevelSelect(e)
+		width := float64(screen.Bounds().Dx())
+		height := float64(screen.Bounds().Dy())
+
+		vector.FillRect(
+			screen,
+			0, 0,
+			float32(width), float32(height),
+			cfg.LevelSelect.BackgroundColor,
+			false,
+		)
+
+		titleFont := fonts.ExcelTitle.GetV2()
+		titleX := centerTextX(cfg.LevelSelect.Title, titleFont, width)
+		drawText(screen, cfg.LevelSelect.Title, titleFont, titleX, int(cfg.LevelSelect.TitleY), cfg.LevelSelect.TitleColor)
+
+		// Scroll so the selected row stays within the visible window
+		first := 0
+		if visible := cfg.LevelSelect.VisibleRows; levelSelect.SelectedIndex >= visible {
+			first = levelSelect.SelectedIndex - visible + 1
+		}
+
+		rowFont := fonts.ExcelBold.GetV2()
+		for i := first; i < len(entries) && i < first+cfg.LevelSelect.VisibleRows; i++ {
+			entry := entries[i]
+			y := int(cfg.LevelSelect.ListStartY) + (i-first)*int(cfg.LevelSelect.RowHeight)
+
+			nameColor := cfg.LevelSelect.TextColorNormal
+			if entry.Status == LevelLocked {
+				nameColor = cfg.LevelSelect.TextColorLocked
+			}
+			if i == levelSelect.SelectedIndex {
+				nameColor = cfg.LevelSelect.TextColorSelected
+			}
+
+			name := fmt.Sprintf("%d. %s", entry.Index+1, entry.Name)
+			drawText(screen, name, rowFont, int(cfg.LevelSelect.NameX), y, nameColor)
+
+			statusColor := cfg.LevelSelect.TextColorNormal
+			switch entry.Status {
+			case LevelLocked:
+				statusColor = cfg.LevelSelect.TextColorLocked
+			case LevelCompleted:
+				statusColor = cfg.LevelSelect.CompletedColor
+			}
+			drawText(screen, getLevelStatusLabel(entry.Status), rowFont, int(cfg.LevelSelect.StatusX), y, statusColor)
+
+			if entry.Status == LevelCompleted {
+				best := entry.Record.BestSecs
+				drawText(screen, fmt.Sprintf("%dm %02ds", best/60, best%60), rowFont, int(cfg.LevelSelect.BestTimeX), y, cfg.LevelSelect.TextColorNormal)
+				drawText(screen, fmt.Sprintf("%d deaths", entry.Record.FewestDeaths), rowFont, int(cfg.LevelSelect.DeathsX), y, cfg.LevelSelect.TextColorNormal)
+			}
+		}
+
+		input := getOrCreateInput(e)
+		hint := getLevelSelectHint(input.LastInputMethod)
+		hintFont := fonts.ExcelSmall.GetV2()
+		hintX := centerTextX(hint, hintFont, width)
+		drawText(screen, hint, hintFont, hintX, int(height)-12, cfg.LevelSelect.TextColorNormal)
+	}
+}
+
+// getLevelStatusLabel returns the display text for a level's status
+func getLevelStatusLabel(status LevelStatus) string {
+	switch status {
+	case LevelCompleted:
+		return "Completed"
+	case LevelUnlocked:
+		return "Unlocked"
+	default:
+		return "Locked"
+	}
+}
+
+// getLevelSelectHint returns the appropriate hint for level select navigation
+func getLevelSelectHint(method components.InputMethod) string {
+	switch method {
+	case components.InputPlayStation:
+		return "D-Pad: Navigate   Cross: Play   Circle: Back"
+	case components.InputXbox:
+		return "D-Pad: Navigate   A: Play   B: Back"
+	}
+	return "Arrows: Navigate   Enter: Play   Esc: Back"
+}
@@ -0,0 +1,50 @@
+package systems_test
+
+import (
+	"testing"
+
+	"github.com/automoto/doomerang/systems"
+)
+
+var testLevelPaths = []string{"levels/level1.tmx", "levels/level2.tmx", "levels/level3.tmx"}
+
+func TestBuildLevelSelectEntries_FreshSave(t *testing.T) {
+	entries := systems.BuildLevelSelectEntries(testLevelPaths, nil, 0)
+
+	want := []systems.LevelStatus{systems.LevelUnlocked, systems.LevelLocked, systems.LevelLocked}
+	for i, entry := range entries {
+		if entry.Status != want[i] {
+			t.Errorf("level %d: expected status %d, got %d", i, want[i], entry.Status)
+		}
+	}
+	if entries[0].Name != "level1" {
+		t.Errorf("expected display name level1, got %q", entries[0].Name)
+	}
+}
+
+func TestBuildLevelSelectEntries_CompletionUnlocksNext(t *testing.T) {
+	records := map[string]systems.SavedLevelRecord{
+		"levels/level1.tmx": {Completed: true, BestSecs: 95, FewestDeaths: 2},
+	}
+	entries := systems.BuildLevelSelectEntries(testLevelPaths, records, 0)
+
+	want := []systems.LevelStatus{systems.LevelCompleted, systems.LevelUnlocked, systems.LevelLocked}
+	for i, entry := range entries {
+		if entry.Status != want[i] {
+			t.Errorf("level %d: expected status %d, got %d", i, want[i], entry.Status)
+		}
+	}
+	if entries[0].Record.BestSecs != 95 {
+		t.Errorf("expected best time 95, got %d", entries[0].Record.BestSecs)
+	}
+}
+
+func TestBuildLevelSelectEntries_CampaignProgressUnlocks(t *testing.T) {
+	entries := systems.BuildLevelSelectEntries(testLevelPaths, nil, 2)
+
+	for i, entry := range entries {
+		if entry.Status != systems.LevelUnlocked {
+			t.Errorf("level %d: expected unlocked, got %d", i, entry.Status)
+		}
+	}
+}
@@ -22,12 +22,19 @@ type PlatformerSceneCreator interface {
 	NewPlatformerScene() interface{}
 }
 
+// MenuScenes creates the scenes the main menu options open, besides the
+// campaign. An option whose factory is nil does nothing.
+type MenuScenes struct {
+	Roguelite   func() interface{}
+	LevelSelect func() interface{}
+	EnterSeed   func() interface{}
+	Daily       func() interface{}
+	RunHistory  func() interface{}
+	LevelPacks  func() interface{}
+}
+
 // NewUpdateMenu creates an UpdateMenu system with scene transition capability
-func NewUpdateMenu(sceneChanger SceneChanger, createPlatformerScene func() interface{}, sceneFactories ...func() interface{}) ecs.System {
-	var createRogueliteScene func() interface{}
-	if len(sceneFactories) > 0 {
-		createRogueliteScene = sceneFactories[0]
-	}
+func NewUpdateMenu(sceneChanger SceneChanger, createPlatformerScene func() interface{}, scenes MenuScenes) ecs.System {
 	// firstFrame guard prevents input bleed: if the player is still holding the
 	// confirm key from a previous scene (e.g. selecting "Main Menu" on the run
 	// summary or game over screen), the fresh InputData sees JustPressed=true,
@@ -90,9 +97,29 @@ func NewUpdateMenu(sceneChanger SceneChanger, createPlatformerScene func() inter
 				FadeOutMusic(e)
 				sceneChanger.ChangeScene(createPlatformerScene())
 			case components.MainMenuRoguelite:
-				if createRogueliteScene != nil {
+				if scenes.Roguelite != nil {
 					FadeOutMusic(e)
-					sceneChanger.ChangeScene(createRogueliteScene())
+					sceneChanger.ChangeScene(scenes.Roguelite())
+				}
+			case components.MainMenuDaily:
+				if scenes.Daily != nil {
+					sceneChanger.ChangeScene(scenes.Daily())
+				}
+			case components.MainMenuSeedEntry:
+				if scenes.EnterSeed != nil {
+					sceneChanger.ChangeScene(scenes.EnterSeed())
+				}
+			case components.MainMenuRunHistory:
+				if scenes.RunHistory != nil {
+					sceneChanger.ChangeScene(scenes.RunHistory())
+				}
+			case components.MainMenuLevelSelect:
+				if scenes.LevelSelect != nil {
+					sceneChanger.ChangeScene(scenes.LevelSelect())
+				}
+			case components.MainMenuLevelPacks:
+				if scenes.LevelPacks != nil {
+					sceneChanger.ChangeScene(scenes.LevelPacks())
 				}
 			case components.MainMenuSettings:
 				OpenSettings(e, false)
@@ -277,6 +304,16 @@ func getOptionLabel(option components.MainMenuOption) string {
 		return "Continue"
 	case components.MainMenuRoguelite:
 		return "Roguelite"
+	case components.MainMenuDaily:
+		return "Daily Run"
+	case components.MainMenuSeedEntry:
+		return "Enter Seed"
+	case components.MainMenuRunHistory:
+		return "Run History"
+	case components.MainMenuLevelSelect:
+		return "Level Select"
+	case components.MainMenuLevelPacks:
+		return "Level Packs"
 	case components.MainMenuSettings:
 		return "Settings"
 	case components.MainMenuExit:
@@ -300,6 +337,11 @@ func GetOrCreateMenu(e *ecs.ECS) *components.MenuData {
 				components.MainMenuContinue,
 				components.MainMenuStart,
 				components.MainMenuRoguelite,
+				components.MainMenuDaily,
+				components.MainMenuSeedEntry,
+				components.MainMenuRunHistory,
+				components.MainMenuLevelSelect,
+				components.MainMenuLevelPacks,
 				components.MainMenuSettings,
 				components.MainMenuExit,
 			}
@@ -308,6 +350,11 @@ func GetOrCreateMenu(e *ecs.ECS) *components.MenuData {
 			visibleOptions = []components.MainMenuOption{
 				components.MainMenuStart,
 				components.MainMenuRoguelite,
+				components.MainMenuDaily,
+				components.MainMenuSeedEntry,
+				components.MainMenuRunHistory,
+				components.MainMenuLevelSelect,
+				components.MainMenuLevelPacks,
 				components.MainMenuSettings,
 				components.MainMenuExit,
 			}
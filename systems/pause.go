@@ -63,6 +63,9 @@ func UpdatePause(ecs *ecs.ECS) {
 		case components.MenuSettings:
 			OpenSettings(ecs, true)
 		case components.MenuExit:
+			FlushReplayRecording()
+			FlushRunHistory()
+			FlushTelemetry()
 			os.Exit(0)
 		}
 	}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"log"
 
+	"github.com/automoto/doomerang/assets"
 	"github.com/automoto/doomerang/components"
 	cfg "github.com/automoto/doomerang/config"
 	"github.com/hajimehoshi/ebiten/v2"
@@ -39,6 +40,54 @@ func InitPersistence() error {
 	return nil
 }
 
+// campaignItemKey returns the save item for campaign data. A level pack with
+// its own campaign keeps its progress and records apart from the built-in one.
+func campaignItemKey(item string) string {
+	if pack := assets.ActiveLevelPack(); pack != nil && pack.Levels > 0 {
+		return item + "_pack_" + pack.ID
+	}
+	return item
+}
+
+// runItemKey returns the save item for roguelite run data. A level pack with
+// its own chunks generates different runs, so its daily results and run
+// history are kept apart from the built-in ones.
+func runItemKey(item string) string {
+	if pack := assets.ActiveLevelPack(); pack != nil && pack.Chunks > 0 {
+		return item + "_pack_" + pack.ID
+	}
+	return item
+}
+
+// LoadLevelPackID returns the ID of the level pack chosen on the level packs
+// screen, or "" for the built-in content
+func LoadLevelPackID() string {
+	if !gdataInitialized || gdataManager == nil {
+		return ""
+	}
+
+	data, err := gdataManager.LoadItem("level_pack")
+	if err != nil {
+		log.Printf("Warning: Could not load level pack choice: %v", err)
+		return ""
+	}
+	return string(data)
+}
+
+// SaveLevelPackID remembers the chosen level pack; "" is the built-in content
+func SaveLevelPackID(id string) error {
+	if !gdataInitialized || gdataManager == nil {
+		return nil
+	}
+
+	if err := gdataManager.SaveItem("level_pack", []byte(id)); err != nil {
+		log.Printf("Warning: Could not save level pack choice: %v", err)
+		return err
+	}
+
+	return nil
+}
+
 // LoadSettings loads settings from disk
 func LoadSettings() (*SavedSettings, error) {
 	if !gdataInitialized || gdataManager == nil {
@@ -178,6 +227,7 @@ type SavedGameProgress struct {
 	CheckpointID     float64 `json:"checkpointId"`
 	CheckpointSpawnX float64 `json:"checkpointSpawnX"`
 	CheckpointSpawnY float64 `json:"checkpointSpawnY"`
+	LevelStart       bool    `json:"levelStart,omitempty"` // true = spawn at the level's default start
 }
 
 func LoadGameProgress() (*SavedGameProgress, error) {
@@ -185,7 +235,7 @@ func LoadGameProgress() (*SavedGameProgress, error) {
 		return nil, nil
 	}
 
-	data, err := gdataManager.LoadItem("progress")
+	data, err := gdataManager.LoadItem(campaignItemKey("progress"))
 	if err != nil {
 		log.Printf("Warning: Could not load game progress: %v", err)
 		return nil, nil
@@ -204,7 +254,7 @@ func LoadGameProgress() (*SavedGameProgress, error) {
 }
 
 func SaveGameProgress(levelIndex int, checkpoint *components.ActiveCheckpointData) error {
-	if !gdataInitialized || gdataManager == nil || checkpoint == nil {
+	if !gdataInitialized || gdataManager == nil || checkpoint == nil || savedProgressAhead(levelIndex) {
 		return nil
 	}
 
@@ -221,7 +271,7 @@ func SaveGameProgress(levelIndex int, checkpoint *components.ActiveCheckpointDat
 		return err
 	}
 
-	if err := gdataManager.SaveItem("progress", data); err != nil {
+	if err := gdataManager.SaveItem(campaignItemKey("progress"), data); err != nil {
 		log.Printf("Warning: Could not save game progress: %v", err)
 		return err
 	}
@@ -229,13 +279,46 @@ func SaveGameProgress(levelIndex int, checkpoint *components.ActiveCheckpointDat
 	return nil
 }
 
+// SaveLevelProgress records that the campaign has advanced to levelIndex.
+// No checkpoint has been reached yet, so the player resumes at the level start.
+func SaveLevelProgress(levelIndex int) error {
+	if !gdataInitialized || gdataManager == nil || savedProgressAhead(levelIndex) {
+		return nil
+	}
+
+	progress := &SavedGameProgress{
+		LevelIndex: levelIndex,
+		LevelStart: true,
+	}
+
+	data, err := json.Marshal(progress)
+	if err != nil {
+		log.Printf("Warning: Could not serialize game progress: %v", err)
+		return err
+	}
+
+	if err := gdataManager.SaveItem(campaignItemKey("progress"), data); err != nil {
+		log.Printf("Warning: Could not save game progress: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+// savedProgressAhead reports whether the saved campaign is already past
+// levelIndex; progress is never moved back
+func savedProgressAhead(levelIndex int) bool {
+	progress, _ := LoadGameProgress()
+	return progress != nil && progress.LevelIndex > levelIndex
+}
+
 // HasSaveGame returns true if a saved game progress exists
 func HasSaveGame() bool {
 	if !gdataInitialized || gdataManager == nil {
 		return false
 	}
 
-	data, err := gdataManager.LoadItem("progress")
+	data, err := gdataManager.LoadItem(campaignItemKey("progress"))
 	if err != nil || data == nil || len(data) == 0 {
 		return false
 	}
@@ -320,6 +403,303 @@ func ClearRogueliteStats() error {
 	return nil
 }
 
+// SavedLevelRecord contains the best results for a single campaign level
+type SavedLevelRecord struct {
+	Completed    bool  `json:"completed"`
+	BestSecs     int64 `json:"bestSecs"`     // 0 = no completion recorded yet
+	FewestDeaths int   `json:"fewestDeaths"` // only meaningful when Completed
+}
+
+// LoadLevelRecords loads per-level records keyed by level name.
+// Returns an empty map if no data is saved yet.
+func LoadLevelRecords() (map[string]SavedLevelRecord, error) {
+	records := make(map[string]SavedLevelRecord)
+	if !gdataInitialized || gdataManager == nil {
+		return records, nil
+	}
+
+	data, err := gdataManager.LoadItem(campaignItemKey("level_records"))
+	if err != nil || len(data) == 0 {
+		return records, nil
+	}
+
+	if err := json.Unmarshal(data, &records); err != nil {
+		log.Printf("Warning: Could not parse level records: %v", err)
+		return make(map[string]SavedLevelRecord), err
+	}
+
+	return records, nil
+}
+
+// SaveLevelCompletion merges a completed level attempt into the persisted level records.
+func SaveLevelCompletion(levelName string, elapsedSecs int64, deaths int) error {
+	if !gdataInitialized || gdataManager == nil {
+		return nil
+	}
+
+	records, err := LoadLevelRecords()
+	if err != nil {
+		log.Printf("Warning: Could not load level records, starting fresh: %v", err)
+	}
+
+	record, seen := records[levelName]
+	if !seen || !record.Completed {
+		record = SavedLevelRecord{
+			Completed:    true,
+			BestSecs:     elapsedSecs,
+			FewestDeaths: deaths,
+		}
+	} else {
+		if record.BestSecs == 0 || (elapsedSecs > 0 && elapsedSecs < record.BestSecs) {
+			record.BestSecs = elapsedSecs
+		}
+		if deaths < record.FewestDeaths {
+			record.FewestDeaths = deaths
+		}
+	}
+	records[levelName] = record
+
+	data, err := json.Marshal(records)
+	if err != nil {
+		log.Printf("Warning: Could not serialize level records: %v", err)
+		return err
+	}
+
+	if err := gdataManager.SaveItem(campaignItemKey("level_records"), data); err != nil {
+		log.Printf("Warning: Could not save level records: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+// ClearLevelRecords removes any saved per-level records
+func ClearLevelRecords() error {
+	if !gdataInitialized || gdataManager == nil {
+		return nil
+	}
+
+	if err := gdataManager.SaveItem(campaignItemKey("level_records"), nil); err != nil {
+		log.Printf("Warning: Could not clear level records: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+// RunOutcome is how a roguelite run ended
+type RunOutcome string
+
+const (
+	RunAbandoned RunOutcome = "abandoned" // started but never finished, e.g. quit mid-run
+	RunCleared   RunOutcome = "cleared"
+	RunDied      RunOutcome = "died"
+)
+
+// SavedDailyRun is the scored result of one day's daily run
+type SavedDailyRun struct {
+	Date         string     `json:"date"` // procgen.DailyKey of the day played
+	SeedCode     string     `json:"seedCode"`
+	Outcome      RunOutcome `json:"outcome"`
+	RoomsCleared int        `json:"roomsCleared"`
+	TotalRooms   int        `json:"totalRooms"`
+	KillCount    int        `json:"killCount"`
+	ElapsedSecs  int64      `json:"elapsedSecs"`
+}
+
+// LoadDailyRuns loads daily run results keyed by date.
+// Returns an empty map if no data is saved yet.
+func LoadDailyRuns() (map[string]SavedDailyRun, error) {
+	runs := make(map[string]SavedDailyRun)
+	if !gdataInitialized || gdataManager == nil {
+		return runs, nil
+	}
+
+	data, err := gdataManager.LoadItem(runItemKey("daily_runs"))
+	if err != nil || len(data) == 0 {
+		return runs, nil
+	}
+
+	if err := json.Unmarshal(data, &runs); err != nil {
+		log.Printf("Warning: Could not parse daily runs: %v", err)
+		return make(map[string]SavedDailyRun), err
+	}
+
+	return runs, nil
+}
+
+func saveDailyRuns(runs map[string]SavedDailyRun) error {
+	data, err := json.Marshal(runs)
+	if err != nil {
+		log.Printf("Warning: Could not serialize daily runs: %v", err)
+		return err
+	}
+
+	if err := gdataManager.SaveItem(runItemKey("daily_runs"), data); err != nil {
+		log.Printf("Warning: Could not save daily runs: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+// StartDailyAttempt records the start of a daily run and reports whether the
+// attempt is scored. Only the first attempt each day is scored; it is saved as
+// abandoned straight away so quitting mid-run can't be used to try again.
+func StartDailyAttempt(date, seedCode string) bool {
+	if !gdataInitialized || gdataManager == nil {
+		return false
+	}
+
+	// Saving over unreadable records would lose them; play unscored instead
+	runs, err := LoadDailyRuns()
+	if err != nil {
+		log.Printf("Warning: Could not load daily runs, attempt not scored: %v", err)
+		return false
+	}
+	if _, played := runs[date]; played {
+		return false
+	}
+
+	runs[date] = SavedDailyRun{Date: date, SeedCode: seedCode, Outcome: RunAbandoned}
+	return saveDailyRuns(runs) == nil
+}
+
+// SaveDailyResult stores the outcome of the scored attempt started for date.
+// Results for days without a started, unfinished attempt are ignored.
+func SaveDailyResult(date string, outcome RunOutcome, run FinalRunStats) error {
+	if !gdataInitialized || gdataManager == nil {
+		return nil
+	}
+
+	runs, err := LoadDailyRuns()
+	if err != nil {
+		return err
+	}
+	record, ok := runs[date]
+	if !ok || record.Outcome != RunAbandoned {
+		return nil
+	}
+
+	record.Outcome = outcome
+	record.RoomsCleared = run.RoomsCleared
+	record.TotalRooms = run.TotalRooms
+	record.KillCount = run.KillCount
+	record.ElapsedSecs = run.ElapsedSecs
+	runs[date] = record
+	return saveDailyRuns(runs)
+}
+
+// ClearDailyRuns removes any saved daily run results
+func ClearDailyRuns() error {
+	if !gdataInitialized || gdataManager == nil {
+		return nil
+	}
+
+	if err := gdataManager.SaveItem(runItemKey("daily_runs"), nil); err != nil {
+		log.Printf("Warning: Could not clear daily runs: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+// SavedRunRecord is one roguelite run in the run history
+type SavedRunRecord struct {
+	ID           int64      `json:"id"`   // start time in Unix nanoseconds
+	Date         string     `json:"date"` // local start time, e.g. "2026-10-17 21:04"
+	Seed         int64      `json:"seed"`
+	SeedCode     string     `json:"seedCode"`
+	DailyDate    string     `json:"dailyDate,omitempty"`
+	Outcome      RunOutcome `json:"outcome"`
+	RoomsCleared int        `json:"roomsCleared"`
+	TotalRooms   int        `json:"totalRooms"`
+	KillCount    int        `json:"killCount"`
+	Deaths       int        `json:"deaths"`
+	ElapsedSecs  int64      `json:"elapsedSecs"`
+	CauseOfDeath string     `json:"causeOfDeath,omitempty"`
+	DeathChunk   string     `json:"deathChunk,omitempty"`
+	Chunks       []string   `json:"chunks"`
+}
+
+// LoadRunHistory loads saved run records, oldest first.
+// Returns an empty list if no data is saved yet.
+func LoadRunHistory() ([]SavedRunRecord, error) {
+	if !gdataInitialized || gdataManager == nil {
+		return nil, nil
+	}
+
+	data, err := gdataManager.LoadItem(runItemKey("run_history"))
+	if err != nil || len(data) == 0 {
+		return nil, nil
+	}
+
+	var records []SavedRunRecord
+	if err := json.Unmarshal(data, &records); err != nil {
+		log.Printf("Warning: Could not parse run history: %v", err)
+		return nil, err
+	}
+
+	return records, nil
+}
+
+// SaveRunRecord adds a run to the history, replacing the record with the same
+// ID if there is one. Only the newest cfg.RunHistory.MaxEntries runs are kept.
+func SaveRunRecord(record SavedRunRecord) error {
+	if !gdataInitialized || gdataManager == nil {
+		return nil
+	}
+
+	// Saving over an unreadable history would lose it; skip this run instead
+	records, err := LoadRunHistory()
+	if err != nil {
+		log.Printf("Warning: Could not load run history, run not saved: %v", err)
+		return err
+	}
+
+	replaced := false
+	for i := range records {
+		if records[i].ID == record.ID {
+			records[i] = record
+			replaced = true
+			break
+		}
+	}
+	if !replaced {
+		records = append(records, record)
+	}
+	if extra := len(records) - cfg.RunHistory.MaxEntries; extra > 0 {
+		records = records[extra:]
+	}
+
+	data, err := json.Marshal(records)
+	if err != nil {
+		log.Printf("Warning: Could not serialize run history: %v", err)
+		return err
+	}
+
+	if err := gdataManager.SaveItem(runItemKey("run_history"), data); err != nil {
+		log.Printf("Warning: Could not save run history: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+// ClearRunHistory removes all saved run records
+func ClearRunHistory() error {
+	if !gdataInitialized || gdataManager == nil {
+		return nil
+	}
+
+	if err := gdataManager.SaveItem(runItemKey("run_history"), nil); err != nil {
+		log.Printf("Warning: Could not clear run history: %v", err)
+		return err
+	}
+
+	return nil
+}
+
 // ClearGameProgress removes any saved game progress
 func ClearGameProgress() error {
 	if !gdataInitialized || gdataManager == nil {
@@ -327,7 +707,7 @@ func ClearGameProgress() error {
 	}
 
 	// Save empty/nil data to clear the progress
-	if err := gdataManager.SaveItem("progress", nil); err != nil {
+	if err := gdataManager.SaveItem(campaignItemKey("progress"), nil); err != nil {
 		log.Printf("Warning: Could not clear game progress: %v", err)
 		return err
 	}
@@ -173,3 +173,33 @@ func TestFastestSecsZeroMeansNoCompletion(t *testing.T) {
 	// This is tested indirectly by TestSaveLoadRogueliteStats_FirstRun
 	t.Log("FastestSecs zero-state handled by SaveRogueliteLifetimeStats correctly")
 }
+
+func TestSaveLevelCompletion_KeepsBestResults(t *testing.T) {
+	setupPersistence(t)
+	if err := systems.ClearLevelRecords(); err != nil {
+		t.Fatalf("failed to clear level records: %v", err)
+	}
+
+	const level = "levels/test_level.tmx"
+	if err := systems.SaveLevelCompletion(level, 120, 3); err != nil {
+		t.Fatalf("first save failed: %v", err)
+	}
+	if err := systems.SaveLevelCompletion(level, 90, 5); err != nil {
+		t.Fatalf("second save failed: %v", err)
+	}
+
+	records, err := systems.LoadLevelRecords()
+	if err != nil {
+		t.Fatalf("LoadLevelRecords failed: %v", err)
+	}
+	record := records[level]
+	if !record.Completed {
+		t.Error("expected level to be marked completed")
+	}
+	if record.BestSecs != 90 {
+		t.Errorf("expected BestSecs=90, got %d", record.BestSecs)
+	}
+	if record.FewestDeaths != 3 {
+		t.Errorf("expected FewestDeaths=3, got %d", record.FewestDeaths)
+	}
+}
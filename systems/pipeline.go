@@ -0,0 +1,29 @@
+package systems
+
+import "github.com/yohamta/donburi/ecs"
+
+// AddGameplaySystems registers the simulation systems shared by every gameplay
+// scene, in update order. Each one stops while paused or after the level is complete.
+// Scenes add input, audio, scene-flow and camera systems around these.
+func AddGameplaySystems(e *ecs.ECS) {
+	e.AddSystem(WithGameplayChecks(UpdatePlayer))
+	e.AddSystem(WithGameplayChecks(UpdateEnemies))
+	e.AddSystem(WithGameplayChecks(UpdateStates))
+	e.AddSystem(WithGameplayChecks(UpdatePhysics))
+	e.AddSystem(WithGameplayChecks(UpdateCollisions))
+	e.AddSystem(WithGameplayChecks(UpdateObjects))
+	e.AddSystem(WithGameplayChecks(UpdateBoomerang))
+	e.AddSystem(WithGameplayChecks(UpdateKnives))
+	e.AddSystem(WithGameplayChecks(UpdateCombat))
+	e.AddSystem(WithGameplayChecks(UpdateCombatHitboxes))
+	e.AddSystem(WithGameplayChecks(UpdateDeaths))
+	e.AddSystem(WithGameplayChecks(UpdateRunStats))
+	e.AddSystem(WithGameplayChecks(UpdateCheckpoints))
+	e.AddSystem(WithGameplayChecks(UpdateRewards))
+	e.AddSystem(WithGameplayChecks(UpdateFire))
+	e.AddSystem(WithGameplayChecks(UpdateEffects))
+	e.AddSystem(WithGameplayChecks(UpdateMessage))
+	e.AddSystem(WithGameplayChecks(UpdateFinishLine))
+	// Delivers this frame's gameplay events; runs even when paused or complete
+	e.AddSystem(UpdateTelemetry)
+}
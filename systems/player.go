@@ -1,6 +1,8 @@
 package systems
 
 import (
+	"math"
+
 	cfg "github.com/automoto/doomerang/config"
 
 	"github.com/automoto/doomerang/components"
@@ -35,6 +37,7 @@ func UpdatePlayer(ecs *ecs.ECS) {
 	animData := components.Animation.Get(playerEntry)
 	playerObject := components.Object.Get(playerEntry).Object
 
+	updateBoomerangButton(ecs, input, playerEntry, player)
 	handlePlayerInput(ecs, input, player, physics, melee, state, playerObject)
 	updatePlayerState(ecs, input, playerEntry, player, physics, melee, state, animData)
 
@@ -181,9 +184,7 @@ func updatePlayerState(ecs *ecs.ECS, input *components.InputData, playerEntry *d
 			state.StateTimer = 0
 		} else if boomerangAction.Pressed && player.ActiveBoomerang == nil {
 			// Start Charging Boomerang (allowed in air too)
-			state.CurrentState = cfg.StateChargingBoomerang
-			player.BoomerangChargeTime = 0
-			state.StateTimer = 0
+			startBoomerangCharge(player, state)
 		} else if crouchAction.JustPressed && physics.OnGround != nil {
 			// Slide if moving fast enough, otherwise crouch
 			if absFloat(physics.SpeedX) >= cfg.Player.SlideSpeedThreshold {
@@ -227,7 +228,7 @@ func updatePlayerState(ecs *ecs.ECS, input *components.InputData, playerEntry *d
 				player.BoomerangChargeTime++
 			}
 			// Spawn charge VFX after holding for a bit (not on quick throws)
-			if player.BoomerangChargeTime == 15 && player.ChargeVFX == nil {
+			if player.BoomerangChargeTime >= 15 && player.ChargeVFX == nil {
 				player.ChargeVFX = factory.SpawnChargeVFX(ecs, playerObject.X+playerObject.W/2, playerObject.Y+playerObject.H)
 				PlaySFX(ecs, cfg.SoundBoomerangCharge)
 			}
@@ -249,7 +250,12 @@ func updatePlayerState(ecs *ecs.ECS, input *components.InputData, playerEntry *d
 		state.StateTimer = 0
 		PlaySFX(ecs, cfg.SoundBoomerangThrow)
 		aimX, aimY := calculateBoomerangAim(input, player.Direction.X)
+		// A free aim behind the player turns them around to throw
+		if math.Abs(aimX) > 0.01 {
+			player.Direction.X = math.Copysign(1, aimX)
+		}
 		factory.CreateBoomerang(ecs, playerEntry, float64(player.BoomerangChargeTime), aimX, aimY)
+		publishGameplayEvent(ecs, playerEntry, components.GameplayEventData{Kind: components.EventBoomerangThrow})
 
 	case cfg.Throw:
 		// Apply friction instead of instant stop for smoother feel
@@ -323,9 +329,7 @@ func updatePlayerState(ecs *ecs.ECS, input *components.InputData, playerEntry *d
 	case cfg.Jump:
 		// Allow boomerang throw while jumping
 		if boomerangAction.Pressed && player.ActiveBoomerang == nil {
-			state.CurrentState = cfg.StateChargingBoomerang
-			player.BoomerangChargeTime = 0
-			state.StateTimer = 0
+			startBoomerangCharge(player, state)
 			break
 		}
 		// Transition to idle/running when landing on the ground
@@ -355,9 +359,26 @@ func updatePlayerState(ecs *ecs.ECS, input *components.InputData, playerEntry *d
 	updatePlayerAnimation(state, animData)
 }
 
+// startBoomerangCharge starts charging a throw, with a head start right
+// after a perfect catch
+func startBoomerangCharge(player *components.PlayerData, state *components.StateData) {
+	state.CurrentState = cfg.StateChargingBoomerang
+	state.StateTimer = 0
+	player.BoomerangChargeTime = 0
+	if player.ChargeBonusFrames > 0 {
+		player.BoomerangChargeTime = cfg.Boomerang.PerfectCatchCharge
+		player.ChargeBonusFrames = 0
+	}
+}
+
 // calculateBoomerangAim returns the aim direction vector based on input.
 // Returns (aimX, aimY) where the vector represents the throw direction.
+// A stick or mouse aims freely; otherwise the keys pick one of 8 directions.
 func calculateBoomerangAim(input *components.InputData, facingX float64) (aimX, aimY float64) {
+	if input.Aiming {
+		return aimDirection(input)
+	}
+
 	upAction := GetAction(input, cfg.ActionMoveUp)
 	downAction := GetAction(input, cfg.ActionCrouch) // Down/Crouch key for aiming down
 	leftAction := GetAction(input, cfg.ActionMoveLeft)
@@ -7,8 +7,11 @@ import (
 
 	"github.com/automoto/doomerang/components"
 	cfg "github.com/automoto/doomerang/config"
+	"github.com/automoto/doomerang/systems/factory"
+	"github.com/automoto/doomerang/tags"
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/solarlune/resolv"
 	"github.com/yohamta/donburi"
 	"github.com/yohamta/donburi/ecs"
 )
@@ -277,6 +280,119 @@ func DrawHealthBars(ecs *ecs.ECS, screen *ebiten.Image) {
 	})
 }
 
+// DrawBoomerangAim previews the flight of the throw the player is charging: a
+// dotted arc out to where the boomerang turns back, and a reticle there. The
+// arc bounces off walls while the throw has ricochets left, and stops at the
+// next wall, which sends the boomerang back or, on a fully charged throw,
+// holds it: a box shows where it will stick.
+func DrawBoomerangAim(ecs *ecs.ECS, screen *ebiten.Image) {
+	cameraEntry, ok := components.Camera.First(ecs.World)
+	if !ok {
+		return // No camera yet
+	}
+	playerEntry, ok := tags.Player.First(ecs.World)
+	if !ok || components.State.Get(playerEntry).CurrentState != cfg.StateChargingBoomerang {
+		return
+	}
+	camera := components.Camera.Get(cameraEntry)
+	space := components.Space.Get(components.Space.MustFirst(ecs.World))
+	player := components.Player.Get(playerEntry)
+	width, height := screen.Bounds().Dx(), screen.Bounds().Dy()
+
+	aimX, aimY := calculateBoomerangAim(getOrCreateInput(ecs), player.Direction.X)
+	launch := factory.NewBoomerangLaunch(playerEntry, float64(player.BoomerangChargeTime), aimX, aimY)
+	// Run modifiers can reach further and bounce more
+	ranged := components.BoomerangData{MaxRange: launch.MaxRange, Bounces: cfg.Boomerang.RicochetBounces}
+	factory.ApplyBoomerangModifiers(&ranged, player.Modifiers)
+	path, embedded := traceBoomerangAim(space, launch, ranged)
+
+	clr := lerpRGBA(cfg.AimPreview.QuickColor, cfg.AimPreview.Color, launch.ChargeRatio)
+	offsetX := float64(width)/2 - camera.Position.X
+	offsetY := float64(height)/2 - camera.Position.Y
+	center := factory.BoomerangSize / 2
+	dotSize := cfg.AimPreview.DotSize
+
+	x, y := launch.X, launch.Y
+	traveled, nextDot := 0.0, 0.0
+	for _, p := range path {
+		traveled += math.Hypot(p[0]-x, p[1]-y)
+		x, y = p[0], p[1]
+		if traveled >= nextDot {
+			nextDot += cfg.AimPreview.DotSpacing
+			vector.FillRect(screen, float32(x+center+offsetX-dotSize/2), float32(y+center+offsetY-dotSize/2),
+				float32(dotSize), float32(dotSize), clr, false)
+		}
+	}
+
+	if embedded {
+		vector.StrokeRect(screen, float32(x+offsetX), float32(y+offsetY),
+			factory.BoomerangSize, factory.BoomerangSize, 1, clr, false)
+		return
+	}
+	vector.StrokeCircle(screen, float32(x+center+offsetX), float32(y+center+offsetY), float32(cfg.AimPreview.ReticleRadius), 1, clr, true)
+}
+
+// traceBoomerangAim steps a throw the way UpdatePhysics, updateOutbound and
+// checkCollisions move it, and returns where the boomerang is each frame of
+// its outbound flight. embedded reports whether it ends stuck in a wall.
+func traceBoomerangAim(space *resolv.Space, launch factory.BoomerangLaunch, ranged components.BoomerangData) (path [][2]float64, embedded bool) {
+	// Checks the level without being part of it
+	probe := resolv.NewObject(launch.X, launch.Y, factory.BoomerangSize, factory.BoomerangSize)
+	probe.Space = space
+
+	x, y := launch.X, launch.Y
+	speedX, speedY := launch.SpeedX, launch.SpeedY
+	canEmbed := ranged.Heavy && cfg.Boomerang.EmbedFrames > 0 && launch.ChargeRatio >= 1
+	traveled := 0.0
+	for traveled < ranged.MaxRange {
+		speedY += cfg.Boomerang.Gravity
+		step := math.Hypot(speedX, speedY)
+		if step == 0 {
+			break
+		}
+		traveled += step
+		x += speedX
+		y += speedY
+		// Past its range it has already turned back before reaching a wall
+		outbound := traveled < ranged.MaxRange
+
+		switch {
+		case outbound && ranged.Bounces > 0:
+			if len(touchingWalls(probe, x, y)) == 0 {
+				break
+			}
+			vx, vy, ok := wallBounce(probe, x, y, speedX, speedY)
+			if !ok {
+				return path, false
+			}
+			x, y = x-speedX, y-speedY
+			speedX, speedY = vx, vy
+			ranged.Bounces--
+		case outbound && canEmbed && speedX != 0:
+			if wallX, ok := embedFace(probe, x, y, speedX, speedY); ok {
+				return append(path, [2]float64{wallX, y - speedY}), true
+			}
+			fallthrough
+		default:
+			cx0, cy0 := space.WorldToSpace(x, y)
+			cx1, cy1 := space.WorldToSpace(x+factory.BoomerangSize-1, y+factory.BoomerangSize-1)
+			if space.CheckCells(cx0, cy0, cx1-cx0+1, cy1-cy0+1, tags.ResolvSolid) != nil {
+				return path, false
+			}
+		}
+		path = append(path, [2]float64{x, y})
+	}
+	return path, false
+}
+
+// lerpRGBA blends from a to b by t (0.0-1.0)
+func lerpRGBA(a, b color.RGBA, t float64) color.RGBA {
+	mix := func(from, to uint8) uint8 {
+		return uint8(float64(from) + (float64(to)-float64(from))*t)
+	}
+	return color.RGBA{R: mix(a.R, b.R), G: mix(a.G, b.G), B: mix(a.B, b.B), A: mix(a.A, b.A)}
+}
+
 func DrawSprites(ecs *ecs.ECS, screen *ebiten.Image) {
 	// Get camera
 	cameraEntry, ok := components.Camera.First(ecs.World)
@@ -340,6 +456,24 @@ func TriggerHitFlash(entry *donburi.Entry) {
 	}
 }
 
+// TriggerCatchFlash starts a gold flash effect on the entity (for a perfect catch)
+func TriggerCatchFlash(entry *donburi.Entry) {
+	if entry.HasComponent(components.Flash) {
+		flash := components.Flash.Get(entry)
+		flash.Duration = cfg.Combat.CatchFlashFrames
+		flash.R, flash.G, flash.B = 3, 2.5, 1 // Gold tint (multiplier)
+	}
+}
+
+// TriggerBurnFlash starts an orange flash effect on the entity (for burn damage)
+func TriggerBurnFlash(entry *donburi.Entry) {
+	if entry.HasComponent(components.Flash) {
+		flash := components.Flash.Get(entry)
+		flash.Duration = cfg.Combat.HitFlashFrames
+		flash.R, flash.G, flash.B = 3, 1.8, 0.6 // Orange tint (multiplier)
+	}
+}
+
 // TriggerDamageFlash starts a red flash effect on the entity (for damage taken)
 func TriggerDamageFlash(entry *donburi.Entry) {
 	// Don't flash dying entities
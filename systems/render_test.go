@@ -0,0 +1,64 @@
+package systems_test
+
+import (
+	"testing"
+
+	"github.com/automoto/doomerang/components"
+	"github.com/automoto/doomerang/systems"
+	"github.com/automoto/doomerang/systems/factory"
+	"github.com/automoto/doomerang/tags"
+	"github.com/solarlune/resolv"
+)
+
+// aimSpace is a room with a wall on the left and right
+func aimSpace() *resolv.Space {
+	space := resolv.NewSpace(640, 480, 16, 16)
+	space.Add(
+		resolv.NewObject(0, 0, 32, 480, tags.ResolvSolid),
+		resolv.NewObject(320, 0, 32, 480, tags.ResolvSolid),
+	)
+	return space
+}
+
+func TestBoomerangAimPath(t *testing.T) {
+	throw := func(charge float64) factory.BoomerangLaunch {
+		return factory.BoomerangLaunch{X: 200, Y: 200, SpeedX: 6, SpeedY: -1, MaxRange: 600, ChargeRatio: charge}
+	}
+	rightmost := func(path [][2]float64) float64 {
+		x := 0.0
+		for _, p := range path {
+			x = max(x, p[0])
+		}
+		return x
+	}
+
+	t.Run("stops at the wall", func(t *testing.T) {
+		path, embedded := systems.TraceBoomerangAim(aimSpace(), throw(0.5), components.BoomerangData{MaxRange: 600, Heavy: true})
+		if embedded || len(path) == 0 {
+			t.Fatalf("embedded = %v with %d points, want a path that doesn't embed", embedded, len(path))
+		}
+		if end := path[len(path)-1][0]; end != rightmost(path) || end+factory.BoomerangSize > 320 {
+			t.Errorf("path ends at x %.1f, want it short of the wall at 320", end)
+		}
+	})
+
+	t.Run("bounces off the wall", func(t *testing.T) {
+		path, embedded := systems.TraceBoomerangAim(aimSpace(), throw(1), components.BoomerangData{MaxRange: 300, Bounces: 1, Heavy: true})
+		if embedded || len(path) == 0 {
+			t.Fatalf("embedded = %v with %d points, want a path that doesn't embed", embedded, len(path))
+		}
+		if end := path[len(path)-1][0]; end >= 200 || end < 32 {
+			t.Errorf("path ends at x %.1f, want it back between the walls after the bounce", end)
+		}
+	})
+
+	t.Run("embeds a charged throw", func(t *testing.T) {
+		path, embedded := systems.TraceBoomerangAim(aimSpace(), throw(1), components.BoomerangData{MaxRange: 600, Heavy: true})
+		if !embedded {
+			t.Fatal("a fully charged throw into a wall didn't embed")
+		}
+		if end := path[len(path)-1][0]; end != 320-factory.BoomerangSize {
+			t.Errorf("embedded at x %.1f, want flush against the wall at %.1f", end, 320-factory.BoomerangSize)
+		}
+	})
+}
@@ -0,0 +1,382 @@
+package systems
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"os"
+
+	"github.com/automoto/doomerang/assets"
+	"github.com/automoto/doomerang/components"
+	cfg "github.com/automoto/doomerang/config"
+	"github.com/automoto/doomerang/tags"
+	"github.com/yohamta/donburi/ecs"
+)
+
+// Replay files are a small header followed by run-length encoded action masks.
+// Gameplay is deterministic given the same start state and per-tick actions:
+// procgen and music selection derive all randomness from the run seed, and no
+// system iterates a map in an order that affects simulation results.
+const (
+	replayMagic   = "DMRP"
+	replayVersion = 5 // v2 adds the roguelite run length and biome, v3 free aim, v4 the level pack
+
+	// Roguelite replays older than this were recorded on runs the chunk fixes
+	// and the heavy reward have since changed, and would desync
+	minRogueliteReplayVersion = 5
+
+	// Free aim is stored in the top byte of a frame, with a flag bit below it
+	replayAimFlag  = 1 << 23
+	replayAimShift = 24
+
+	maxReplayBiomeLen = 64
+	maxReplayPackLen  = 255
+	maxReplayFrames   = 60 * 60 * 60 * 4 // Four hours at 60 ticks a second
+)
+
+// activeRecording is the replay currently being recorded, if any.
+// Kept at package level so it can be flushed to disk on scene change or exit.
+var activeRecording *components.ReplayData
+
+// StartReplayRecording begins recording input for the scene in e.
+// The start state (level, checkpoint, player lives/health) is captured from the
+// world, so call this after the level and player have been created. The
+// returned replay can be amended with start state the world doesn't hold.
+func StartReplayRecording(e *ecs.ECS, mode components.ReplayMode, seed int64) *components.ReplayData {
+	replay := &components.ReplayData{
+		Mode:         mode,
+		Seed:         seed,
+		CheckpointID: -1,
+		LevelPack:    activeLevelPackID(),
+	}
+
+	if levelEntry, ok := components.Level.First(e.World); ok {
+		levelData := components.Level.Get(levelEntry)
+		replay.LevelIndex = levelData.LevelIndex
+		if levelData.ActiveCheckpoint != nil {
+			replay.CheckpointID = levelData.ActiveCheckpoint.CheckpointID
+			replay.SpawnX = levelData.ActiveCheckpoint.SpawnX
+			replay.SpawnY = levelData.ActiveCheckpoint.SpawnY
+		}
+	}
+
+	if playerEntry, ok := tags.Player.First(e.World); ok {
+		replay.Lives = components.Lives.Get(playerEntry).Lives
+		replay.Health = components.Health.Get(playerEntry).Current
+	}
+
+	state := getOrCreateReplayState(e)
+	state.Replay = replay
+	state.Recording = true
+	activeRecording = replay
+	return replay
+}
+
+// StartReplayPlayback makes UpdateInput feed the replay's recorded actions
+// instead of reading the keyboard/gamepad.
+func StartReplayPlayback(e *ecs.ECS, replay *components.ReplayData) {
+	state := getOrCreateReplayState(e)
+	state.Replay = replay
+	state.Recording = false
+	state.Tick = 0
+}
+
+// FlushReplayRecording writes the active recording to cfg.Debug.RecordReplayPath
+// and stops recording. Safe to call when nothing is being recorded.
+func FlushReplayRecording() {
+	if activeRecording == nil || cfg.Debug.RecordReplayPath == "" {
+		return
+	}
+	replay := activeRecording
+	activeRecording = nil
+
+	if err := SaveReplayFile(cfg.Debug.RecordReplayPath, replay); err != nil {
+		log.Printf("Warning: Could not save replay: %v", err)
+		return
+	}
+	log.Printf("Replay saved to %s (%d ticks)", cfg.Debug.RecordReplayPath, len(replay.Frames))
+}
+
+// ApplyReplayInput feeds the current tick's actions during playback.
+// Returns true if the input came from a replay and live devices should be ignored.
+// UpdateInput calls it, as does the headless sim in place of device polling.
+func ApplyReplayInput(e *ecs.ECS, input *components.InputData) bool {
+	entry, ok := components.ReplayState.First(e.World)
+	if !ok {
+		return false
+	}
+	state := components.ReplayState.Get(entry)
+	if state.Replay == nil || state.Recording || state.Finished {
+		return false
+	}
+
+	if state.Tick >= len(state.Replay.Frames) {
+		state.Finished = true
+		log.Printf("Replay finished after %d ticks", state.Tick)
+		return false
+	}
+
+	frame := state.Replay.Frames[state.Tick]
+	input.Current = unpackActions(frame)
+	input.Aiming = frame&replayAimFlag != 0
+	input.AimStep = int(frame >> replayAimShift)
+	state.Tick++
+	return true
+}
+
+// RecordReplayInput appends the current tick's actions to an active recording
+func RecordReplayInput(e *ecs.ECS, input *components.InputData) {
+	entry, ok := components.ReplayState.First(e.World)
+	if !ok {
+		return
+	}
+	state := components.ReplayState.Get(entry)
+	if state.Replay == nil || !state.Recording {
+		return
+	}
+	frame := packActions(input.Current)
+	if input.Aiming {
+		frame |= replayAimFlag | uint32(input.AimStep)<<replayAimShift
+	}
+	state.Replay.Frames = append(state.Replay.Frames, frame)
+}
+
+// isReplayPlayback reports whether the scene in e is playing back a replay
+func isReplayPlayback(e *ecs.ECS) bool {
+	entry, ok := components.ReplayState.First(e.World)
+	if !ok {
+		return false
+	}
+	state := components.ReplayState.Get(entry)
+	return state.Replay != nil && !state.Recording
+}
+
+func getOrCreateReplayState(e *ecs.ECS) *components.ReplayStateData {
+	if _, ok := components.ReplayState.First(e.World); !ok {
+		ent := e.World.Entry(e.World.Create(components.ReplayState))
+		components.ReplayState.SetValue(ent, components.ReplayStateData{})
+	}
+	ent, _ := components.ReplayState.First(e.World)
+	return components.ReplayState.Get(ent)
+}
+
+func packActions(actions [cfg.ActionCount]bool) uint32 {
+	var mask uint32
+	for i, pressed := range actions {
+		if pressed {
+			mask |= 1 << uint(i)
+		}
+	}
+	return mask
+}
+
+func unpackActions(mask uint32) [cfg.ActionCount]bool {
+	var actions [cfg.ActionCount]bool
+	for i := range actions {
+		actions[i] = mask&(1<<uint(i)) != 0
+	}
+	return actions
+}
+
+// EncodeReplay writes a replay in the compact binary format.
+func EncodeReplay(w io.Writer, replay *components.ReplayData) error {
+	bw := bufio.NewWriter(w)
+	buf := make([]byte, binary.MaxVarintLen64)
+
+	putUvarint := func(v uint64) {
+		n := binary.PutUvarint(buf, v)
+		_, _ = bw.Write(buf[:n])
+	}
+	putVarint := func(v int64) {
+		n := binary.PutVarint(buf, v)
+		_, _ = bw.Write(buf[:n])
+	}
+	putFloat := func(v float64) {
+		binary.LittleEndian.PutUint64(buf, math.Float64bits(v))
+		_, _ = bw.Write(buf[:8])
+	}
+
+	_, _ = bw.WriteString(replayMagic)
+	_ = bw.WriteByte(replayVersion)
+	_ = bw.WriteByte(byte(replay.Mode))
+	putVarint(replay.Seed)
+	putVarint(int64(replay.RunLength))
+	putUvarint(uint64(len(replay.Biome)))
+	_, _ = bw.WriteString(replay.Biome)
+	putVarint(int64(replay.LevelIndex))
+	putFloat(replay.CheckpointID)
+	putFloat(replay.SpawnX)
+	putFloat(replay.SpawnY)
+	putVarint(int64(replay.Lives))
+	putVarint(int64(replay.Health))
+	putUvarint(uint64(len(replay.LevelPack)))
+	_, _ = bw.WriteString(replay.LevelPack)
+
+	// Run-length encode: held inputs repeat the same mask for many ticks
+	type run struct {
+		mask   uint32
+		length uint64
+	}
+	var runs []run
+	for _, mask := range replay.Frames {
+		if len(runs) > 0 && runs[len(runs)-1].mask == mask {
+			runs[len(runs)-1].length++
+			continue
+		}
+		runs = append(runs, run{mask: mask, length: 1})
+	}
+
+	putUvarint(uint64(len(runs)))
+	for _, r := range runs {
+		putUvarint(uint64(r.mask))
+		putUvarint(r.length)
+	}
+
+	return bw.Flush()
+}
+
+// DecodeReplay reads a replay written by EncodeReplay.
+func DecodeReplay(r io.Reader) (*components.ReplayData, error) {
+	br := bufio.NewReader(r)
+
+	magic := make([]byte, len(replayMagic))
+	if _, err := io.ReadFull(br, magic); err != nil {
+		return nil, err
+	}
+	if string(magic) != replayMagic {
+		return nil, errors.New("not a replay file")
+	}
+
+	version, err := br.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+	if version < 1 || version > replayVersion {
+		return nil, fmt.Errorf("unsupported replay version %d", version)
+	}
+
+	mode, err := br.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+	if components.ReplayMode(mode) == components.ReplayRoguelite && version < minRogueliteReplayVersion {
+		return nil, fmt.Errorf("roguelite replay version %d is from an older run generator", version)
+	}
+
+	// Header fields are read in order; the first error stops all further reads
+	var readErr error
+	varint := func() int64 {
+		if readErr != nil {
+			return 0
+		}
+		v, err := binary.ReadVarint(br)
+		readErr = err
+		return v
+	}
+	uvarint := func() uint64 {
+		if readErr != nil {
+			return 0
+		}
+		v, err := binary.ReadUvarint(br)
+		readErr = err
+		return v
+	}
+	float := func() float64 {
+		if readErr != nil {
+			return 0
+		}
+		var b [8]byte
+		_, readErr = io.ReadFull(br, b[:])
+		return math.Float64frombits(binary.LittleEndian.Uint64(b[:]))
+	}
+	str := func(what string, maxLen uint64) string {
+		n := uvarint()
+		if readErr != nil {
+			return ""
+		}
+		if n > maxLen {
+			readErr = fmt.Errorf("%s of %d bytes", what, n)
+			return ""
+		}
+		b := make([]byte, n)
+		_, readErr = io.ReadFull(br, b)
+		return string(b)
+	}
+
+	replay := &components.ReplayData{Mode: components.ReplayMode(mode)}
+	replay.Seed = varint()
+	if version >= 2 {
+		replay.RunLength = int(varint())
+		replay.Biome = str("biome name", maxReplayBiomeLen)
+	}
+	replay.LevelIndex = int(varint())
+	replay.CheckpointID = float()
+	replay.SpawnX = float()
+	replay.SpawnY = float()
+	replay.Lives = int(varint())
+	replay.Health = int(varint())
+	if version >= 4 {
+		replay.LevelPack = str("level pack ID", maxReplayPackLen)
+	}
+
+	numRuns := uvarint()
+	for i := uint64(0); i < numRuns && readErr == nil; i++ {
+		mask := uint32(uvarint())
+		length := uvarint()
+		if readErr == nil && length > maxReplayFrames-uint64(len(replay.Frames)) {
+			readErr = fmt.Errorf("more than %d frames", maxReplayFrames)
+		}
+		for j := uint64(0); j < length && readErr == nil; j++ {
+			replay.Frames = append(replay.Frames, mask)
+		}
+	}
+	if readErr != nil {
+		return nil, fmt.Errorf("corrupt replay: %w", readErr)
+	}
+
+	return replay, nil
+}
+
+// SaveReplayFile writes a replay to path.
+func SaveReplayFile(path string, replay *components.ReplayData) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := EncodeReplay(f, replay); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// LoadReplayFile reads a replay from path. A replay recorded with another
+// level pack than the active one is refused, since its levels would differ.
+func LoadReplayFile(path string) (*components.ReplayData, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	replay, err := DecodeReplay(f)
+	if err != nil {
+		return nil, err
+	}
+	if active := activeLevelPackID(); replay.LevelPack != active {
+		return nil, fmt.Errorf("recorded with level pack %q, but %q is active", replay.LevelPack, active)
+	}
+	return replay, nil
+}
+
+// activeLevelPackID returns the ID of the active level pack, or "" for the
+// built-in content
+func activeLevelPackID() string {
+	if pack := assets.ActiveLevelPack(); pack != nil {
+		return pack.ID
+	}
+	return ""
+}
@@ -0,0 +1,178 @@
+package systems_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"path/filepath"
+	"testing"
+
+	"github.com/automoto/doomerang/assets"
+	"github.com/automoto/doomerang/components"
+	cfg "github.com/automoto/doomerang/config"
+	"github.com/automoto/doomerang/systems"
+	"github.com/yohamta/donburi/ecs"
+)
+
+func TestReplayEncodeDecodeRoundTrip(t *testing.T) {
+	original := &components.ReplayData{
+		Mode:         components.ReplayRoguelite,
+		Seed:         -1234567890123,
+		RunLength:    15,
+		Biome:        "neon",
+		LevelIndex:   2,
+		CheckpointID: 1.5,
+		SpawnX:       320.25,
+		SpawnY:       96,
+		Lives:        2,
+		Health:       75,
+		LevelPack:    "neon-city",
+		Frames:       []uint32{0, 0, 0, 1 << uint(cfg.ActionJump), 1 << uint(cfg.ActionJump), 0, 1<<uint(cfg.ActionMoveRight) | 1<<uint(cfg.ActionAttack)},
+	}
+
+	var buf bytes.Buffer
+	if err := systems.EncodeReplay(&buf, original); err != nil {
+		t.Fatalf("EncodeReplay failed: %v", err)
+	}
+
+	decoded, err := systems.DecodeReplay(&buf)
+	if err != nil {
+		t.Fatalf("DecodeReplay failed: %v", err)
+	}
+
+	if decoded.Mode != original.Mode || decoded.Seed != original.Seed || decoded.LevelIndex != original.LevelIndex {
+		t.Errorf("header mismatch: got %+v", decoded)
+	}
+	if decoded.RunLength != original.RunLength || decoded.Biome != original.Biome {
+		t.Errorf("run options mismatch: got length=%d biome=%q", decoded.RunLength, decoded.Biome)
+	}
+	if decoded.CheckpointID != original.CheckpointID || decoded.SpawnX != original.SpawnX || decoded.SpawnY != original.SpawnY {
+		t.Errorf("spawn mismatch: got checkpoint=%v spawn=(%v,%v)", decoded.CheckpointID, decoded.SpawnX, decoded.SpawnY)
+	}
+	if decoded.Lives != original.Lives || decoded.Health != original.Health {
+		t.Errorf("player state mismatch: got lives=%d health=%d", decoded.Lives, decoded.Health)
+	}
+	if decoded.LevelPack != original.LevelPack {
+		t.Errorf("expected level pack %q, got %q", original.LevelPack, decoded.LevelPack)
+	}
+	if len(decoded.Frames) != len(original.Frames) {
+		t.Fatalf("expected %d frames, got %d", len(original.Frames), len(decoded.Frames))
+	}
+	for i := range original.Frames {
+		if decoded.Frames[i] != original.Frames[i] {
+			t.Errorf("frame %d: expected %b, got %b", i, original.Frames[i], decoded.Frames[i])
+		}
+	}
+}
+
+func TestDecodeReplayRejectsGarbage(t *testing.T) {
+	if _, err := systems.DecodeReplay(bytes.NewReader([]byte("not a replay"))); err == nil {
+		t.Error("expected error for non-replay data")
+	}
+}
+
+func TestDecodeReplayRejectsHugeFrameCount(t *testing.T) {
+	var buf bytes.Buffer
+	if err := systems.EncodeReplay(&buf, &components.ReplayData{Frames: []uint32{0}}); err != nil {
+		t.Fatalf("encode: %v", err)
+	}
+
+	// The last byte is the length of the single run; swap it for ~10^12 ticks
+	data := buf.Bytes()[:buf.Len()-1]
+	data = binary.AppendUvarint(data, 1<<40)
+
+	if _, err := systems.DecodeReplay(bytes.NewReader(data)); err == nil {
+		t.Error("expected error for a replay with an absurd frame count")
+	}
+}
+
+func TestDecodeReplayRefusesOldRogueliteReplays(t *testing.T) {
+	// Version 4 files have the same layout; only the version byte differs
+	asVersion4 := func(replay *components.ReplayData) []byte {
+		var buf bytes.Buffer
+		if err := systems.EncodeReplay(&buf, replay); err != nil {
+			t.Fatalf("EncodeReplay failed: %v", err)
+		}
+		data := buf.Bytes()
+		data[len("DMRP")] = 4
+		return data
+	}
+
+	roguelite := asVersion4(&components.ReplayData{Mode: components.ReplayRoguelite, Seed: 42, Frames: []uint32{0}})
+	if _, err := systems.DecodeReplay(bytes.NewReader(roguelite)); err == nil {
+		t.Error("expected a roguelite replay from before the generator changes to be refused")
+	}
+	campaign := asVersion4(&components.ReplayData{Mode: components.ReplayCampaign, Frames: []uint32{0}})
+	if _, err := systems.DecodeReplay(bytes.NewReader(campaign)); err != nil {
+		t.Errorf("expected an older campaign replay to still load, got %v", err)
+	}
+}
+
+func TestLoadReplayFileRefusesOtherLevelPack(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "run.dmrp")
+	if err := systems.SaveReplayFile(path, &components.ReplayData{LevelPack: "neon-city"}); err != nil {
+		t.Fatalf("SaveReplayFile failed: %v", err)
+	}
+
+	if _, err := systems.LoadReplayFile(path); err == nil {
+		t.Error("expected a replay from another level pack to be refused")
+	}
+
+	assets.UseLevelPack(&assets.LevelPack{ID: "neon-city", Levels: 1})
+	defer assets.UseLevelPack(nil)
+	if _, err := systems.LoadReplayFile(path); err != nil {
+		t.Errorf("expected the replay to load with its level pack active, got %v", err)
+	}
+}
+
+func TestReplayPlaybackFeedsInput(t *testing.T) {
+	e := newTestECS()
+	replay := &components.ReplayData{
+		Frames: []uint32{1 << uint(cfg.ActionJump), 1 << uint(cfg.ActionJump), 0},
+	}
+	systems.StartReplayPlayback(e, replay)
+
+	systems.UpdateInput(e)
+	input := getInput(t, e)
+	if !systems.GetAction(input, cfg.ActionJump).JustPressed {
+		t.Error("expected jump to be just pressed on tick 1")
+	}
+
+	systems.UpdateInput(e)
+	if state := systems.GetAction(input, cfg.ActionJump); !state.Pressed || state.JustPressed {
+		t.Errorf("expected jump held on tick 2, got %+v", state)
+	}
+
+	systems.UpdateInput(e)
+	if !systems.GetAction(input, cfg.ActionJump).JustReleased {
+		t.Error("expected jump to be just released on tick 3")
+	}
+}
+
+func TestReplayPlaybackFeedsAim(t *testing.T) {
+	e := newTestECS()
+	// Free aim rides in the top byte of a frame, flagged by bit 23
+	replay := &components.ReplayData{
+		Frames: []uint32{1<<23 | 160<<24 | 1<<uint(cfg.ActionBoomerang), 1 << uint(cfg.ActionBoomerang)},
+	}
+	systems.StartReplayPlayback(e, replay)
+
+	systems.UpdateInput(e)
+	input := getInput(t, e)
+	if !input.Aiming || input.AimStep != 160 {
+		t.Errorf("expected free aim at step 160 on tick 1, got aiming=%v step=%d", input.Aiming, input.AimStep)
+	}
+
+	systems.UpdateInput(e)
+	if input.Aiming {
+		t.Error("expected no free aim on tick 2")
+	}
+}
+
+func getInput(t *testing.T, e *ecs.ECS) *components.InputData {
+	t.Helper()
+	entry, ok := components.Input.First(e.World)
+	if !ok {
+		t.Fatal("expected input component to exist")
+	}
+	return components.Input.Get(entry)
+}
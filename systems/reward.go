@@ -0,0 +1,69 @@
+package systems
+
+import (
+	"github.com/automoto/doomerang/components"
+	cfg "github.com/automoto/doomerang/config"
+	"github.com/automoto/doomerang/tags"
+	"github.com/yohamta/donburi"
+	"github.com/yohamta/donburi/ecs"
+)
+
+// UpdateRewards applies and removes reward pickups the player touches
+func UpdateRewards(ecs *ecs.ECS) {
+	playerEntry, ok := tags.Player.First(ecs.World)
+	if !ok {
+		return
+	}
+
+	playerObj := components.Object.Get(playerEntry)
+	check := playerObj.Check(0, 0, tags.ResolvReward)
+	if check == nil {
+		return
+	}
+
+	for _, rewardObj := range check.ObjectsByTags(tags.ResolvReward) {
+		rewardEntry, ok := rewardObj.Data.(*donburi.Entry)
+		if !ok || rewardEntry == nil || !rewardEntry.Valid() {
+			continue
+		}
+
+		reward := components.Reward.Get(rewardEntry)
+		switch mod := components.BoomerangModifier(reward.RewardType); {
+		case reward.RewardType == "health":
+			restoreHealth(playerEntry)
+		case mod.Label() != "":
+			addBoomerangModifier(ecs, playerEntry, mod)
+		}
+		PlaySFX(ecs, cfg.SoundRewardPickup)
+
+		if spaceEntry, ok := components.Space.First(ecs.World); ok {
+			components.Space.Get(spaceEntry).Remove(rewardObj)
+		}
+		ecs.World.Remove(rewardEntry.Entity())
+	}
+}
+
+func restoreHealth(playerEntry *donburi.Entry) {
+	health := components.Health.Get(playerEntry)
+	health.Current = health.Max
+}
+
+// addBoomerangModifier gives the player another stack of a run modifier. One
+// already at its most stacks restores health instead, so the pickup isn't wasted.
+func addBoomerangModifier(ecs *ecs.ECS, playerEntry *donburi.Entry, mod components.BoomerangModifier) {
+	player := components.Player.Get(playerEntry)
+	if components.ModifierStacks(player.Modifiers)[mod] >= cfg.BoomerangModifier.MaxStacks {
+		restoreHealth(playerEntry)
+		return
+	}
+	player.Modifiers = append(player.Modifiers, mod)
+
+	if entry, ok := components.RunStats.First(ecs.World); ok {
+		stats := components.RunStats.Get(entry)
+		stats.Modifiers = append(stats.Modifiers, mod)
+	}
+	publishGameplayEvent(ecs, playerEntry, components.GameplayEventData{
+		Kind:   components.EventModifierPickup,
+		Detail: string(mod),
+	})
+}
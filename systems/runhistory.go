@@ -0,0 +1,390 @@
+package systems
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/automoto/doomerang/components"
+	cfg "github.com/automoto/doomerang/config"
+	"github.com/automoto/doomerang/fonts"
+	"github.com/hajimehoshi/ebiten/v2"
+	textv2 "github.com/hajimehoshi/ebiten/v2/text/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/yohamta/donburi/ecs"
+)
+
+// activeRun is the roguelite run being written to the run history, if any.
+// Kept at package level so a run still in progress can be saved as abandoned
+// when the game exits.
+var activeRun struct {
+	ecs  *ecs.ECS
+	id   int64
+	date string
+}
+
+// StartRunHistory begins recording the roguelite run in e. The run is saved as
+// abandoned straight away and updated when it ends, so a crash or quit still
+// leaves a record. Call this after the RunStats entity has been created.
+func StartRunHistory(e *ecs.ECS) {
+	now := time.Now()
+	activeRun.ecs = e
+	activeRun.id = now.UnixNano()
+	activeRun.date = now.Format("2006-01-02 15:04")
+	saveActiveRun(RunAbandoned)
+}
+
+// FinishRunHistory saves how the run in e ended and stops recording it.
+func FinishRunHistory(e *ecs.ECS, outcome RunOutcome) {
+	if activeRun.ecs == nil || activeRun.ecs != e {
+		return
+	}
+	saveActiveRun(outcome)
+	activeRun.ecs = nil
+}
+
+// FlushRunHistory saves the run in progress, if any, as abandoned.
+// Safe to call when no run is being recorded.
+func FlushRunHistory() {
+	if activeRun.ecs == nil {
+		return
+	}
+	saveActiveRun(RunAbandoned)
+	activeRun.ecs = nil
+}
+
+func saveActiveRun(outcome RunOutcome) {
+	record := NewRunRecord(SnapshotRunStats(activeRun.ecs), outcome)
+	record.ID = activeRun.id
+	record.Date = activeRun.date
+	if err := SaveRunRecord(record); err != nil {
+		log.Printf("Warning: Could not save run history: %v", err)
+	}
+}
+
+// NewRunRecord converts a run's final stats into a run history record.
+// ID and Date are left for the caller to fill in.
+func NewRunRecord(stats FinalRunStats, outcome RunOutcome) SavedRunRecord {
+	return SavedRunRecord{
+		Seed:         stats.Seed,
+		SeedCode:     stats.SeedCode,
+		DailyDate:    stats.DailyDate,
+		Outcome:      outcome,
+		RoomsCleared: stats.RoomsCleared,
+		TotalRooms:   stats.TotalRooms,
+		KillCount:    stats.KillCount,
+		Deaths:       stats.Deaths,
+		ElapsedSecs:  stats.ElapsedSecs,
+		CauseOfDeath: stats.CauseOfDeath,
+		DeathChunk:   stats.DeathChunk,
+		Chunks:       stats.Chunks,
+	}
+}
+
+// SortRunHistory returns a copy of records in the given order. Ties, and the
+// newest order itself, put the most recent run first; the fastest order lists
+// cleared runs before unfinished ones.
+func SortRunHistory(records []SavedRunRecord, by components.RunHistorySort) []SavedRunRecord {
+	sorted := append([]SavedRunRecord(nil), records...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := sorted[i], sorted[j]
+		switch by {
+		case components.RunHistoryMostRooms:
+			if a.RoomsCleared != b.RoomsCleared {
+				return a.RoomsCleared > b.RoomsCleared
+			}
+		case components.RunHistoryMostKills:
+			if a.KillCount != b.KillCount {
+				return a.KillCount > b.KillCount
+			}
+		case components.RunHistoryFastest:
+			if aCleared, bCleared := a.Outcome == RunCleared, b.Outcome == RunCleared; aCleared != bCleared {
+				return aCleared
+			}
+			if a.ElapsedSecs != b.ElapsedSecs {
+				return a.ElapsedSecs < b.ElapsedSecs
+			}
+		}
+		return a.ID > b.ID
+	})
+	return sorted
+}
+
+// runHistoryView caches the sorted run list between frames
+type runHistoryView struct {
+	records []SavedRunRecord
+	sorted  []SavedRunRecord
+	by      components.RunHistorySort
+}
+
+func (v *runHistoryView) get(by components.RunHistorySort) []SavedRunRecord {
+	if v.sorted == nil || v.by != by {
+		v.sorted = SortRunHistory(v.records, by)
+		v.by = by
+	}
+	return v.sorted
+}
+
+// GetOrCreateRunHistory returns the singleton RunHistory component, creating if needed
+func GetOrCreateRunHistory(e *ecs.ECS) *components.RunHistoryData {
+	if _, ok := components.RunHistory.First(e.World); !ok {
+		ent := e.World.Entry(e.World.Create(components.RunHistory))
+		components.RunHistory.SetValue(ent, components.RunHistoryData{})
+	}
+
+	ent, _ := components.RunHistory.First(e.World)
+	return components.RunHistory.Get(ent)
+}
+
+// NewUpdateRunHistory creates the update system for the run history screen.
+// Up/down pick a run, left/right change the sort order and select opens the
+// run's details; selecting again from the details plays the run's seed.
+func NewUpdateRunHistory(
+	sceneChanger SceneChanger,
+	records []SavedRunRecord,
+	createRunScene func(record SavedRunRecord) interface{},
+	createMenuScene func() interface{},
+) ecs.System {
+	view := &runHistoryView{records: records}
+	// Skip the first frame so the confirm press that opened this screen
+	// from the main menu doesn't immediately open the first run.
+	firstFrame := true
+	return func(e *ecs.ECS) {
+		if firstFrame {
+			firstFrame = false
+			return
+		}
+
+		history := GetOrCreateRunHistory(e)
+		input := getOrCreateInput(e)
+		sorted := view.get(history.Sort)
+
+		if history.ShowingDetail {
+			if GetAction(input, cfg.ActionMenuBack).JustPressed {
+				PlaySFX(e, cfg.SoundMenuNavigate)
+				history.ShowingDetail = false
+				return
+			}
+			if GetAction(input, cfg.ActionMenuSelect).JustPressed {
+				PlaySFX(e, cfg.SoundMenuSelect)
+				FadeOutMusic(e)
+				sceneChanger.ChangeScene(createRunScene(sorted[history.SelectedIndex]))
+			}
+			return
+		}
+
+		if GetAction(input, cfg.ActionMenuBack).JustPressed {
+			PlaySFX(e, cfg.SoundMenuNavigate)
+			sceneChanger.ChangeScene(createMenuScene())
+			return
+		}
+
+		numSorts := int(components.RunHistorySortCount)
+		if GetAction(input, cfg.ActionMenuLeft).JustPressed {
+			PlaySFX(e, cfg.SoundMenuNavigate)
+			history.Sort = components.RunHistorySort((int(history.Sort) - 1 + numSorts) % numSorts)
+			history.SelectedIndex = 0
+		}
+		if GetAction(input, cfg.ActionMenuRight).JustPressed {
+			PlaySFX(e, cfg.SoundMenuNavigate)
+			history.Sort = components.RunHistorySort((int(history.Sort) + 1) % numSorts)
+			history.SelectedIndex = 0
+		}
+
+		numRecords := len(sorted)
+		if numRecords == 0 {
+			return
+		}
+
+		if GetAction(input, cfg.ActionMenuUp).JustPressed {
+			PlaySFX(e, cfg.SoundMenuNavigate)
+			history.SelectedIndex = (history.SelectedIndex - 1 + numRecords) % numRecords
+		}
+		if GetAction(input, cfg.ActionMenuDown).JustPressed {
+			PlaySFX(e, cfg.SoundMenuNavigate)
+			history.SelectedIndex = (history.SelectedIndex + 1) % numRecords
+		}
+
+		if GetAction(input, cfg.ActionMenuSelect).JustPressed {
+			PlaySFX(e, cfg.SoundMenuSelect)
+			history.ShowingDetail = true
+		}
+	}
+}
+
+// DrawRunHistory returns a renderer for the run history screen.
+func DrawRunHistory(records []SavedRunRecord) func(*ecs.ECS, *ebiten.Image) {
+	view := &runHistoryView{records: records}
+	return func(e *ecs.ECS, screen *ebiten.Image) {
+		history := GetOrCreateRunHistory(e)
+		sorted := view.get(history.Sort)
+		width := float64(screen.Bounds().Dx())
+		height := float64(screen.Bounds().Dy())
+
+		vector.FillRect(
+			screen,
+			0, 0,
+			float32(width), float32(height),
+			cfg.RunHistory.BackgroundColor,
+			false,
+		)
+
+		titleFont := fonts.ExcelTitle.GetV2()
+		titleX := centerTextX(cfg.RunHistory.Title, titleFont, width)
+		drawText(screen, cfg.RunHistory.Title, titleFont, titleX, int(cfg.RunHistory.TitleY), cfg.RunHistory.TitleColor)
+
+		rowFont := fonts.ExcelBold.GetV2()
+		hintFont := fonts.ExcelSmall.GetV2()
+		input := getOrCreateInput(e)
+
+		if history.ShowingDetail && history.SelectedIndex < len(sorted) {
+			drawRunDetail(screen, sorted[history.SelectedIndex], rowFont, hintFont)
+			hint := getRunDetailHint(input.LastInputMethod)
+			drawText(screen, hint, hintFont, centerTextX(hint, hintFont, width), int(height)-12, cfg.RunHistory.TextColorNormal)
+			return
+		}
+
+		sortLabel := "< Sort: " + getRunHistorySortLabel(history.Sort) + " >"
+		drawText(screen, sortLabel, hintFont, centerTextX(sortLabel, hintFont, width), int(cfg.RunHistory.SortY), cfg.RunHistory.LabelColor)
+
+		if len(sorted) == 0 {
+			empty := "No runs played yet"
+			drawText(screen, empty, rowFont, centerTextX(empty, rowFont, width), int(cfg.RunHistory.ListStartY), cfg.RunHistory.TextColorNormal)
+		}
+
+		// Scroll so the selected row stays within the visible window
+		first := 0
+		if visible := cfg.RunHistory.VisibleRows; history.SelectedIndex >= visible {
+			first = history.SelectedIndex - visible + 1
+		}
+
+		for i := first; i < len(sorted) && i < first+cfg.RunHistory.VisibleRows; i++ {
+			run := sorted[i]
+			y := int(cfg.RunHistory.ListStartY) + (i-first)*int(cfg.RunHistory.RowHeight)
+
+			textColor := cfg.RunHistory.TextColorNormal
+			if i == history.SelectedIndex {
+				textColor = cfg.RunHistory.TextColorSelected
+			}
+			outcomeColor := cfg.RunHistory.FailedColor
+			if run.Outcome == RunCleared {
+				outcomeColor = cfg.RunHistory.ClearedColor
+			}
+
+			drawText(screen, run.Date, rowFont, int(cfg.RunHistory.DateX), y, textColor)
+			drawText(screen, getRunOutcomeLabel(run.Outcome), rowFont, int(cfg.RunHistory.OutcomeX), y, outcomeColor)
+			drawText(screen, fmt.Sprintf("%d/%d rooms", run.RoomsCleared, run.TotalRooms), rowFont, int(cfg.RunHistory.RoomsX), y, textColor)
+			drawText(screen, fmt.Sprintf("%d kills", run.KillCount), rowFont, int(cfg.RunHistory.KillsX), y, textColor)
+			drawText(screen, fmt.Sprintf("%dm %02ds", run.ElapsedSecs/60, run.ElapsedSecs%60), rowFont, int(cfg.RunHistory.TimeX), y, textColor)
+		}
+
+		hint := getRunHistoryHint(input.LastInputMethod)
+		drawText(screen, hint, hintFont, centerTextX(hint, hintFont, width), int(height)-12, cfg.RunHistory.TextColorNormal)
+	}
+}
+
+// drawRunDetail renders every saved field of one run; the room list uses the
+// smaller font so a full-length run still fits on screen
+func drawRunDetail(screen *ebiten.Image, run SavedRunRecord, font, smallFont *textv2.GoXFace) {
+	type detailRow struct{ label, value string }
+	rows := []detailRow{
+		{"Date", run.Date},
+		{"Seed", run.SeedCode},
+		{"Outcome", getRunOutcomeLabel(run.Outcome)},
+		{"Rooms", fmt.Sprintf("%d / %d", run.RoomsCleared, run.TotalRooms)},
+		{"Kills", fmt.Sprintf("%d", run.KillCount)},
+		{"Time", fmt.Sprintf("%dm %02ds", run.ElapsedSecs/60, run.ElapsedSecs%60)},
+	}
+	if run.DailyDate != "" {
+		rows = append(rows, detailRow{"Daily", run.DailyDate})
+	}
+	if run.Deaths > 0 {
+		rows = append(rows, detailRow{"Deaths", fmt.Sprintf("%d, last to %s in %s", run.Deaths, run.CauseOfDeath, run.DeathChunk)})
+	}
+
+	y := int(cfg.RunHistory.DetailStartY)
+	for _, row := range rows {
+		drawText(screen, row.label, font, int(cfg.RunHistory.DetailLabelX), y, cfg.RunHistory.LabelColor)
+		drawText(screen, row.value, font, int(cfg.RunHistory.DetailValueX), y, cfg.RunHistory.TextColorNormal)
+		y += int(cfg.RunHistory.DetailRowHeight)
+	}
+
+	drawText(screen, "Rooms", font, int(cfg.RunHistory.DetailLabelX), y, cfg.RunHistory.LabelColor)
+	for _, line := range wrapWords(run.Chunks, smallFont, cfg.RunHistory.DetailChunksWidth) {
+		drawText(screen, line, smallFont, int(cfg.RunHistory.DetailValueX), y, cfg.RunHistory.TextColorNormal)
+		y += int(cfg.RunHistory.ChunkLineHeight)
+	}
+}
+
+// wrapWords joins words with ", " into lines no wider than maxWidth
+func wrapWords(words []string, font *textv2.GoXFace, maxWidth float64) []string {
+	var lines []string
+	var line strings.Builder
+	for i, word := range words {
+		if i < len(words)-1 {
+			word += ","
+		}
+		if line.Len() > 0 && float64(measureTextWidth(line.String()+" "+word, font)) > maxWidth {
+			lines = append(lines, line.String())
+			line.Reset()
+		}
+		if line.Len() > 0 {
+			line.WriteString(" ")
+		}
+		line.WriteString(word)
+	}
+	if line.Len() > 0 {
+		lines = append(lines, line.String())
+	}
+	return lines
+}
+
+// getRunOutcomeLabel returns the display text for a run outcome
+func getRunOutcomeLabel(outcome RunOutcome) string {
+	switch outcome {
+	case RunCleared:
+		return "Cleared"
+	case RunDied:
+		return "Died"
+	default:
+		return "Abandoned"
+	}
+}
+
+// getRunHistorySortLabel returns the display text for a sort order
+func getRunHistorySortLabel(by components.RunHistorySort) string {
+	switch by {
+	case components.RunHistoryMostRooms:
+		return "Most Rooms"
+	case components.RunHistoryMostKills:
+		return "Most Kills"
+	case components.RunHistoryFastest:
+		return "Fastest Clear"
+	default:
+		return "Newest"
+	}
+}
+
+// getRunHistoryHint returns the appropriate hint for run history navigation
+func getRunHistoryHint(method components.InputMethod) string {
+	switch method {
+	case components.InputPlayStation:
+		return "D-Pad: Navigate/Sort   Cross: Details   Circle: Back"
+	case components.InputXbox:
+		return "D-Pad: Navigate/Sort   A: Details   B: Back"
+	}
+	return "Up/Down: Navigate   Left/Right: Sort   Enter: Details   Esc: Back"
+}
+
+// getRunDetailHint returns the appropriate hint for the run detail view
+func getRunDetailHint(method components.InputMethod) string {
+	switch method {
+	case components.InputPlayStation:
+		return "Cross: Play This Seed   Circle: Back"
+	case components.InputXbox:
+		return "A: Play This Seed   B: Back"
+	}
+	return "Enter: Play This Seed   Esc: Back"
+}
@@ -0,0 +1,96 @@
+package systems_test
+
+import (
+	"testing"
+
+	"github.com/automoto/doomerang/components"
+	cfg "github.com/automoto/doomerang/config"
+	"github.com/automoto/doomerang/systems"
+)
+
+func setupRunHistoryPersistence(t *testing.T) {
+	t.Helper()
+	if err := systems.InitPersistence(); err != nil {
+		t.Skipf("persistence not available in this environment: %v", err)
+	}
+	if err := systems.ClearRunHistory(); err != nil {
+		t.Fatalf("failed to clear run history: %v", err)
+	}
+}
+
+func TestSaveRunRecordReplacesSameID(t *testing.T) {
+	setupRunHistoryPersistence(t)
+
+	_ = systems.SaveRunRecord(systems.SavedRunRecord{ID: 1, Outcome: systems.RunAbandoned})
+	_ = systems.SaveRunRecord(systems.SavedRunRecord{ID: 2, Outcome: systems.RunCleared})
+	_ = systems.SaveRunRecord(systems.SavedRunRecord{ID: 1, Outcome: systems.RunDied, RoomsCleared: 3})
+
+	records, _ := systems.LoadRunHistory()
+	if len(records) != 2 {
+		t.Fatalf("expected 2 records, got %d", len(records))
+	}
+	if records[0].ID != 1 || records[0].Outcome != systems.RunDied || records[0].RoomsCleared != 3 {
+		t.Errorf("expected run 1 to be updated in place, got %+v", records[0])
+	}
+}
+
+func TestSaveRunRecordKeepsUnreadableHistory(t *testing.T) {
+	setupRunHistoryPersistence(t)
+	defer systems.ClearRunHistory()
+	if err := systems.SaveRawItem("run_history", []byte("[{not json")); err != nil {
+		t.Fatalf("SaveRawItem failed: %v", err)
+	}
+
+	if err := systems.SaveRunRecord(systems.SavedRunRecord{ID: 1, Outcome: systems.RunCleared}); err == nil {
+		t.Error("expected saving to fail when the history can't be read")
+	}
+	if _, err := systems.LoadRunHistory(); err == nil {
+		t.Error("expected the unreadable history to be left alone")
+	}
+}
+
+func TestSaveRunRecordKeepsNewest(t *testing.T) {
+	setupRunHistoryPersistence(t)
+	defer func(n int) { cfg.RunHistory.MaxEntries = n }(cfg.RunHistory.MaxEntries)
+	cfg.RunHistory.MaxEntries = 3
+
+	for id := int64(1); id <= 5; id++ {
+		_ = systems.SaveRunRecord(systems.SavedRunRecord{ID: id})
+	}
+
+	records, _ := systems.LoadRunHistory()
+	if len(records) != 3 || records[0].ID != 3 || records[2].ID != 5 {
+		t.Errorf("expected runs 3-5 to be kept, got %+v", records)
+	}
+}
+
+func TestSortRunHistory(t *testing.T) {
+	records := []systems.SavedRunRecord{
+		{ID: 1, Outcome: systems.RunCleared, RoomsCleared: 10, KillCount: 5, ElapsedSecs: 300},
+		{ID: 2, Outcome: systems.RunDied, RoomsCleared: 4, KillCount: 12, ElapsedSecs: 60},
+		{ID: 3, Outcome: systems.RunCleared, RoomsCleared: 10, KillCount: 8, ElapsedSecs: 200},
+		{ID: 4, Outcome: systems.RunAbandoned, RoomsCleared: 1, KillCount: 0, ElapsedSecs: 20},
+	}
+
+	tests := []struct {
+		by   components.RunHistorySort
+		want []int64
+	}{
+		{components.RunHistoryNewest, []int64{4, 3, 2, 1}},
+		{components.RunHistoryMostRooms, []int64{3, 1, 2, 4}},
+		{components.RunHistoryMostKills, []int64{2, 3, 1, 4}},
+		{components.RunHistoryFastest, []int64{3, 1, 4, 2}},
+	}
+	for _, tt := range tests {
+		got := systems.SortRunHistory(records, tt.by)
+		for i, id := range tt.want {
+			if got[i].ID != id {
+				t.Errorf("sort %d: expected order %v, got run %d at %d", tt.by, tt.want, got[i].ID, i)
+				break
+			}
+		}
+	}
+	if records[0].ID != 1 {
+		t.Error("expected SortRunHistory to leave the input unchanged")
+	}
+}
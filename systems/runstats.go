@@ -2,6 +2,7 @@ package systems
 
 import (
 	"github.com/automoto/doomerang/components"
+	"github.com/automoto/doomerang/procgen"
 	"github.com/automoto/doomerang/tags"
 	"github.com/yohamta/donburi"
 	"github.com/yohamta/donburi/ecs"
@@ -15,6 +16,16 @@ func UpdateRunStats(e *ecs.ECS) {
 	stats := GetOrCreateRunStats(e)
 
 	stats.ElapsedTicks++
+	playerEntry, hasPlayer := tags.Player.First(e.World)
+	hasPlayer = hasPlayer && playerEntry.HasComponent(components.Object)
+	var room *components.RoomStats
+	if hasPlayer {
+		playerObject := components.Object.Get(playerEntry)
+		room = stats.CurrentRoom(playerObject.X, playerObject.Y)
+	}
+	if room != nil {
+		room.Ticks++
+	}
 
 	// Count live enemies for kill delta
 	liveCount := 0
@@ -24,24 +35,75 @@ func UpdateRunStats(e *ecs.ECS) {
 	delta := stats.PrevEnemyCount - liveCount
 	if delta > 0 {
 		stats.KillCount += delta
+		if room != nil {
+			room.Kills += delta
+		}
 	}
 	stats.PrevEnemyCount = liveCount
 
-	// Advance rooms cleared based on player X position
-	playerEntry, ok := tags.Player.First(e.World)
-	if !ok || !playerEntry.HasComponent(components.Object) {
+	// Advance rooms cleared as the player crosses each room's exit
+	if !hasPlayer {
 		return
 	}
-	playerX := components.Object.Get(playerEntry).X
+	playerObject := components.Object.Get(playerEntry)
 	for i := stats.LastRoomIndex; i < len(stats.RoomBoundaries); i++ {
-		if playerX < stats.RoomBoundaries[i] {
+		if !stats.RoomBoundaries[i].Crossed(playerObject.X, playerObject.Y) {
 			break
 		}
 		stats.RoomsCleared = i + 1
 		stats.LastRoomIndex = i + 1
+		if next := i + 1; next < len(stats.Rooms) {
+			publishGameplayEvent(e, playerEntry, components.GameplayEventData{
+				Kind:   components.EventRoomEnter,
+				Amount: next,
+				Detail: stats.Rooms[next].ChunkID,
+			})
+		}
+	}
+
+	// Count optional branch rooms the first time the player enters each one
+	if len(stats.OptionalVisited) != len(stats.OptionalRooms) {
+		stats.OptionalVisited = make([]bool, len(stats.OptionalRooms))
+	}
+	for i, room := range stats.OptionalRooms {
+		if !stats.OptionalVisited[i] && room.Contains(playerObject.X, playerObject.Y) {
+			stats.OptionalVisited[i] = true
+			stats.OptionalRoomsFound++
+		}
 	}
 }
 
+// NewRunStats returns the stats of a fresh run through a generated level,
+// with its rooms laid out for tracking.
+func NewRunStats(result *procgen.GenerationResult) components.RunStatsData {
+	stats := components.RunStatsData{TotalRooms: result.MainPathLength()}
+	for _, b := range result.RoomBoundaries() {
+		edge := components.RoomExitRight
+		switch b.Edge {
+		case procgen.EdgeBottom:
+			edge = components.RoomExitBottom
+		case procgen.EdgeTop:
+			edge = components.RoomExitTop
+		}
+		stats.RoomBoundaries = append(stats.RoomBoundaries, components.RoomBoundary{Edge: edge, Pos: b.Pos})
+	}
+	for _, room := range result.MainPathRooms() {
+		stats.Rooms = append(stats.Rooms, components.RoomStats{ChunkID: room.ChunkID, NodeType: string(room.NodeType)})
+	}
+	for _, area := range result.MainPathRoomAreas() {
+		stats.RoomAreas = append(stats.RoomAreas, roomArea(area))
+	}
+	for _, area := range result.OptionalRooms() {
+		stats.OptionalRooms = append(stats.OptionalRooms, roomArea(area))
+	}
+	return stats
+}
+
+// roomArea converts the area of a generated room for run stats
+func roomArea(area procgen.RoomArea) components.RoomArea {
+	return components.RoomArea{X: area.X, Y: area.Y, Width: area.Width, Height: area.Height}
+}
+
 // GetOrCreateRunStats returns the singleton RunStats component, creating if needed.
 func GetOrCreateRunStats(e *ecs.ECS) *components.RunStatsData {
 	if _, ok := components.RunStats.First(e.World); !ok {
@@ -54,22 +116,56 @@ func GetOrCreateRunStats(e *ecs.ECS) *components.RunStatsData {
 
 // FinalRunStats is the snapshot passed to the run summary scene.
 type FinalRunStats struct {
-	Seed         int64
-	TotalRooms   int
-	RoomsCleared int
-	KillCount    int
-	ElapsedSecs  int64
+	Seed               int64
+	SeedCode           string
+	DailyDate          string
+	DailyScored        bool
+	TotalRooms         int
+	RoomsCleared       int
+	OptionalRooms      int
+	OptionalRoomsFound int
+	KillCount          int
+	Deaths             int
+	PerfectCatches     int
+	Modifiers          []components.BoomerangModifier // boomerang modifiers picked up, in pickup order
+	ElapsedSecs        int64
+	CauseOfDeath       string                 // source of the latest death; "" = no deaths
+	DeathChunk         string                 // chunk ID of the room of the latest death
+	Chunks             []string               // chunk ID of each main path room
+	Rooms              []components.RoomStats // per-room breakdown of the main path
+	Replay             bool                   // a playback of a recorded run, which isn't saved again
 }
 
 // SnapshotRunStats converts the live RunStatsData into a FinalRunStats.
 func SnapshotRunStats(e *ecs.ECS) FinalRunStats {
 	stats := GetOrCreateRunStats(e)
 	elapsedSecs := stats.ElapsedTicks / ticksPerSecond
+	chunks := make([]string, len(stats.Rooms))
+	for i, room := range stats.Rooms {
+		chunks[i] = room.ChunkID
+	}
+	deathChunk := ""
+	if stats.Deaths > 0 && stats.DeathRoom < len(chunks) {
+		deathChunk = chunks[stats.DeathRoom]
+	}
 	return FinalRunStats{
-		Seed:         stats.Seed,
-		TotalRooms:   stats.TotalRooms,
-		RoomsCleared: stats.RoomsCleared,
-		KillCount:    stats.KillCount,
-		ElapsedSecs:  elapsedSecs,
+		Seed:               stats.Seed,
+		SeedCode:           stats.SeedCode,
+		DailyDate:          stats.DailyDate,
+		DailyScored:        stats.DailyScored,
+		TotalRooms:         stats.TotalRooms,
+		RoomsCleared:       stats.RoomsCleared,
+		OptionalRooms:      len(stats.OptionalRooms),
+		OptionalRoomsFound: stats.OptionalRoomsFound,
+		KillCount:          stats.KillCount,
+		Deaths:             stats.Deaths,
+		PerfectCatches:     stats.PerfectCatches,
+		Modifiers:          append([]components.BoomerangModifier(nil), stats.Modifiers...),
+		ElapsedSecs:        elapsedSecs,
+		CauseOfDeath:       stats.CauseOfDeath,
+		DeathChunk:         deathChunk,
+		Chunks:             chunks,
+		Rooms:              append([]components.RoomStats(nil), stats.Rooms...),
+		Replay:             isReplayPlayback(e),
 	}
 }
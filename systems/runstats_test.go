@@ -1,10 +1,12 @@
 package systems_test
 
 import (
+	"slices"
 	"testing"
 
 	"github.com/automoto/doomerang/components"
 	cfg "github.com/automoto/doomerang/config"
+	"github.com/automoto/doomerang/procgen"
 	"github.com/automoto/doomerang/systems"
 	"github.com/automoto/doomerang/tags"
 	"github.com/solarlune/resolv"
@@ -17,9 +19,13 @@ func newTestECS() *ecs.ECS {
 }
 
 func addRunStats(e *ecs.ECS, boundaries []float64) *components.RunStatsData {
+	rooms := make([]components.RoomBoundary, len(boundaries))
+	for i, x := range boundaries {
+		rooms[i] = components.RoomBoundary{Edge: components.RoomExitRight, Pos: x}
+	}
 	entry := e.World.Entry(e.Create(cfg.Default, components.RunStats))
 	components.RunStats.SetValue(entry, components.RunStatsData{
-		RoomBoundaries: boundaries,
+		RoomBoundaries: rooms,
 	})
 	return components.RunStats.Get(entry)
 }
@@ -126,3 +132,170 @@ func TestRoomsNeverGoBackward(t *testing.T) {
 		t.Errorf("RoomsCleared should not decrease: expected 2, got %d", stats.RoomsCleared)
 	}
 }
+
+func TestRoomsClearedVerticalExit(t *testing.T) {
+	e := newTestECS()
+	entry := e.World.Entry(e.Create(cfg.Default, components.RunStats))
+	components.RunStats.SetValue(entry, components.RunStatsData{
+		RoomBoundaries: []components.RoomBoundary{
+			{Edge: components.RoomExitBottom, Pos: 374}, // drop shaft
+			{Edge: components.RoomExitRight, Pos: 640},
+		},
+	})
+	stats := components.RunStats.Get(entry)
+	playerEntry := addPlayer(e, 200, 300)
+
+	// Moving right inside the shaft doesn't clear it
+	components.Object.Get(playerEntry).X = 700
+	systems.UpdateRunStats(e)
+	if stats.RoomsCleared != 0 {
+		t.Fatalf("expected RoomsCleared=0 before dropping, got %d", stats.RoomsCleared)
+	}
+
+	// Dropping below the shaft clears it
+	components.Object.Get(playerEntry).X = 200
+	components.Object.Get(playerEntry).Y = 400
+	systems.UpdateRunStats(e)
+	if stats.RoomsCleared != 1 {
+		t.Errorf("expected RoomsCleared=1 after dropping, got %d", stats.RoomsCleared)
+	}
+}
+
+func TestOptionalRoomsFoundOnce(t *testing.T) {
+	e := newTestECS()
+	stats := addRunStats(e, []float64{320, 640})
+	stats.OptionalRooms = []components.RoomArea{{X: 320, Y: -320, Width: 320, Height: 320}}
+	playerEntry := addPlayer(e, 100, 200)
+
+	systems.UpdateRunStats(e)
+	if stats.OptionalRoomsFound != 0 {
+		t.Fatalf("expected OptionalRoomsFound=0 before entering, got %d", stats.OptionalRoomsFound)
+	}
+
+	// Entering the side room counts it; staying inside doesn't count again
+	components.Object.Get(playerEntry).X = 400
+	components.Object.Get(playerEntry).Y = -100
+	systems.UpdateRunStats(e)
+	systems.UpdateRunStats(e)
+	if stats.OptionalRoomsFound != 1 {
+		t.Errorf("expected OptionalRoomsFound=1, got %d", stats.OptionalRoomsFound)
+	}
+
+	if final := systems.SnapshotRunStats(e); final.OptionalRooms != 1 || final.OptionalRoomsFound != 1 {
+		t.Errorf("expected snapshot 1/1 optional rooms, got %d/%d", final.OptionalRoomsFound, final.OptionalRooms)
+	}
+}
+
+func TestSnapshotMarksReplays(t *testing.T) {
+	e := newTestECS()
+	addRunStats(e, nil)
+	if systems.SnapshotRunStats(e).Replay {
+		t.Error("expected a live run not to be marked as a replay")
+	}
+
+	entry := e.World.Entry(e.World.Create(components.ReplayState))
+	components.ReplayState.SetValue(entry, components.ReplayStateData{Replay: &components.ReplayData{}, Recording: true})
+	if systems.SnapshotRunStats(e).Replay {
+		t.Error("expected a run being recorded not to be marked as a replay")
+	}
+
+	components.ReplayState.Get(entry).Recording = false
+	if !systems.SnapshotRunStats(e).Replay {
+		t.Error("expected a playback to be marked as a replay")
+	}
+}
+
+func TestSnapshotDeathChunk(t *testing.T) {
+	e := newTestECS()
+	stats := addRunStats(e, []float64{320, 640})
+	stats.Rooms = []components.RoomStats{{ChunkID: "start_01"}, {ChunkID: "combat_02"}, {ChunkID: "exit_01"}}
+
+	if final := systems.SnapshotRunStats(e); final.DeathChunk != "" {
+		t.Errorf("expected no death chunk before dying, got %q", final.DeathChunk)
+	}
+
+	stats.Deaths = 1
+	stats.DeathRoom = 1
+	stats.CauseOfDeath = "Guard"
+	final := systems.SnapshotRunStats(e)
+	if final.CauseOfDeath != "Guard" || final.DeathChunk != "combat_02" {
+		t.Errorf("expected death to Guard in combat_02, got %q in %q", final.CauseOfDeath, final.DeathChunk)
+	}
+	if len(final.Chunks) != 3 {
+		t.Errorf("expected 3 chunks, got %d", len(final.Chunks))
+	}
+}
+
+func TestRoomStatsFollowPlayer(t *testing.T) {
+	e := newTestECS()
+	stats := addRunStats(e, []float64{320, 640})
+	stats.Rooms = []components.RoomStats{{ChunkID: "start_01"}, {ChunkID: "combat_02"}}
+	stats.RoomAreas = []components.RoomArea{{X: 0, Y: 0, Width: 320, Height: 320}, {X: 320, Y: 0, Width: 320, Height: 320}}
+	playerEntry := addPlayer(e, 100, 200)
+	enemy := e.World.Entry(e.Create(cfg.Default, tags.Enemy))
+
+	// Two frames in the first room, then the second
+	systems.UpdateRunStats(e)
+	systems.UpdateRunStats(e)
+	components.Object.Get(playerEntry).X = 400
+	systems.UpdateRunStats(e)
+
+	// Kill the enemy in the second room
+	e.World.Remove(enemy.Entity())
+	systems.UpdateRunStats(e)
+
+	// Past the final boundary the exit room keeps counting
+	components.Object.Get(playerEntry).X = 700
+	systems.UpdateRunStats(e)
+
+	// Walking back counts toward the room the player is in, not the furthest
+	// one reached
+	components.Object.Get(playerEntry).X = 100
+	systems.UpdateRunStats(e)
+
+	if got := stats.Rooms[0]; got.Ticks != 3 || got.Kills != 0 {
+		t.Errorf("expected room 1 to have 3 ticks and 0 kills, got %+v", got)
+	}
+	if got := stats.Rooms[1]; got.Ticks != 3 || got.Kills != 1 {
+		t.Errorf("expected room 2 to have 3 ticks and 1 kill, got %+v", got)
+	}
+
+	final := systems.SnapshotRunStats(e)
+	stats.Rooms[1].Kills = 99
+	if final.Rooms[1].Kills != 1 {
+		t.Error("expected the snapshot to keep its own copy of the room stats")
+	}
+}
+
+func TestNewRunStatsFromLevel(t *testing.T) {
+	result := &procgen.GenerationResult{PlacedChunks: []procgen.PlacedChunk{
+		{Chunk: &procgen.Chunk{ID: "drop", Width: 320, Height: 320}, ExitEdge: procgen.EdgeBottom, Node: &procgen.GraphNode{Type: procgen.NodeCombat}},
+		{Chunk: &procgen.Chunk{ID: "end", Width: 640, Height: 320}, OffsetY: 320},
+		{Chunk: &procgen.Chunk{ID: "side", Width: 320, Height: 320}, OffsetX: 320, Node: &procgen.GraphNode{Optional: true}},
+	}}
+
+	stats := systems.NewRunStats(result)
+	if stats.TotalRooms != 2 {
+		t.Errorf("TotalRooms = %d, want 2", stats.TotalRooms)
+	}
+	wantBoundaries := []components.RoomBoundary{
+		{Edge: components.RoomExitBottom, Pos: 320},
+		{Edge: components.RoomExitRight, Pos: 640},
+	}
+	if !slices.Equal(stats.RoomBoundaries, wantBoundaries) {
+		t.Errorf("RoomBoundaries = %+v, want %+v", stats.RoomBoundaries, wantBoundaries)
+	}
+	wantRooms := []components.RoomStats{
+		{ChunkID: "drop", NodeType: string(procgen.NodeCombat)},
+		{ChunkID: "end"},
+	}
+	if !slices.Equal(stats.Rooms, wantRooms) {
+		t.Errorf("Rooms = %+v, want %+v", stats.Rooms, wantRooms)
+	}
+	if len(stats.RoomAreas) != 2 || stats.RoomAreas[1] != (components.RoomArea{Y: 320, Width: 640, Height: 320}) {
+		t.Errorf("RoomAreas = %+v, want the two main path rooms", stats.RoomAreas)
+	}
+	if len(stats.OptionalRooms) != 1 || stats.OptionalRooms[0] != (components.RoomArea{X: 320, Width: 320, Height: 320}) {
+		t.Errorf("OptionalRooms = %+v, want the side room", stats.OptionalRooms)
+	}
+}
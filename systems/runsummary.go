@@ -16,11 +16,12 @@ type RunSummaryOption int
 
 const (
 	RunSummaryPlayAgain RunSummaryOption = iota
+	RunSummaryRooms
 	RunSummaryMainMenu
 )
 
 // runSummaryMenuOptions are the display strings for each option.
-var runSummaryMenuOptions = []string{"Play Again", "Main Menu"}
+var runSummaryMenuOptions = []string{"Play Again", "Room Breakdown", "Main Menu"}
 
 // GetOrCreateRunSummaryMenu returns the menu selection state for the run summary screen.
 // Intentionally reuses GameOverData — both screens need a single int selection field
@@ -29,6 +30,17 @@ func GetOrCreateRunSummaryMenu(e *ecs.ECS) *components.GameOverData {
 	return GetOrCreateGameOver(e)
 }
 
+// GetOrCreateRoomBreakdown returns the singleton RoomBreakdown component, creating if needed
+func GetOrCreateRoomBreakdown(e *ecs.ECS) *components.RoomBreakdownData {
+	if _, ok := components.RoomBreakdown.First(e.World); !ok {
+		ent := e.World.Entry(e.World.Create(components.RoomBreakdown))
+		components.RoomBreakdown.SetValue(ent, components.RoomBreakdownData{})
+	}
+
+	ent, _ := components.RoomBreakdown.First(e.World)
+	return components.RoomBreakdown.Get(ent)
+}
+
 // NewUpdateRunSummary creates the update system for the run summary screen.
 // It follows the same closure pattern as NewUpdateGameOver.
 func NewUpdateRunSummary(
@@ -52,6 +64,22 @@ func NewUpdateRunSummary(
 		menu := GetOrCreateRunSummaryMenu(e)
 		input := getOrCreateInput(e)
 
+		// The room table takes over input while open: up/down scroll, back or select close it
+		breakdown := GetOrCreateRoomBreakdown(e)
+		if breakdown.Showing {
+			maxScroll := len(stats.Rooms) - cfg.RunSummary.RoomsVisibleRows
+			if GetAction(input, cfg.ActionMenuUp).JustPressed && breakdown.ScrollOffset > 0 {
+				breakdown.ScrollOffset--
+			}
+			if GetAction(input, cfg.ActionMenuDown).JustPressed && breakdown.ScrollOffset < maxScroll {
+				breakdown.ScrollOffset++
+			}
+			if GetAction(input, cfg.ActionMenuBack).JustPressed || GetAction(input, cfg.ActionMenuSelect).JustPressed {
+				breakdown.Showing = false
+			}
+			return
+		}
+
 		numOptions := len(runSummaryMenuOptions)
 		if GetAction(input, cfg.ActionMenuUp).JustPressed {
 			menu.SelectedOption = components.GameOverOption(
@@ -68,6 +96,9 @@ func NewUpdateRunSummary(
 			switch RunSummaryOption(menu.SelectedOption) {
 			case RunSummaryPlayAgain:
 				sceneChanger.ChangeScene(createRogueliteScene())
+			case RunSummaryRooms:
+				breakdown.Showing = true
+				breakdown.ScrollOffset = 0
 			case RunSummaryMainMenu:
 				sceneChanger.ChangeScene(createMenuScene())
 			}
@@ -79,12 +110,36 @@ func NewUpdateRunSummary(
 // Formatted stat strings are pre-computed once at construction time since stats never change.
 func DrawRunSummary(stats FinalRunStats) func(e *ecs.ECS, screen *ebiten.Image) {
 	type statRow struct{ label, value string }
+	seed := stats.SeedCode
+	if seed == "" {
+		seed = fmt.Sprintf("#%05d", absInt64(stats.Seed)%100000)
+	}
+	modifiers := formatModifiers(stats.Modifiers, ", ")
+	if modifiers == "" {
+		modifiers = "None"
+	}
 	rows := []statRow{
 		{"Rooms Cleared", fmt.Sprintf("%d / %d", stats.RoomsCleared, stats.TotalRooms)},
-		{"Enemies Killed", fmt.Sprintf("%d", stats.KillCount)},
-		{"Time", fmt.Sprintf("%dm %02ds", stats.ElapsedSecs/60, stats.ElapsedSecs%60)},
-		{"Seed", fmt.Sprintf("#%05d", absInt64(stats.Seed)%100000)},
 	}
+	if stats.OptionalRooms > 0 {
+		rows = append(rows, statRow{"Side Rooms Found", fmt.Sprintf("%d / %d", stats.OptionalRoomsFound, stats.OptionalRooms)})
+	}
+	rows = append(rows,
+		statRow{"Enemies Killed", fmt.Sprintf("%d", stats.KillCount)},
+		statRow{"Perfect Catches", fmt.Sprintf("%d", stats.PerfectCatches)},
+		statRow{"Modifiers", modifiers},
+		statRow{"Time", fmt.Sprintf("%dm %02ds", stats.ElapsedSecs/60, stats.ElapsedSecs%60)},
+		statRow{"Seed", seed},
+	)
+	if stats.DailyDate != "" {
+		attempt := "Practice"
+		if stats.DailyScored {
+			attempt = "Scored"
+		}
+		rows = append(rows, statRow{"Daily " + stats.DailyDate, attempt})
+	}
+
+	drawRooms := drawRoomBreakdown(stats.Rooms)
 
 	return func(e *ecs.ECS, screen *ebiten.Image) {
 		menu := GetOrCreateRunSummaryMenu(e)
@@ -99,6 +154,11 @@ func DrawRunSummary(stats FinalRunStats) func(e *ecs.ECS, screen *ebiten.Image)
 			false,
 		)
 
+		if breakdown := GetOrCreateRoomBreakdown(e); breakdown.Showing {
+			drawRooms(e, screen, breakdown.ScrollOffset)
+			return
+		}
+
 		// Title
 		titleFont := fonts.ExcelTitle.GetV2()
 		titleX := centerTextX(cfg.RunSummary.Title, titleFont, width)
@@ -133,6 +193,99 @@ func DrawRunSummary(stats FinalRunStats) func(e *ecs.ECS, screen *ebiten.Image)
 	}
 }
 
+// drawRoomBreakdown returns a renderer for the per-room table. The slowest room's
+// time is highlighted and rooms the player died in are marked so problem rooms
+// stand out.
+func drawRoomBreakdown(rooms []components.RoomStats) func(e *ecs.ECS, screen *ebiten.Image, scroll int) {
+	type roomRow struct {
+		cells   [7]string
+		slowest bool
+		died    bool
+	}
+	slowest := -1
+	for i, room := range rooms {
+		if slowest < 0 || room.Ticks > rooms[slowest].Ticks {
+			slowest = i
+		}
+	}
+	rows := make([]roomRow, len(rooms))
+	for i, room := range rooms {
+		secs := room.Ticks / ticksPerSecond
+		rows[i] = roomRow{
+			cells: [7]string{
+				fmt.Sprintf("%d", i+1),
+				room.ChunkID,
+				room.NodeType,
+				fmt.Sprintf("%dm %02ds", secs/60, secs%60),
+				fmt.Sprintf("%d", room.Kills),
+				fmt.Sprintf("%d", room.DamageTaken),
+				fmt.Sprintf("%d", room.Deaths),
+			},
+			slowest: i == slowest && room.Ticks > 0,
+			died:    room.Deaths > 0,
+		}
+	}
+	header := [7]string{"#", "Room", "Type", "Time", "Kills", "Dmg", "Deaths"}
+	columns := [7]float64{
+		cfg.RunSummary.RoomsIndexX,
+		cfg.RunSummary.RoomsChunkX,
+		cfg.RunSummary.RoomsTypeX,
+		cfg.RunSummary.RoomsTimeX,
+		cfg.RunSummary.RoomsKillsX,
+		cfg.RunSummary.RoomsDamageX,
+		cfg.RunSummary.RoomsDeathsX,
+	}
+
+	return func(e *ecs.ECS, screen *ebiten.Image, scroll int) {
+		width := float64(screen.Bounds().Dx())
+		height := float64(screen.Bounds().Dy())
+
+		titleFont := fonts.ExcelTitle.GetV2()
+		titleX := centerTextX(cfg.RunSummary.RoomsTitle, titleFont, width)
+		drawText(screen, cfg.RunSummary.RoomsTitle, titleFont, titleX, int(cfg.RunSummary.TitleY), cfg.RunSummary.TitleColor)
+
+		smallFont := fonts.ExcelSmall.GetV2()
+		for col, label := range header {
+			drawText(screen, label, smallFont, int(columns[col]), int(cfg.RunSummary.RoomsHeaderY), cfg.RunSummary.LabelColor)
+		}
+
+		rowFont := fonts.ExcelBold.GetV2()
+		if len(rows) == 0 {
+			empty := "No room data for this run"
+			drawText(screen, empty, rowFont, centerTextX(empty, rowFont, width), int(cfg.RunSummary.RoomsStartY), cfg.RunSummary.ValueColor)
+		}
+		for i := scroll; i < len(rows) && i < scroll+cfg.RunSummary.RoomsVisibleRows; i++ {
+			row := rows[i]
+			y := int(cfg.RunSummary.RoomsStartY) + (i-scroll)*int(cfg.RunSummary.RoomsRowHeight)
+			for col, cell := range row.cells {
+				color := cfg.RunSummary.ValueColor
+				switch {
+				case col == 3 && row.slowest:
+					color = cfg.RunSummary.HighlightColor
+				case col == 6 && row.died:
+					color = cfg.RunSummary.DeathColor
+				}
+				drawText(screen, cell, rowFont, int(columns[col]), y, color)
+			}
+		}
+
+		input := getOrCreateInput(e)
+		hint := getRoomBreakdownHint(input.LastInputMethod)
+		drawText(screen, hint, smallFont, centerTextX(hint, smallFont, width), int(height)-12, cfg.RunSummary.TextColorNormal)
+	}
+}
+
+// getRoomBreakdownHint returns the appropriate hint for the room table
+func getRoomBreakdownHint(method components.InputMethod) string {
+	switch method {
+	case components.InputPlayStation:
+		return "D-Pad: Scroll   Circle: Back"
+	case components.InputXbox:
+		return "D-Pad: Scroll   B: Back"
+	}
+	return "Arrows: Scroll   Esc: Back"
+}
+
 func absInt64(n int64) int64 {
 	if n < 0 {
 		return -n
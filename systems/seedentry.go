@@ -0,0 +1,180 @@
+package systems
+
+import (
+	"errors"
+	"strings"
+	"unicode"
+
+	"github.com/automoto/doomerang/components"
+	cfg "github.com/automoto/doomerang/config"
+	"github.com/automoto/doomerang/fonts"
+	"github.com/automoto/doomerang/procgen"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/yohamta/donburi/ecs"
+)
+
+// GetOrCreateSeedEntry returns the singleton SeedEntry component, creating if needed
+func GetOrCreateSeedEntry(e *ecs.ECS) *components.SeedEntryData {
+	if _, ok := components.SeedEntry.First(e.World); !ok {
+		ent := e.World.Entry(e.World.Create(components.SeedEntry))
+		components.SeedEntry.SetValue(ent, components.SeedEntryData{})
+	}
+
+	ent, _ := components.SeedEntry.First(e.World)
+	return components.SeedEntry.Get(ent)
+}
+
+// TypeSeedCode applies typed characters and backspaces to the code being entered.
+// Only letters, digits and dashes are kept; letters are upper-cased.
+func TypeSeedCode(entry *components.SeedEntryData, chars []rune, backspace bool) {
+	if backspace && len(entry.Code) > 0 {
+		entry.Code = entry.Code[:len(entry.Code)-1]
+		entry.Invalid = false
+	}
+	for _, r := range chars {
+		if len(entry.Code) >= cfg.SeedEntry.MaxLength {
+			break
+		}
+		if r > unicode.MaxASCII || !(unicode.IsLetter(r) || unicode.IsDigit(r) || r == '-') {
+			continue
+		}
+		entry.Code += strings.ToUpper(string(r))
+		entry.Invalid = false
+	}
+}
+
+// seedCodeAlphabet is the characters a gamepad steps through, in seed code order
+const seedCodeAlphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// CycleSeedCodeChar steps the last character of the code step places through
+// the seed code alphabet, for entering codes without a keyboard. An empty code
+// starts at the first character.
+func CycleSeedCodeChar(entry *components.SeedEntryData, step int) {
+	entry.Invalid = false
+	if entry.Code == "" {
+		entry.Code = seedCodeAlphabet[:1]
+		return
+	}
+	last := len(entry.Code) - 1
+	n := len(seedCodeAlphabet)
+	next := ((strings.IndexByte(seedCodeAlphabet, entry.Code[last])+step)%n + n) % n
+	entry.Code = entry.Code[:last] + seedCodeAlphabet[next:next+1]
+}
+
+// NewUpdateSeedEntry creates the update system for the seed entry screen.
+// Confirming a valid code starts that run; back on an empty code returns to the menu.
+// Codes can be typed, pasted, or entered on a gamepad: up/down changes the last
+// character, right adds one and left deletes one.
+func NewUpdateSeedEntry(
+	sceneChanger SceneChanger,
+	createRogueliteScene func(opts procgen.RunOptions) interface{},
+	createMenuScene func() interface{},
+) ecs.System {
+	// Skip the first frame so the confirm press that opened this screen
+	// from the main menu isn't read as submitting an empty code.
+	firstFrame := true
+	var chars []rune
+	return func(e *ecs.ECS) {
+		if firstFrame {
+			firstFrame = false
+			return
+		}
+
+		entry := GetOrCreateSeedEntry(e)
+		input := getOrCreateInput(e)
+
+		// Backspace is also bound to menu back; it only leaves once the code is empty
+		backspace := inpututil.IsKeyJustPressed(ebiten.KeyBackspace)
+		if GetAction(input, cfg.ActionMenuBack).JustPressed && (!backspace || entry.Code == "") {
+			PlaySFX(e, cfg.SoundMenuNavigate)
+			sceneChanger.ChangeScene(createMenuScene())
+			return
+		}
+
+		chars = ebiten.AppendInputChars(chars[:0])
+		chars = append(chars, []rune(pastedText())...)
+		TypeSeedCode(entry, chars, backspace)
+
+		// The menu directions are also bound to WASD, which keyboard users type
+		if input.LastInputMethod != components.InputKeyboard {
+			switch {
+			case GetAction(input, cfg.ActionMenuUp).JustPressed:
+				CycleSeedCodeChar(entry, 1)
+			case GetAction(input, cfg.ActionMenuDown).JustPressed:
+				CycleSeedCodeChar(entry, -1)
+			case GetAction(input, cfg.ActionMenuRight).JustPressed:
+				TypeSeedCode(entry, []rune(seedCodeAlphabet[:1]), false)
+			case GetAction(input, cfg.ActionMenuLeft).JustPressed:
+				TypeSeedCode(entry, nil, true)
+			}
+		}
+
+		if GetAction(input, cfg.ActionMenuSelect).JustPressed {
+			opts, err := procgen.DecodeSeedCode(entry.Code)
+			if err != nil {
+				PlaySFX(e, cfg.SoundMenuNavigate)
+				entry.Invalid = true
+				entry.WrongPack = errors.Is(err, procgen.ErrSeedCodeLevelPack)
+				return
+			}
+			PlaySFX(e, cfg.SoundMenuSelect)
+			FadeOutMusic(e)
+			sceneChanger.ChangeScene(createRogueliteScene(opts))
+		}
+	}
+}
+
+// DrawSeedEntry renders the seed entry screen
+func DrawSeedEntry(e *ecs.ECS, screen *ebiten.Image) {
+	entry := GetOrCreateSeedEntry(e)
+	width := float64(screen.Bounds().Dx())
+	height := float64(screen.Bounds().Dy())
+
+	vector.FillRect(
+		screen,
+		0, 0,
+		float32(width), float32(height),
+		cfg.SeedEntry.BackgroundColor,
+		false,
+	)
+
+	titleFont := fonts.ExcelTitle.GetV2()
+	titleX := centerTextX(cfg.SeedEntry.Title, titleFont, width)
+	drawText(screen, cfg.SeedEntry.Title, titleFont, titleX, int(cfg.SeedEntry.TitleY), cfg.SeedEntry.TitleColor)
+
+	textFont := fonts.ExcelBold.GetV2()
+	promptX := centerTextX(cfg.SeedEntry.Prompt, textFont, width)
+	drawText(screen, cfg.SeedEntry.Prompt, textFont, promptX, int(cfg.SeedEntry.PromptY), cfg.SeedEntry.TextColor)
+
+	// Trailing cursor shows where the next character goes
+	code := entry.Code + "_"
+	codeX := centerTextX(code, titleFont, width)
+	drawText(screen, code, titleFont, codeX, int(cfg.SeedEntry.CodeY), cfg.SeedEntry.CodeColor)
+
+	if entry.Invalid {
+		message := cfg.SeedEntry.InvalidMessage
+		if entry.WrongPack {
+			message = cfg.SeedEntry.WrongPackMessage
+		}
+		errX := centerTextX(message, textFont, width)
+		drawText(screen, message, textFont, errX, int(cfg.SeedEntry.ErrorY), cfg.SeedEntry.ErrorColor)
+	}
+
+	hint := getSeedEntryHint(getOrCreateInput(e).LastInputMethod)
+	hintFont := fonts.ExcelSmall.GetV2()
+	hintX := centerTextX(hint, hintFont, width)
+	drawText(screen, hint, hintFont, hintX, int(height)-12, cfg.SeedEntry.TextColor)
+}
+
+// getSeedEntryHint returns the controls hint for the seed entry screen
+func getSeedEntryHint(method components.InputMethod) string {
+	switch method {
+	case components.InputPlayStation:
+		return "Up/Down: Letter   Left/Right: Delete/Add   Cross: Play   Circle: Back"
+	case components.InputXbox:
+		return "Up/Down: Letter   Left/Right: Delete/Add   A: Play   B: Back"
+	}
+	return "Enter: Play   Ctrl+V: Paste   Esc: Back"
+}
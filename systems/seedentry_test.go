@@ -0,0 +1,59 @@
+package systems_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/automoto/doomerang/components"
+	cfg "github.com/automoto/doomerang/config"
+	"github.com/automoto/doomerang/systems"
+)
+
+func TestTypeSeedCodeFiltersAndUppercases(t *testing.T) {
+	entry := &components.SeedEntryData{}
+	systems.TypeSeedCode(entry, []rune("ab3d-ef g!é"), false)
+	if entry.Code != "AB3D-EFG" {
+		t.Errorf("expected AB3D-EFG, got %q", entry.Code)
+	}
+
+	systems.TypeSeedCode(entry, nil, true)
+	if entry.Code != "AB3D-EF" {
+		t.Errorf("expected backspace to remove the last character, got %q", entry.Code)
+	}
+}
+
+func TestTypeSeedCodeClearsInvalid(t *testing.T) {
+	entry := &components.SeedEntryData{Code: "ZZZZ", Invalid: true}
+	systems.TypeSeedCode(entry, nil, true)
+	if entry.Invalid {
+		t.Error("editing the code should clear the invalid message")
+	}
+}
+
+func TestTypeSeedCodeMaxLength(t *testing.T) {
+	entry := &components.SeedEntryData{}
+	systems.TypeSeedCode(entry, []rune(strings.Repeat("A", cfg.SeedEntry.MaxLength+5)), false)
+	if len(entry.Code) != cfg.SeedEntry.MaxLength {
+		t.Errorf("expected code capped at %d characters, got %d", cfg.SeedEntry.MaxLength, len(entry.Code))
+	}
+}
+
+func TestCycleSeedCodeChar(t *testing.T) {
+	entry := &components.SeedEntryData{Invalid: true}
+
+	systems.CycleSeedCodeChar(entry, 1)
+	if entry.Code != "0" || entry.Invalid {
+		t.Errorf("expected an empty code to start at 0, got %q (invalid %v)", entry.Code, entry.Invalid)
+	}
+
+	// Wraps around the alphabet in both directions, skipping I, L, O and U
+	systems.CycleSeedCodeChar(entry, -1)
+	if entry.Code != "Z" {
+		t.Errorf("expected 0 to step back to Z, got %q", entry.Code)
+	}
+	entry.Code = "AB-H"
+	systems.CycleSeedCodeChar(entry, 1)
+	if entry.Code != "AB-J" {
+		t.Errorf("expected H to step to J, got %q", entry.Code)
+	}
+}
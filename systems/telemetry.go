@@ -0,0 +1,130 @@
+package systems
+
+import (
+	"bufio"
+	"encoding/json"
+	"log"
+	"os"
+
+	"github.com/automoto/doomerang/components"
+	cfg "github.com/automoto/doomerang/config"
+	"github.com/automoto/doomerang/tags"
+	"github.com/yohamta/donburi"
+	"github.com/yohamta/donburi/ecs"
+)
+
+// telemetryLog is the open telemetry file, if any. Kept at package level so
+// one file collects every scene of a session and can be flushed on exit.
+var telemetryLog struct {
+	out *bufio.Writer
+	enc *json.Encoder
+}
+
+// UpdateTelemetry delivers the gameplay events published this frame to their
+// subscribers. It runs while paused and after the level is complete so the
+// events that end a level are still delivered, but only counts gameplay ticks.
+func UpdateTelemetry(e *ecs.ECS) {
+	components.GameplayEvent.ProcessEvents(e.World)
+	if !GetOrCreatePause(e).IsPaused && !IsLevelComplete(e) {
+		getOrCreateTelemetry(e).Tick++
+	}
+}
+
+// StartTelemetry appends the gameplay events of the scene in e to
+// cfg.Debug.TelemetryPath, one JSON object per line. label names the level or
+// run being played and is logged as the scene's first event. Does nothing
+// when telemetry is off.
+func StartTelemetry(e *ecs.ECS, label string) {
+	if cfg.Debug.TelemetryPath == "" {
+		return
+	}
+	if telemetryLog.out == nil {
+		f, err := os.OpenFile(cfg.Debug.TelemetryPath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+		if err != nil {
+			log.Printf("Warning: Could not open telemetry log: %v", err)
+			cfg.Debug.TelemetryPath = ""
+			return
+		}
+		telemetryLog.out = bufio.NewWriter(f)
+		telemetryLog.enc = json.NewEncoder(telemetryLog.out)
+	}
+
+	components.GameplayEvent.Subscribe(e.World, writeTelemetryEvent)
+	publishGameplayEvent(e, nil, components.GameplayEventData{
+		Kind:   components.EventLevelStart,
+		Detail: label,
+	})
+}
+
+// FlushTelemetry writes buffered telemetry events to disk.
+// Safe to call when telemetry is off.
+func FlushTelemetry() {
+	if telemetryLog.out == nil {
+		return
+	}
+	if err := telemetryLog.out.Flush(); err != nil {
+		log.Printf("Warning: Could not write telemetry log: %v", err)
+	}
+}
+
+func writeTelemetryEvent(_ donburi.World, event components.GameplayEventData) {
+	if err := telemetryLog.enc.Encode(event); err != nil {
+		log.Printf("Warning: Could not write telemetry event: %v", err)
+	}
+}
+
+// publishGameplayEvent queues event for delivery at the end of the frame,
+// stamped with the current tick. If entry is set, the event is about that
+// entity and takes its type, the center of its collision box and, in a
+// generated level, the chunk that point is in.
+func publishGameplayEvent(e *ecs.ECS, entry *donburi.Entry, event components.GameplayEventData) {
+	event.Tick = getOrCreateTelemetry(e).Tick
+	if entry != nil && entry.Valid() {
+		event.Entity = gameplayEntityName(entry)
+		if entry.HasComponent(components.Object) {
+			obj := components.Object.Get(entry)
+			event.X = obj.X + obj.W/2
+			event.Y = obj.Y + obj.H/2
+			setEventChunk(e, &event)
+		}
+	}
+	components.GameplayEvent.Publish(e.World, event)
+}
+
+// setEventChunk records which chunk of a generated level the event's position is in
+func setEventChunk(e *ecs.ECS, event *components.GameplayEventData) {
+	levelEntry, ok := components.Level.First(e.World)
+	if !ok || components.Level.Get(levelEntry).CurrentLevel == nil {
+		return
+	}
+	for _, room := range components.Level.Get(levelEntry).CurrentLevel.Rooms {
+		if room.ChunkID != "" && room.Contains(event.X, event.Y) {
+			event.Chunk = room.ChunkID
+			event.ChunkX = event.X - room.X
+			event.ChunkY = event.Y - room.Y
+			return
+		}
+	}
+}
+
+// gameplayEntityName returns the type name telemetry uses for an entity
+func gameplayEntityName(entry *donburi.Entry) string {
+	switch {
+	case entry.HasComponent(tags.Player):
+		return "Player"
+	case entry.HasComponent(components.Enemy):
+		return components.Enemy.Get(entry).TypeName
+	case entry.HasComponent(components.Boomerang):
+		return "Boomerang"
+	}
+	return ""
+}
+
+func getOrCreateTelemetry(e *ecs.ECS) *components.TelemetryData {
+	if _, ok := components.Telemetry.First(e.World); !ok {
+		ent := e.World.Entry(e.World.Create(components.Telemetry))
+		components.Telemetry.SetValue(ent, components.TelemetryData{})
+	}
+	ent, _ := components.Telemetry.First(e.World)
+	return components.Telemetry.Get(ent)
+}
@@ -0,0 +1,137 @@
+package systems_test
+
+import (
+	"testing"
+
+	"github.com/automoto/doomerang/assets"
+	"github.com/automoto/doomerang/components"
+	cfg "github.com/automoto/doomerang/config"
+	"github.com/automoto/doomerang/systems"
+	"github.com/yohamta/donburi"
+)
+
+func TestTelemetryDeliversRoomEnter(t *testing.T) {
+	e := newTestECS()
+	stats := addRunStats(e, []float64{320, 640})
+	stats.Rooms = []components.RoomStats{{ChunkID: "start_01"}, {ChunkID: "combat_02"}}
+	playerEntry := addPlayer(e, 100, 200)
+
+	var got []components.GameplayEventData
+	components.GameplayEvent.Subscribe(e.World, func(_ donburi.World, event components.GameplayEventData) {
+		got = append(got, event)
+	})
+
+	for i := 0; i < 3; i++ {
+		systems.UpdateRunStats(e)
+		systems.UpdateTelemetry(e)
+	}
+	if len(got) != 0 {
+		t.Fatalf("expected no events before leaving the first room, got %+v", got)
+	}
+
+	components.Object.Get(playerEntry).X = 400
+	systems.UpdateRunStats(e)
+	// Events wait for UpdateTelemetry to be delivered
+	if len(got) != 0 {
+		t.Fatal("expected events to be queued until UpdateTelemetry runs")
+	}
+	systems.UpdateTelemetry(e)
+
+	if len(got) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(got))
+	}
+	event := got[0]
+	if event.Kind != components.EventRoomEnter || event.Amount != 1 || event.Detail != "combat_02" {
+		t.Errorf("expected room_enter for room 1 (combat_02), got %+v", event)
+	}
+	if event.Entity != "Player" || event.Tick != 3 {
+		t.Errorf("expected the player at tick 3, got %q at tick %d", event.Entity, event.Tick)
+	}
+}
+
+func TestTelemetryTickStopsWhilePaused(t *testing.T) {
+	e := newTestECS()
+	stats := addRunStats(e, []float64{320})
+	stats.Rooms = []components.RoomStats{{ChunkID: "start_01"}, {ChunkID: "exit_01"}}
+	playerEntry := addPlayer(e, 100, 200)
+
+	var ticks []int64
+	components.GameplayEvent.Subscribe(e.World, func(_ donburi.World, event components.GameplayEventData) {
+		ticks = append(ticks, event.Tick)
+	})
+
+	systems.UpdateTelemetry(e)
+	systems.GetOrCreatePause(e).IsPaused = true
+	systems.UpdateTelemetry(e)
+	systems.UpdateTelemetry(e)
+	systems.GetOrCreatePause(e).IsPaused = false
+
+	components.Object.Get(playerEntry).X = 400
+	systems.UpdateRunStats(e)
+	systems.UpdateTelemetry(e)
+
+	if len(ticks) != 1 || ticks[0] != 1 {
+		t.Errorf("expected one event at tick 1, got %v", ticks)
+	}
+}
+
+func TestTelemetryEventsCarryChunk(t *testing.T) {
+	e := newTestECS()
+	stats := addRunStats(e, []float64{320})
+	stats.Rooms = []components.RoomStats{{ChunkID: "start_01"}, {ChunkID: "combat_02"}}
+	level := &assets.Level{Rooms: []assets.Room{
+		{X: 0, Y: 0, Width: 320, Height: 320, ChunkID: "start_01"},
+		{X: 320, Y: 0, Width: 320, Height: 320, ChunkID: "combat_02"},
+	}}
+	levelEntry := e.World.Entry(e.Create(cfg.Default, components.Level))
+	components.Level.SetValue(levelEntry, components.LevelData{CurrentLevel: level})
+	playerEntry := addPlayer(e, 100, 200)
+
+	var got []components.GameplayEventData
+	components.GameplayEvent.Subscribe(e.World, func(_ donburi.World, event components.GameplayEventData) {
+		got = append(got, event)
+	})
+
+	components.Object.Get(playerEntry).X = 400
+	systems.UpdateRunStats(e)
+	systems.UpdateTelemetry(e)
+
+	if len(got) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(got))
+	}
+	// The player's center is at (408, 220), 88 pixels into the second room
+	if event := got[0]; event.Chunk != "combat_02" || event.ChunkX != 88 || event.ChunkY != 220 {
+		t.Errorf("expected the event at (88, 220) in combat_02, got %q at (%v, %v)", event.Chunk, event.ChunkX, event.ChunkY)
+	}
+}
+
+func TestHeatmapCells(t *testing.T) {
+	events := []components.GameplayEventData{
+		{Kind: components.EventLevelStart, Detail: "levels/level1.tmx"},
+		{Kind: components.EventDeath, X: 40, Y: 40},
+		{Kind: components.EventBoomerangThrow, X: 200, Y: 40},
+		{Kind: components.EventLevelStart, Detail: "roguelite 22H0-BC34-0C"},
+		{Kind: components.EventDeath, X: 1000, Y: 40, Chunk: "combat_02", ChunkX: 40, ChunkY: 40},
+		{Kind: components.EventDamageTaken, X: 1000, Y: 40, Chunk: "combat_02", ChunkX: 40, ChunkY: 40},
+	}
+	size := cfg.Heatmap.CellSize
+
+	cells := systems.HeatmapCells(&assets.Level{Name: "levels/level1.tmx"}, events)
+	if len(cells) != 1 || cells[0].X != size || cells[0].Y != size {
+		t.Fatalf("expected only the death in the campaign level, got %+v", cells)
+	}
+
+	// A later run placed combat_02 elsewhere; its heat follows the chunk
+	generated := &assets.Level{Rooms: []assets.Room{
+		{X: 0, Y: 0, Width: 320, Height: 320, ChunkID: "start_01"},
+		{X: 320, Y: 0, Width: 320, Height: 320, ChunkID: "combat_02"},
+	}}
+	cells = systems.HeatmapCells(generated, events)
+	if len(cells) != 1 || cells[0].X != 320+size || cells[0].Y != size {
+		t.Fatalf("expected one cell offset into the combat_02 room, got %+v", cells)
+	}
+
+	if cells := systems.HeatmapCells(&assets.Level{Name: "levels/level2.tmx"}, events); cells != nil {
+		t.Errorf("expected no cells for a level without events, got %+v", cells)
+	}
+}
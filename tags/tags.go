@@ -14,6 +14,7 @@ var (
 	Fire             = donburi.NewTag().SetName("Fire")
 	Knife            = donburi.NewTag().SetName("Knife")
 	FinishLine       = donburi.NewTag().SetName("FinishLine")
+	Reward           = donburi.NewTag().SetName("Reward")
 )
 
 // Resolv tags for physics collision
@@ -28,6 +29,7 @@ const (
 	ResolvFire       = "fire"
 	ResolvKnife      = "Knife"
 	ResolvFinishLine = "finishline"
+	ResolvReward     = "reward"
 
 	// Slope type tags
 	Slope45UpRight = "45_up_right"
@@ -0,0 +1,64 @@
+package telemetry
+
+import (
+	"image"
+	"image/color"
+	"math"
+)
+
+// Grid counts events per square cell of world space
+type Grid struct {
+	CellSize float64
+	Counts   map[image.Point]int // events per cell; cell (i, j) covers [i*CellSize, (i+1)*CellSize)
+	Max      int                 // highest count in any cell
+}
+
+// NewGrid returns an empty grid with cells cellSize world pixels wide
+func NewGrid(cellSize float64) *Grid {
+	return &Grid{CellSize: cellSize, Counts: map[image.Point]int{}}
+}
+
+// Add counts an event at world point (x, y)
+func (g *Grid) Add(x, y float64) {
+	cell := image.Pt(int(math.Floor(x/g.CellSize)), int(math.Floor(y/g.CellSize)))
+	g.Counts[cell]++
+	g.Max = max(g.Max, g.Counts[cell])
+}
+
+// Empty reports whether no events were counted
+func (g *Grid) Empty() bool {
+	return g.Max == 0
+}
+
+// Bounds returns the smallest rectangle of cells holding every counted event
+func (g *Grid) Bounds() image.Rectangle {
+	var bounds image.Rectangle
+	first := true
+	for cell := range g.Counts {
+		r := image.Rectangle{Min: cell, Max: cell.Add(image.Pt(1, 1))}
+		if first {
+			bounds, first = r, false
+		} else {
+			bounds = bounds.Union(r)
+		}
+	}
+	return bounds
+}
+
+// Color returns the heat color of a cell with count events, relative to the
+// grid's hottest cell
+func (g *Grid) Color(count int) color.RGBA {
+	if g.Max == 0 {
+		return HeatColor(0)
+	}
+	return HeatColor(float64(count) / float64(g.Max))
+}
+
+// HeatColor maps 0..1 to a dark red → red → yellow → white ramp
+func HeatColor(t float64) color.RGBA {
+	t = math.Sqrt(math.Max(0, math.Min(1, t))) // lift single events out of the dark end
+	channel := func(start float64) uint8 {
+		return uint8(255 * math.Max(0, math.Min(1, (t-start)*3)))
+	}
+	return color.RGBA{R: max(channel(0), 80), G: channel(1.0 / 3), B: channel(2.0 / 3), A: 255}
+}
@@ -0,0 +1,95 @@
+// Package telemetry reads gameplay event logs written with the game's
+// -telemetry flag and aggregates where events happened into heatmaps. It is
+// shared by the in-game debug overlay and cmd/telemetry.
+package telemetry
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/automoto/doomerang/components"
+)
+
+// ReadEvents decodes a telemetry log, one JSON event per line
+func ReadEvents(r io.Reader) ([]components.GameplayEventData, error) {
+	var events []components.GameplayEventData
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var event components.GameplayEventData
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		events = append(events, event)
+	}
+	return events, scanner.Err()
+}
+
+// LoadEvents reads the telemetry log at path
+func LoadEvents(path string) ([]components.GameplayEventData, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ReadEvents(f)
+}
+
+// Kinds is a set of event kinds to plot
+type Kinds map[components.GameplayEventKind]bool
+
+// DefaultKinds are the events heatmaps show unless told otherwise: where the
+// player dies and gets hurt
+var DefaultKinds = Kinds{
+	components.EventDeath:       true,
+	components.EventDamageTaken: true,
+}
+
+// LevelHeat returns the heat of the chosen events logged while level was
+// being played, in level coordinates. level is the detail of the level_start
+// event, e.g. "levels/level1.tmx".
+func LevelHeat(events []components.GameplayEventData, level string, kinds Kinds, cellSize float64) *Grid {
+	grid := NewGrid(cellSize)
+	current := ""
+	for _, event := range events {
+		if event.Kind == components.EventLevelStart {
+			current = event.Detail
+			continue
+		}
+		if current == level && kinds[event.Kind] {
+			grid.Add(event.X, event.Y)
+		}
+	}
+	return grid
+}
+
+// ChunkHeat returns the heat of the chosen events that happened inside chunk,
+// in chunk coordinates, across every run that used it.
+func ChunkHeat(events []components.GameplayEventData, chunk string, kinds Kinds, cellSize float64) *Grid {
+	grid := NewGrid(cellSize)
+	for _, event := range events {
+		if event.Chunk == chunk && kinds[event.Kind] {
+			grid.Add(event.ChunkX, event.ChunkY)
+		}
+	}
+	return grid
+}
+
+// Chunks returns the IDs of every chunk events were logged in, in first-seen order
+func Chunks(events []components.GameplayEventData) []string {
+	var ids []string
+	seen := map[string]bool{}
+	for _, event := range events {
+		if event.Chunk != "" && !seen[event.Chunk] {
+			seen[event.Chunk] = true
+			ids = append(ids, event.Chunk)
+		}
+	}
+	return ids
+}
@@ -0,0 +1,73 @@
+package telemetry
+
+import (
+	"image"
+	"strings"
+	"testing"
+)
+
+const testLog = `{"kind":"level_start","tick":0,"x":0,"y":0,"entity":"","detail":"levels/level1.tmx"}
+{"kind":"damage_taken","tick":120,"x":300,"y":100,"entity":"Player","amount":20,"detail":"Guard"}
+{"kind":"death","tick":180,"x":300,"y":100,"entity":"Player","detail":"Guard"}
+{"kind":"level_start","tick":0,"x":0,"y":0,"entity":"","detail":"roguelite 22H0-BC34-0C"}
+{"kind":"death","tick":500,"x":1000,"y":100,"entity":"Player","detail":"Fire","chunk":"combat_01","chunkX":40,"chunkY":100}
+{"kind":"boomerang_throw","tick":510,"x":1000,"y":100,"entity":"Player","chunk":"combat_01","chunkX":40,"chunkY":100}
+{"kind":"level_start","tick":0,"x":0,"y":0,"entity":"","detail":"roguelite 3B1Z-0000-7Q"}
+{"kind":"death","tick":90,"x":2100,"y":60,"entity":"Player","detail":"Guard","chunk":"combat_01","chunkX":50,"chunkY":90}
+{"kind":"level_start","tick":0,"x":0,"y":0,"entity":"","detail":"levels/level1.tmx"}
+{"kind":"death","tick":60,"x":-10,"y":110,"entity":"Player","detail":"Fire"}
+`
+
+func TestLevelHeat(t *testing.T) {
+	events, err := ReadEvents(strings.NewReader(testLog))
+	if err != nil {
+		t.Fatalf("ReadEvents failed: %v", err)
+	}
+
+	grid := LevelHeat(events, "levels/level1.tmx", DefaultKinds, 32)
+	if grid.Counts[image.Pt(9, 3)] != 2 || grid.Max != 2 {
+		t.Errorf("expected the damage and death at (300, 100) in one cell, got %v", grid.Counts)
+	}
+	// Events left of the origin land in negative cells rather than cell 0
+	if grid.Counts[image.Pt(-1, 3)] != 1 {
+		t.Errorf("expected the death at x=-10 in cell (-1, 3), got %v", grid.Counts)
+	}
+	if want := image.Rect(-1, 3, 10, 4); grid.Bounds() != want {
+		t.Errorf("expected bounds %v, got %v", want, grid.Bounds())
+	}
+
+	if !LevelHeat(events, "levels/level2.tmx", DefaultKinds, 32).Empty() {
+		t.Error("expected no heat for a level that was never played")
+	}
+}
+
+func TestChunkHeat(t *testing.T) {
+	events, _ := ReadEvents(strings.NewReader(testLog))
+
+	if chunks := Chunks(events); len(chunks) != 1 || chunks[0] != "combat_01" {
+		t.Fatalf("expected only combat_01, got %v", chunks)
+	}
+	grid := ChunkHeat(events, "combat_01", Kinds{"death": true}, 32)
+	if grid.Counts[image.Pt(1, 3)] != 1 || grid.Counts[image.Pt(1, 2)] != 1 || len(grid.Counts) != 2 {
+		t.Errorf("expected one death per run in chunk coordinates, got %v", grid.Counts)
+	}
+	// The two runs placed the chunk at different world positions, but with
+	// bigger cells both deaths fall in the same cell of the chunk
+	grid = ChunkHeat(events, "combat_01", Kinds{"death": true}, 64)
+	if grid.Counts[image.Pt(0, 1)] != 2 || len(grid.Counts) != 1 {
+		t.Errorf("expected both runs' deaths in one cell, got %v", grid.Counts)
+	}
+}
+
+func TestHeatColor(t *testing.T) {
+	grid := NewGrid(32)
+	grid.Add(0, 0)
+	grid.Add(0, 0)
+	grid.Add(100, 0)
+	if grid.Color(1) == grid.Color(2) {
+		t.Error("expected the hottest cell to be colored differently from a colder one")
+	}
+	if c := grid.Color(2); c != HeatColor(1) {
+		t.Errorf("expected the hottest cell to use the top of the ramp, got %v", c)
+	}
+}